@@ -0,0 +1,165 @@
+package veneur
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/stripe/veneur/samplers"
+)
+
+// CardinalityLimit is a budget on the number of distinct timeseries that a
+// worker will create for each metric name in an interval.
+type CardinalityLimit struct {
+	// MetricName is a glob (as in path.Match) selecting the metric names
+	// the limit applies to. Every matching name has a budget of its own.
+	// If empty, the limit applies to every metric name.
+	MetricName string
+	// TagKey, if set, limits the number of distinct values of that tag
+	// for each metric name, rather than the number of timeseries.
+	TagKey string
+	// Limit is the budget for each metric name.
+	Limit int
+	// Collapse merges the timeseries beyond the budget into an overflow
+	// series instead of dropping them. The overflow series has the
+	// offending tag stripped or, without a TagKey, all of its tags.
+	Collapse bool
+}
+
+// NewCardinalityLimit creates a CardinalityLimit from its configuration.
+// The action is either "drop" (the default) or "collapse".
+func NewCardinalityLimit(metricName, tagKey string, limit int, action string) (CardinalityLimit, error) {
+	cl := CardinalityLimit{MetricName: metricName, TagKey: tagKey, Limit: limit}
+	if _, err := path.Match(metricName, ""); err != nil {
+		return cl, fmt.Errorf("invalid cardinality limit metric name %q: %v", metricName, err)
+	}
+	if limit <= 0 {
+		return cl, fmt.Errorf("cardinality limit for %q must be positive, not %d", metricName, limit)
+	}
+	switch action {
+	case "", "drop":
+	case "collapse":
+		cl.Collapse = true
+	default:
+		return cl, fmt.Errorf("unknown cardinality limit action %q", action)
+	}
+	return cl, nil
+}
+
+func (cl CardinalityLimit) matches(name string) bool {
+	if cl.MetricName == "" {
+		return true
+	}
+	ok, _ := path.Match(cl.MetricName, name)
+	return ok
+}
+
+// cardinalityLimiter enforces CardinalityLimits on the metrics a worker
+// creates in an interval. It is not safe for concurrent use; workers only
+// use it while holding their mutex.
+type cardinalityLimiter struct {
+	limits []CardinalityLimit
+	// seen holds the tags (or tag values) admitted for each limit and
+	// metric name in this interval. Names that a limit doesn't match map
+	// to nil, to avoid matching them again.
+	seen []map[string]map[string]struct{}
+	// limited counts the timeseries dropped or collapsed in this interval,
+	// by metric name
+	limited map[string]int64
+}
+
+func newCardinalityLimiter(limits []CardinalityLimit) *cardinalityLimiter {
+	cl := &cardinalityLimiter{limits: limits}
+	cl.reset()
+	return cl
+}
+
+// reset starts a new interval, returning the number of timeseries limited
+// in the last one.
+func (cl *cardinalityLimiter) reset() map[string]int64 {
+	limited := cl.limited
+	cl.seen = make([]map[string]map[string]struct{}, len(cl.limits))
+	for i := range cl.seen {
+		cl.seen[i] = map[string]map[string]struct{}{}
+	}
+	cl.limited = map[string]int64{}
+	return limited
+}
+
+// admit applies every limit to a metric, in order. It returns the key and
+// tags that the metric should be recorded under, which differ from the
+// metric's own if it was collapsed, or false if it should be dropped.
+func (cl *cardinalityLimiter) admit(mk samplers.MetricKey, tags []string) (samplers.MetricKey, []string, bool) {
+	if cl == nil {
+		return mk, tags, true
+	}
+	for i, limit := range cl.limits {
+		byName, ok := cl.seen[i][mk.Name]
+		if !ok {
+			if limit.matches(mk.Name) {
+				byName = map[string]struct{}{}
+			}
+			cl.seen[i][mk.Name] = byName
+		}
+		if byName == nil {
+			continue
+		}
+
+		// without a tag key, each distinct set of tags counts against the
+		// budget
+		member := mk.JoinedTags
+		if limit.TagKey != "" {
+			var ok bool
+			if member, ok = tagValue(tags, limit.TagKey); !ok {
+				continue
+			}
+		}
+		if _, ok := byName[member]; ok {
+			continue
+		}
+		if len(byName) < limit.Limit {
+			byName[member] = struct{}{}
+			continue
+		}
+
+		cl.limited[mk.Name]++
+		if !limit.Collapse {
+			return mk, tags, false
+		}
+		tags = stripTags(tags, limit.TagKey)
+		mk.JoinedTags = strings.Join(tags, ",")
+	}
+	return mk, tags, true
+}
+
+// tagValue returns the value of the tag with the given key, if the tags
+// contain it.
+func tagValue(tags []string, key string) (string, bool) {
+	for _, tag := range tags {
+		if tag == key {
+			return "", true
+		}
+		if strings.HasPrefix(tag, key) && len(tag) > len(key) && tag[len(key)] == ':' {
+			return tag[len(key)+1:], true
+		}
+	}
+	return "", false
+}
+
+// stripTags returns a copy of tags without the tag with the given key, or
+// without any tags if key is empty. Tags that route the metric to specific
+// sinks are always kept.
+func stripTags(tags []string, key string) []string {
+	ret := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if strings.HasPrefix(tag, "veneursinkonly:") {
+			ret = append(ret, tag)
+			continue
+		}
+		if key == "" || tag == key || strings.HasPrefix(tag, key+":") {
+			continue
+		}
+		ret = append(ret, tag)
+	}
+	return ret
+}
@@ -0,0 +1,93 @@
+package veneur
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/veneur/samplers"
+)
+
+func TestCardinalityLimiterDrop(t *testing.T) {
+	limit, err := NewCardinalityLimit("api.*", "", 2, "")
+	require.NoError(t, err)
+	cl := newCardinalityLimiter([]CardinalityLimit{limit})
+
+	admit := func(name string, tags ...string) bool {
+		mk := samplers.MetricKey{Name: name, Type: counterTypeName, JoinedTags: fmt.Sprint(tags)}
+		_, _, ok := cl.admit(mk, tags)
+		return ok
+	}
+	assert.True(t, admit("api.hits", "request_id:1"))
+	assert.True(t, admit("api.hits", "request_id:2"))
+	assert.True(t, admit("api.hits", "request_id:1"), "admitted timeseries should stay admitted")
+	assert.False(t, admit("api.hits", "request_id:3"))
+	assert.True(t, admit("api.errors", "request_id:3"), "each metric name should have its own budget")
+	assert.True(t, admit("db.hits", "request_id:3"), "unmatched names shouldn't be limited")
+	assert.True(t, admit("db.hits", "request_id:4"))
+	assert.True(t, admit("db.hits", "request_id:5"))
+
+	assert.Equal(t, map[string]int64{"api.hits": 1}, cl.reset())
+	assert.True(t, admit("api.hits", "request_id:3"), "budgets should reset every interval")
+}
+
+func TestCardinalityLimiterCollapseTag(t *testing.T) {
+	limit, err := NewCardinalityLimit("", "request_id", 1, "collapse")
+	require.NoError(t, err)
+	cl := newCardinalityLimiter([]CardinalityLimit{limit})
+
+	tags := []string{"env:prod", "request_id:1", "veneursinkonly:datadog"}
+	mk := samplers.MetricKey{Name: "api.hits", Type: counterTypeName, JoinedTags: "env:prod,request_id:1,veneursinkonly:datadog"}
+	key, got, ok := cl.admit(mk, tags)
+	assert.True(t, ok)
+	assert.Equal(t, mk, key)
+	assert.Equal(t, tags, got)
+
+	key, got, ok = cl.admit(samplers.MetricKey{Name: "api.hits", Type: counterTypeName, JoinedTags: "env:prod,request_id:2,veneursinkonly:datadog"},
+		[]string{"env:prod", "request_id:2", "veneursinkonly:datadog"})
+	assert.True(t, ok, "collapsed timeseries should be admitted")
+	assert.Equal(t, []string{"env:prod", "veneursinkonly:datadog"}, got)
+	assert.Equal(t, "env:prod,veneursinkonly:datadog", key.JoinedTags)
+
+	_, _, ok = cl.admit(samplers.MetricKey{Name: "api.hits", Type: counterTypeName, JoinedTags: "env:prod"}, []string{"env:prod"})
+	assert.True(t, ok, "metrics without the tag key shouldn't count against its budget")
+}
+
+func TestNewCardinalityLimitErrors(t *testing.T) {
+	_, err := NewCardinalityLimit("api.[", "", 1, "")
+	assert.Error(t, err)
+	_, err = NewCardinalityLimit("", "", 0, "")
+	assert.Error(t, err)
+	_, err = NewCardinalityLimit("", "", 1, "sample")
+	assert.Error(t, err)
+}
+
+func TestWorkerCardinalityLimits(t *testing.T) {
+	w := NewWorker(1, true, false, nil, logrus.New(), nil)
+	byName, err := NewCardinalityLimit("api.hits", "", 2, "collapse")
+	require.NoError(t, err)
+	w.SetCardinalityLimits([]CardinalityLimit{byName})
+
+	for i := 0; i < 5; i++ {
+		m, err := samplers.ParseMetric([]byte(fmt.Sprintf("api.hits:1|c|#request_id:%d", i)))
+		require.NoError(t, err)
+		w.ProcessMetric(m)
+	}
+	// an imported metric beyond the budget is collapsed too:
+	jsonMetric, err := samplers.NewCounter("api.hits", []string{"request_id:5"}).Export()
+	require.NoError(t, err)
+	w.ImportMetric(jsonMetric)
+
+	wm := w.Flush()
+	assert.Len(t, wm.counters, 3, "two counters within the budget, and the overflow")
+	require.Len(t, wm.globalCounters, 1)
+	overflow, ok := wm.counters[samplers.MetricKey{Name: "api.hits", Type: counterTypeName}]
+	require.True(t, ok, "counters beyond the budget should be collapsed into an overflow series")
+	assert.Empty(t, overflow.Tags)
+	assert.Equal(t, float64(3), overflow.Flush(0)[0].Value)
+	for _, c := range wm.globalCounters {
+		assert.Empty(t, c.Tags)
+	}
+}
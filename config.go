@@ -1,87 +1,142 @@
 package veneur
 
 type Config struct {
-	Aggregates                             []string `yaml:"aggregates"`
-	AwsAccessKeyID                         string   `yaml:"aws_access_key_id"`
-	AwsRegion                              string   `yaml:"aws_region"`
-	AwsS3Bucket                            string   `yaml:"aws_s3_bucket"`
-	AwsSecretAccessKey                     string   `yaml:"aws_secret_access_key"`
-	BlockProfileRate                       int      `yaml:"block_profile_rate"`
-	CountUniqueTimeseries                  bool     `yaml:"count_unique_timeseries"`
-	DatadogAPIHostname                     string   `yaml:"datadog_api_hostname"`
-	DatadogAPIKey                          string   `yaml:"datadog_api_key"`
+	Aggregates         []string `yaml:"aggregates"`
+	AwsAccessKeyID     string   `yaml:"aws_access_key_id"`
+	AwsRegion          string   `yaml:"aws_region"`
+	AwsS3Bucket        string   `yaml:"aws_s3_bucket"`
+	AwsSecretAccessKey string   `yaml:"aws_secret_access_key"`
+	BlockProfileRate   int      `yaml:"block_profile_rate"`
+	CardinalityLimits  []struct {
+		Action     string `yaml:"action"`
+		Limit      int    `yaml:"limit"`
+		MetricName string `yaml:"metric_name"`
+		TagKey     string `yaml:"tag_key"`
+	} `yaml:"cardinality_limits"`
+	CountUniqueTimeseries                  bool   `yaml:"count_unique_timeseries"`
+	DatadogAPIHostname                     string `yaml:"datadog_api_hostname"`
+	DatadogAPIKey                          string `yaml:"datadog_api_key"`
 	DatadogExcludeTagsPrefixByPrefixMetric []struct {
 		MetricPrefix string   `yaml:"metric_prefix"`
 		Tags         []string `yaml:"tags"`
 	} `yaml:"datadog_exclude_tags_prefix_by_prefix_metric"`
-	DatadogFlushMaxPerBody                    int       `yaml:"datadog_flush_max_per_body"`
-	DatadogMetricNamePrefixDrops              []string  `yaml:"datadog_metric_name_prefix_drops"`
-	DatadogSpanBufferSize                     int       `yaml:"datadog_span_buffer_size"`
-	DatadogTraceAPIAddress                    string    `yaml:"datadog_trace_api_address"`
-	Debug                                     bool      `yaml:"debug"`
-	DebugFlushedMetrics                       bool      `yaml:"debug_flushed_metrics"`
-	DebugIngestedSpans                        bool      `yaml:"debug_ingested_spans"`
-	EnableProfiling                           bool      `yaml:"enable_profiling"`
-	FalconerAddress                           string    `yaml:"falconer_address"`
-	FlushFile                                 string    `yaml:"flush_file"`
-	FlushMaxPerBody                           int       `yaml:"flush_max_per_body"`
-	FlushWatchdogMissedFlushes                int       `yaml:"flush_watchdog_missed_flushes"`
-	ForwardAddress                            string    `yaml:"forward_address"`
-	ForwardUseGrpc                            bool      `yaml:"forward_use_grpc"`
-	GrpcAddress                               string    `yaml:"grpc_address"`
-	Hostname                                  string    `yaml:"hostname"`
-	HTTPAddress                               string    `yaml:"http_address"`
-	HTTPQuit                                  bool      `yaml:"http_quit"`
-	IndicatorSpanTimerName                    string    `yaml:"indicator_span_timer_name"`
-	Interval                                  string    `yaml:"interval"`
-	KafkaBroker                               string    `yaml:"kafka_broker"`
-	KafkaCheckTopic                           string    `yaml:"kafka_check_topic"`
-	KafkaEventTopic                           string    `yaml:"kafka_event_topic"`
-	KafkaMetricBufferBytes                    int       `yaml:"kafka_metric_buffer_bytes"`
-	KafkaMetricBufferFrequency                string    `yaml:"kafka_metric_buffer_frequency"`
-	KafkaMetricBufferMessages                 int       `yaml:"kafka_metric_buffer_messages"`
-	KafkaMetricRequireAcks                    string    `yaml:"kafka_metric_require_acks"`
-	KafkaMetricTopic                          string    `yaml:"kafka_metric_topic"`
-	KafkaPartitioner                          string    `yaml:"kafka_partitioner"`
-	KafkaRetryMax                             int       `yaml:"kafka_retry_max"`
-	KafkaSpanBufferBytes                      int       `yaml:"kafka_span_buffer_bytes"`
-	KafkaSpanBufferFrequency                  string    `yaml:"kafka_span_buffer_frequency"`
-	KafkaSpanBufferMesages                    int       `yaml:"kafka_span_buffer_mesages"`
-	KafkaSpanRequireAcks                      string    `yaml:"kafka_span_require_acks"`
-	KafkaSpanSampleRatePercent                float64   `yaml:"kafka_span_sample_rate_percent"`
-	KafkaSpanSampleTag                        string    `yaml:"kafka_span_sample_tag"`
-	KafkaSpanSerializationFormat              string    `yaml:"kafka_span_serialization_format"`
-	KafkaSpanTopic                            string    `yaml:"kafka_span_topic"`
-	LightstepAccessToken                      string    `yaml:"lightstep_access_token"`
-	LightstepCollectorHost                    string    `yaml:"lightstep_collector_host"`
-	LightstepMaximumSpans                     int       `yaml:"lightstep_maximum_spans"`
-	LightstepNumClients                       int       `yaml:"lightstep_num_clients"`
-	LightstepReconnectPeriod                  string    `yaml:"lightstep_reconnect_period"`
-	MetricMaxLength                           int       `yaml:"metric_max_length"`
-	MutexProfileFraction                      int       `yaml:"mutex_profile_fraction"`
-	NumReaders                                int       `yaml:"num_readers"`
-	NumSpanWorkers                            int       `yaml:"num_span_workers"`
-	NumWorkers                                int       `yaml:"num_workers"`
-	ObjectiveSpanTimerName                    string    `yaml:"objective_span_timer_name"`
-	OmitEmptyHostname                         bool      `yaml:"omit_empty_hostname"`
-	Percentiles                               []float64 `yaml:"percentiles"`
-	ReadBufferSizeBytes                       int       `yaml:"read_buffer_size_bytes"`
-	SentryDsn                                 string    `yaml:"sentry_dsn"`
-	SignalfxAPIKey                            string    `yaml:"signalfx_api_key"`
-	SignalfxDynamicPerTagAPIKeysEnable        bool      `yaml:"signalfx_dynamic_per_tag_api_keys_enable"`
-	SignalfxDynamicPerTagAPIKeysRefreshPeriod string    `yaml:"signalfx_dynamic_per_tag_api_keys_refresh_period"`
-	SignalfxEndpointAPI                       string    `yaml:"signalfx_endpoint_api"`
-	SignalfxEndpointBase                      string    `yaml:"signalfx_endpoint_base"`
-	SignalfxFlushMaxPerBody                   int       `yaml:"signalfx_flush_max_per_body"`
-	SignalfxHostnameTag                       string    `yaml:"signalfx_hostname_tag"`
-	SignalfxMetricNamePrefixDrops             []string  `yaml:"signalfx_metric_name_prefix_drops"`
-	SignalfxMetricTagPrefixDrops              []string  `yaml:"signalfx_metric_tag_prefix_drops"`
+	DatadogFlushMaxPerBody       int      `yaml:"datadog_flush_max_per_body"`
+	DatadogMetricNamePrefixDrops []string `yaml:"datadog_metric_name_prefix_drops"`
+	DatadogSpanBufferSize        int      `yaml:"datadog_span_buffer_size"`
+	DatadogTraceAPIAddress       string   `yaml:"datadog_trace_api_address"`
+	Debug                        bool     `yaml:"debug"`
+	DebugFlushedMetrics          bool     `yaml:"debug_flushed_metrics"`
+	DebugIngestedSpans           bool     `yaml:"debug_ingested_spans"`
+	EnableProfiling              bool     `yaml:"enable_profiling"`
+	FalconerAddress              string   `yaml:"falconer_address"`
+	FlushFile                    string   `yaml:"flush_file"`
+	FlushMaxPerBody              int      `yaml:"flush_max_per_body"`
+	FlushWatchdogMissedFlushes   int      `yaml:"flush_watchdog_missed_flushes"`
+	ForwardAddress               string   `yaml:"forward_address"`
+	ForwardGrpcBatchSize         int      `yaml:"forward_grpc_batch_size"`
+	ForwardGrpcBearerToken       string   `yaml:"forward_grpc_bearer_token"`
+	ForwardGrpcCompression       string   `yaml:"forward_grpc_compression"`
+	ForwardSpoolDirectory        string   `yaml:"forward_spool_directory"`
+	ForwardSpoolMaxAge           string   `yaml:"forward_spool_max_age"`
+	ForwardSpoolMaxBytes         int      `yaml:"forward_spool_max_bytes"`
+	ForwardUseGrpc               bool     `yaml:"forward_use_grpc"`
+	GrpcAddress                  string   `yaml:"grpc_address"`
+	GrpcBearerTokens             []string `yaml:"grpc_bearer_tokens"`
+	GrpcTLSAuthorityCertificate  string   `yaml:"grpc_tls_authority_certificate"`
+	GrpcTLSCertificate           string   `yaml:"grpc_tls_certificate"`
+	GrpcTLSKey                   string   `yaml:"grpc_tls_key"`
+	GrpcUnauthenticatedServices  []string `yaml:"grpc_unauthenticated_services"`
+	HistogramRules               []struct {
+		Aggregates  []string  `yaml:"aggregates"`
+		MetricName  string    `yaml:"metric_name"`
+		MetricRegex string    `yaml:"metric_regex"`
+		Percentiles []float64 `yaml:"percentiles"`
+		Tags        []string  `yaml:"tags"`
+	} `yaml:"histogram_rules"`
+	HistogramSketch               string `yaml:"histogram_sketch"`
+	HistogramSketchByPrefixMetric []struct {
+		MetricPrefix string `yaml:"metric_prefix"`
+		Sketch       string `yaml:"sketch"`
+	} `yaml:"histogram_sketch_by_prefix_metric"`
+	Hostname                             string    `yaml:"hostname"`
+	HTTPAddress                          string    `yaml:"http_address"`
+	HTTPQuit                             bool      `yaml:"http_quit"`
+	IndicatorSpanTimerName               string    `yaml:"indicator_span_timer_name"`
+	Interval                             string    `yaml:"interval"`
+	JaegerListenAddress                  string    `yaml:"jaeger_listen_address"`
+	KafkaBroker                          string    `yaml:"kafka_broker"`
+	KafkaCheckTopic                      string    `yaml:"kafka_check_topic"`
+	KafkaEventTopic                      string    `yaml:"kafka_event_topic"`
+	KafkaMetricBufferBytes               int       `yaml:"kafka_metric_buffer_bytes"`
+	KafkaMetricBufferFrequency           string    `yaml:"kafka_metric_buffer_frequency"`
+	KafkaMetricBufferMessages            int       `yaml:"kafka_metric_buffer_messages"`
+	KafkaMetricRequireAcks               string    `yaml:"kafka_metric_require_acks"`
+	KafkaMetricSketches                  bool      `yaml:"kafka_metric_sketches"`
+	KafkaMetricTopic                     string    `yaml:"kafka_metric_topic"`
+	KafkaPartitioner                     string    `yaml:"kafka_partitioner"`
+	KafkaRetryMax                        int       `yaml:"kafka_retry_max"`
+	KafkaSpanBufferBytes                 int       `yaml:"kafka_span_buffer_bytes"`
+	KafkaSpanBufferFrequency             string    `yaml:"kafka_span_buffer_frequency"`
+	KafkaSpanBufferMesages               int       `yaml:"kafka_span_buffer_mesages"`
+	KafkaSpanRequireAcks                 string    `yaml:"kafka_span_require_acks"`
+	KafkaSpanSampleRatePercent           float64   `yaml:"kafka_span_sample_rate_percent"`
+	KafkaSpanSampleTag                   string    `yaml:"kafka_span_sample_tag"`
+	KafkaSpanSerializationFormat         string    `yaml:"kafka_span_serialization_format"`
+	KafkaSpanTopic                       string    `yaml:"kafka_span_topic"`
+	LightstepAccessToken                 string    `yaml:"lightstep_access_token"`
+	LightstepCollectorHost               string    `yaml:"lightstep_collector_host"`
+	LightstepMaximumSpans                int       `yaml:"lightstep_maximum_spans"`
+	LightstepNumClients                  int       `yaml:"lightstep_num_clients"`
+	LightstepReconnectPeriod             string    `yaml:"lightstep_reconnect_period"`
+	MetricMaxLength                      int       `yaml:"metric_max_length"`
+	MutexProfileFraction                 int       `yaml:"mutex_profile_fraction"`
+	NumReaders                           int       `yaml:"num_readers"`
+	NumSpanWorkers                       int       `yaml:"num_span_workers"`
+	NumWorkers                           int       `yaml:"num_workers"`
+	ObjectiveSpanTimerName               string    `yaml:"objective_span_timer_name"`
+	OmitEmptyHostname                    bool      `yaml:"omit_empty_hostname"`
+	OtlpSpanBatchSize                    int       `yaml:"otlp_span_batch_size"`
+	OtlpSpanBufferSize                   int       `yaml:"otlp_span_buffer_size"`
+	OtlpTraceAddress                     string    `yaml:"otlp_trace_address"`
+	OtlpTraceUseTLS                      bool      `yaml:"otlp_trace_use_tls"`
+	Percentiles                          []float64 `yaml:"percentiles"`
+	PrometheusCounterExpiryFlushes       int       `yaml:"prometheus_counter_expiry_flushes"`
+	PrometheusRemoteWriteAddress         string    `yaml:"prometheus_remote_write_address"`
+	PrometheusRemoteWriteBearerToken     string    `yaml:"prometheus_remote_write_bearer_token"`
+	PrometheusRemoteWriteFlushMaxPerBody int       `yaml:"prometheus_remote_write_flush_max_per_body"`
+	PrometheusScrapeEnabled              bool      `yaml:"prometheus_scrape_enabled"`
+	ReadBufferSizeBytes                  int       `yaml:"read_buffer_size_bytes"`
+	RelabelRules                         []struct {
+		Action      string   `yaml:"action"`
+		MatchName   string   `yaml:"match_name"`
+		MatchTags   []string `yaml:"match_tags"`
+		Replacement string   `yaml:"replacement"`
+		Scope       string   `yaml:"scope"`
+		Tag         string   `yaml:"tag"`
+		TagKey      string   `yaml:"tag_key"`
+		Type        string   `yaml:"type"`
+		ValueRegex  string   `yaml:"value_regex"`
+	} `yaml:"relabel_rules"`
+	SentryDsn                                 string   `yaml:"sentry_dsn"`
+	SignalfxAPIKey                            string   `yaml:"signalfx_api_key"`
+	SignalfxDynamicPerTagAPIKeysEnable        bool     `yaml:"signalfx_dynamic_per_tag_api_keys_enable"`
+	SignalfxDynamicPerTagAPIKeysRefreshPeriod string   `yaml:"signalfx_dynamic_per_tag_api_keys_refresh_period"`
+	SignalfxEndpointAPI                       string   `yaml:"signalfx_endpoint_api"`
+	SignalfxEndpointBase                      string   `yaml:"signalfx_endpoint_base"`
+	SignalfxFlushMaxPerBody                   int      `yaml:"signalfx_flush_max_per_body"`
+	SignalfxHostnameTag                       string   `yaml:"signalfx_hostname_tag"`
+	SignalfxMetricNamePrefixDrops             []string `yaml:"signalfx_metric_name_prefix_drops"`
+	SignalfxMetricTagPrefixDrops              []string `yaml:"signalfx_metric_tag_prefix_drops"`
 	SignalfxPerTagAPIKeys                     []struct {
 		APIKey string `yaml:"api_key"`
 		Name   string `yaml:"name"`
 	} `yaml:"signalfx_per_tag_api_keys"`
 	SignalfxVaryKeyBy                 string   `yaml:"signalfx_vary_key_by"`
 	SpanChannelCapacity               int      `yaml:"span_channel_capacity"`
+	SpanHTTPMaxBodyBytes              int      `yaml:"span_http_max_body_bytes"`
+	SpanRedMetrics                    bool     `yaml:"span_red_metrics"`
+	SpanServiceGraphMaxSpans          int      `yaml:"span_service_graph_max_spans"`
+	SpanServiceGraphWindow            string   `yaml:"span_service_graph_window"`
 	SplunkHecAddress                  string   `yaml:"splunk_hec_address"`
 	SplunkHecBatchSize                int      `yaml:"splunk_hec_batch_size"`
 	SplunkHecConnectionLifetimeJitter string   `yaml:"splunk_hec_connection_lifetime_jitter"`
@@ -99,6 +154,15 @@ type Config struct {
 	SynchronizeWithInterval           bool     `yaml:"synchronize_with_interval"`
 	Tags                              []string `yaml:"tags"`
 	TagsExclude                       []string `yaml:"tags_exclude"`
+	TailSamplingDecisionWait          string   `yaml:"tail_sampling_decision_wait"`
+	TailSamplingKeepErrors            bool     `yaml:"tail_sampling_keep_errors"`
+	TailSamplingKeepIndicators        bool     `yaml:"tail_sampling_keep_indicators"`
+	TailSamplingMaxTraces             int      `yaml:"tail_sampling_max_traces"`
+	TailSamplingMinRootDuration       string   `yaml:"tail_sampling_min_root_duration"`
+	TailSamplingPeers                 []string `yaml:"tail_sampling_peers"`
+	TailSamplingRatePercent           float64  `yaml:"tail_sampling_rate_percent"`
+	TailSamplingSelf                  string   `yaml:"tail_sampling_self"`
+	TailSamplingTags                  []string `yaml:"tail_sampling_tags"`
 	TLSAuthorityCertificate           string   `yaml:"tls_authority_certificate"`
 	TLSCertificate                    string   `yaml:"tls_certificate"`
 	TLSKey                            string   `yaml:"tls_key"`
@@ -119,4 +183,8 @@ type Config struct {
 	XrayAddress          string   `yaml:"xray_address"`
 	XrayAnnotationTags   []string `yaml:"xray_annotation_tags"`
 	XraySamplePercentage int      `yaml:"xray_sample_percentage"`
+	ZipkinListenAddress  string   `yaml:"zipkin_listen_address"`
+	ZipkinSpanBatchSize  int      `yaml:"zipkin_span_batch_size"`
+	ZipkinSpanBufferSize int      `yaml:"zipkin_span_buffer_size"`
+	ZipkinTraceAddress   string   `yaml:"zipkin_trace_address"`
 }
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/kelseyhightower/envconfig"
+	"github.com/stripe/veneur/samplers"
 
 	"gopkg.in/yaml.v2"
 )
@@ -23,6 +24,7 @@ var defaultConfig = Config{
 }
 
 var defaultProxyConfig = ProxyConfig{
+	ForwardHashingLoadFactor:     1.25,
 	MaxIdleConnsPerHost:          100,
 	TracingClientCapacity:        1024,
 	TracingClientFlushInterval:   "500ms",
@@ -42,6 +44,9 @@ func ReadProxyConfig(path string) (c ProxyConfig, err error) {
 }
 
 func (c *ProxyConfig) applyDefaults() {
+	if c.ForwardHashingLoadFactor == 0 {
+		c.ForwardHashingLoadFactor = defaultProxyConfig.ForwardHashingLoadFactor
+	}
 	if c.MaxIdleConnsPerHost == 0 {
 		// It's dangerous to leave this as the default. Since veneur-proxy is
 		// designed for HA environments with lots of globalstats backends we
@@ -229,3 +234,77 @@ func (c *Config) applyDefaults() {
 func (c Config) ParseInterval() (time.Duration, error) {
 	return time.ParseDuration(c.Interval)
 }
+
+// ParseSketchSelector returns the sketches that should back histograms,
+// timers and distributions, as configured by histogram_sketch and
+// histogram_sketch_by_prefix_metric.
+func (c Config) ParseSketchSelector() (samplers.SketchSelector, error) {
+	var err error
+	sketches := samplers.SketchSelector{}
+	sketches.Default, err = samplers.ParseSketchType(c.HistogramSketch)
+	if err != nil {
+		return sketches, err
+	}
+	if len(c.HistogramSketchByPrefixMetric) == 0 {
+		return sketches, nil
+	}
+	sketches.Prefixes = make(map[string]samplers.SketchType, len(c.HistogramSketchByPrefixMetric))
+	for _, m := range c.HistogramSketchByPrefixMetric {
+		sketches.Prefixes[m.MetricPrefix], err = samplers.ParseSketchType(m.Sketch)
+		if err != nil {
+			return sketches, err
+		}
+	}
+	return sketches, nil
+}
+
+// ParseHistogramRules returns the per-metric percentiles and aggregates
+// configured by histogram_rules.
+func (c Config) ParseHistogramRules() (samplers.HistogramRules, error) {
+	rules := make(samplers.HistogramRules, 0, len(c.HistogramRules))
+	for _, r := range c.HistogramRules {
+		rule, err := samplers.NewHistogramRule(r.MetricName, r.MetricRegex, r.Tags, r.Percentiles, r.Aggregates)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// ParseCardinalityLimits returns the timeseries budgets configured by
+// cardinality_limits.
+func (c Config) ParseCardinalityLimits() ([]CardinalityLimit, error) {
+	limits := make([]CardinalityLimit, 0, len(c.CardinalityLimits))
+	for _, l := range c.CardinalityLimits {
+		limit, err := NewCardinalityLimit(l.MetricName, l.TagKey, l.Limit, l.Action)
+		if err != nil {
+			return nil, err
+		}
+		limits = append(limits, limit)
+	}
+	return limits, nil
+}
+
+// ParseRelabelRules returns the rules configured by relabel_rules.
+func (c Config) ParseRelabelRules() (samplers.Relabeler, error) {
+	rules := make(samplers.Relabeler, 0, len(c.RelabelRules))
+	for _, r := range c.RelabelRules {
+		rule, err := samplers.NewRelabelRule(samplers.RelabelConfig{
+			Action:      r.Action,
+			MatchName:   r.MatchName,
+			MatchTags:   r.MatchTags,
+			Replacement: r.Replacement,
+			Scope:       r.Scope,
+			Tag:         r.Tag,
+			TagKey:      r.TagKey,
+			Type:        r.Type,
+			ValueRegex:  r.ValueRegex,
+		})
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
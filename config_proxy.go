@@ -1,27 +1,57 @@
 package veneur
 
 type ProxyConfig struct {
-	ConsulForwardGrpcServiceName string `yaml:"consul_forward_grpc_service_name"`
-	ConsulForwardServiceName     string `yaml:"consul_forward_service_name"`
-	ConsulRefreshInterval        string `yaml:"consul_refresh_interval"`
-	ConsulTraceServiceName       string `yaml:"consul_trace_service_name"`
-	Debug                        bool   `yaml:"debug"`
-	EnableProfiling              bool   `yaml:"enable_profiling"`
-	ForwardAddress               string `yaml:"forward_address"`
-	ForwardTimeout               string `yaml:"forward_timeout"`
-	GrpcAddress                  string `yaml:"grpc_address"`
-	GrpcForwardAddress           string `yaml:"grpc_forward_address"`
-	HTTPAddress                  string `yaml:"http_address"`
-	IdleConnectionTimeout        string `yaml:"idle_connection_timeout"`
-	MaxIdleConns                 int    `yaml:"max_idle_conns"`
-	MaxIdleConnsPerHost          int    `yaml:"max_idle_conns_per_host"`
-	RuntimeMetricsInterval       string `yaml:"runtime_metrics_interval"`
-	SentryDsn                    string `yaml:"sentry_dsn"`
-	SsfDestinationAddress        string `yaml:"ssf_destination_address"`
-	StatsAddress                 string `yaml:"stats_address"`
-	TraceAddress                 string `yaml:"trace_address"`
-	TraceAPIAddress              string `yaml:"trace_api_address"`
-	TracingClientCapacity        int    `yaml:"tracing_client_capacity"`
-	TracingClientFlushInterval   string `yaml:"tracing_client_flush_interval"`
-	TracingClientMetricsInterval string `yaml:"tracing_client_metrics_interval"`
+	ConsulForwardGrpcServiceName       string  `yaml:"consul_forward_grpc_service_name"`
+	ConsulForwardServiceName           string  `yaml:"consul_forward_service_name"`
+	ConsulRefreshInterval              string  `yaml:"consul_refresh_interval"`
+	ConsulTraceServiceName             string  `yaml:"consul_trace_service_name"`
+	Debug                              bool    `yaml:"debug"`
+	Discoverer                         string  `yaml:"discoverer"`
+	DiscovererFile                     string  `yaml:"discoverer_file"`
+	EnableProfiling                    bool    `yaml:"enable_profiling"`
+	ForwardAddress                     string  `yaml:"forward_address"`
+	ForwardEjectionConsecutiveFailures int     `yaml:"forward_ejection_consecutive_failures"`
+	ForwardEjectionCooldown            string  `yaml:"forward_ejection_cooldown"`
+	ForwardEjectionErrorRate           float64 `yaml:"forward_ejection_error_rate"`
+	ForwardEjectionLatency             string  `yaml:"forward_ejection_latency"`
+	ForwardEjectionMaxRatio            float64 `yaml:"forward_ejection_max_ratio"`
+	ForwardGrpcBatchSize               int     `yaml:"forward_grpc_batch_size"`
+	ForwardGrpcBearerToken             string  `yaml:"forward_grpc_bearer_token"`
+	ForwardGrpcCompression             string  `yaml:"forward_grpc_compression"`
+	ForwardHandoffGracePeriod          string  `yaml:"forward_handoff_grace_period"`
+	ForwardHashing                     string  `yaml:"forward_hashing"`
+	ForwardHashingLoadFactor           float64 `yaml:"forward_hashing_load_factor"`
+	ForwardMirrors                     []struct {
+		ConsulForwardGrpcServiceName string   `yaml:"consul_forward_grpc_service_name"`
+		ConsulForwardServiceName     string   `yaml:"consul_forward_service_name"`
+		ForwardAddress               string   `yaml:"forward_address"`
+		GrpcForwardAddress           string   `yaml:"grpc_forward_address"`
+		GrpcMaxActiveHandlers        int      `yaml:"grpc_max_active_handlers"`
+		GrpcMaxInFlightBytes         int      `yaml:"grpc_max_in_flight_bytes"`
+		MetricNamePrefixDrops        []string `yaml:"metric_name_prefix_drops"`
+		MetricNamePrefixes           []string `yaml:"metric_name_prefixes"`
+		Name                         string   `yaml:"name"`
+	} `yaml:"forward_mirrors"`
+	ForwardTimeout               string   `yaml:"forward_timeout"`
+	GrpcAddress                  string   `yaml:"grpc_address"`
+	GrpcBearerTokens             []string `yaml:"grpc_bearer_tokens"`
+	GrpcForwardAddress           string   `yaml:"grpc_forward_address"`
+	GrpcMaxActiveHandlers        int      `yaml:"grpc_max_active_handlers"`
+	GrpcMaxInFlightBytes         int      `yaml:"grpc_max_in_flight_bytes"`
+	GrpcTLSAuthorityCertificate  string   `yaml:"grpc_tls_authority_certificate"`
+	GrpcTLSCertificate           string   `yaml:"grpc_tls_certificate"`
+	GrpcTLSKey                   string   `yaml:"grpc_tls_key"`
+	HTTPAddress                  string   `yaml:"http_address"`
+	IdleConnectionTimeout        string   `yaml:"idle_connection_timeout"`
+	MaxIdleConns                 int      `yaml:"max_idle_conns"`
+	MaxIdleConnsPerHost          int      `yaml:"max_idle_conns_per_host"`
+	RuntimeMetricsInterval       string   `yaml:"runtime_metrics_interval"`
+	SentryDsn                    string   `yaml:"sentry_dsn"`
+	SsfDestinationAddress        string   `yaml:"ssf_destination_address"`
+	StatsAddress                 string   `yaml:"stats_address"`
+	TraceAddress                 string   `yaml:"trace_address"`
+	TraceAPIAddress              string   `yaml:"trace_api_address"`
+	TracingClientCapacity        int      `yaml:"tracing_client_capacity"`
+	TracingClientFlushInterval   string   `yaml:"tracing_client_flush_interval"`
+	TracingClientMetricsInterval string   `yaml:"tracing_client_metrics_interval"`
 }
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stripe/veneur/samplers"
 )
 
 func TestReadConfig(t *testing.T) {
@@ -26,6 +27,18 @@ func TestReadConfig(t *testing.T) {
 	interval, err := c.ParseInterval()
 	assert.NoError(t, err)
 	assert.Equal(t, interval, 10*time.Second)
+
+	sketches, err := c.ParseSketchSelector()
+	assert.NoError(t, err)
+	assert.Equal(t, samplers.SketchTDigest, sketches.For("api.requests"))
+	assert.Equal(t, samplers.SketchDDSketch, sketches.For("api.latency"))
+
+	rules, err := c.ParseHistogramRules()
+	assert.NoError(t, err)
+	assert.Len(t, rules, 2)
+	ps, aggs := rules.For("db.reads", nil, c.Percentiles, samplers.HistogramAggregates{})
+	assert.Empty(t, ps)
+	assert.Equal(t, 1, aggs.Count)
 }
 
 func TestReadBadConfig(t *testing.T) {
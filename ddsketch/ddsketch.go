@@ -0,0 +1,332 @@
+// Package ddsketch provides an implementation of DDSketch, a quantile sketch
+// with relative-error guarantees, for online, distributed applications.
+// Unlike the t-digest, its error at a quantile is bounded relative to the
+// value at that quantile, which keeps extreme tails (p99.9 and beyond)
+// accurate. For more details, refer to the paper:
+//
+// https://arxiv.org/abs/1908.10693
+package ddsketch
+
+import (
+	"math"
+
+	"github.com/stripe/veneur/tdigest"
+)
+
+const (
+	// DefaultRelativeAccuracy is the relative accuracy used if none is
+	// given: every quantile is within 1% of the true value.
+	DefaultRelativeAccuracy = 0.01
+
+	// DefaultMaxBins is the number of buckets kept on each side of zero if
+	// no maximum is given. With the default relative accuracy, this covers
+	// about 17 orders of magnitude before the lowest buckets are collapsed.
+	DefaultMaxBins = 2048
+)
+
+// A DDSketch maps every value to a bucket indexed by the logarithm of its
+// magnitude, so that every value in a bucket is within the relative accuracy
+// of the bucket's representative value. When a sketch grows beyond its
+// maximum number of buckets, its lowest buckets are collapsed together,
+// sacrificing accuracy for the smallest values first.
+//
+// DDSketch is not safe for use by multiple goroutines simultaneously.
+type DDSketch struct {
+	relativeAccuracy float64
+	maxBins          int
+	gamma            float64
+	multiplier       float64
+	// values whose magnitude is below this go into the zero bucket
+	minIndexable float64
+
+	positive  store
+	negative  store
+	zeroCount float64
+
+	count         float64
+	min           float64
+	max           float64
+	sum           float64
+	reciprocalSum float64
+}
+
+// New initializes a new DDSketch. Every quantile it returns is within
+// relativeAccuracy of the true value, as long as no more than maxBins
+// buckets are needed on either side of zero. Non-positive values select
+// the defaults.
+func New(relativeAccuracy float64, maxBins int) *DDSketch {
+	if relativeAccuracy <= 0 || relativeAccuracy >= 1 {
+		relativeAccuracy = DefaultRelativeAccuracy
+	}
+	if maxBins <= 0 {
+		maxBins = DefaultMaxBins
+	}
+	gamma := (1 + relativeAccuracy) / (1 - relativeAccuracy)
+	return &DDSketch{
+		relativeAccuracy: relativeAccuracy,
+		maxBins:          maxBins,
+		gamma:            gamma,
+		multiplier:       1 / math.Log(gamma),
+		minIndexable:     math.SmallestNonzeroFloat64 * gamma,
+		min:              math.Inf(+1),
+		max:              math.Inf(-1),
+	}
+}
+
+// NewFromData returns a DDSketch with values initialized from DDSketchData.
+// This should be the way to generate a DDSketch from a serialized protobuf.
+func NewFromData(d *DDSketchData) *DDSketch {
+	dd := New(d.RelativeAccuracy, int(d.MaxBins))
+	dd.positive = store{offset: d.Positive.Offset, counts: d.Positive.Counts}
+	dd.negative = store{offset: d.Negative.Offset, counts: d.Negative.Counts}
+	dd.zeroCount = d.ZeroCount
+	dd.min = d.Min
+	dd.max = d.Max
+	dd.sum = d.Sum
+	dd.reciprocalSum = d.ReciprocalSum
+
+	dd.count = dd.zeroCount + dd.positive.total() + dd.negative.total()
+	return dd
+}
+
+// Data returns a DDSketchData based on the DDSketch. This can be used with
+// proto.Marshal to encode a DDSketch as a protobuf.
+func (dd *DDSketch) Data() *DDSketchData {
+	return &DDSketchData{
+		RelativeAccuracy: dd.relativeAccuracy,
+		MaxBins:          int32(dd.maxBins),
+		Positive:         Store{Offset: dd.positive.offset, Counts: dd.positive.counts},
+		Negative:         Store{Offset: dd.negative.offset, Counts: dd.negative.counts},
+		ZeroCount:        dd.zeroCount,
+		Min:              dd.min,
+		Max:              dd.max,
+		Sum:              dd.sum,
+		ReciprocalSum:    dd.reciprocalSum,
+	}
+}
+
+// Add adds a new value to the sketch, with a given weight that must be
+// positive. Infinities and NaN cannot be added.
+func (dd *DDSketch) Add(value float64, weight float64) {
+	if math.IsNaN(value) || math.IsInf(value, 0) || weight <= 0 {
+		panic("invalid value added")
+	}
+	dd.addToBucket(value, weight)
+
+	dd.count += weight
+	dd.sum += value * weight
+	dd.reciprocalSum += (1 / value) * weight
+	dd.min = math.Min(dd.min, value)
+	dd.max = math.Max(dd.max, value)
+}
+
+func (dd *DDSketch) addToBucket(value float64, weight float64) {
+	switch {
+	case value > dd.minIndexable:
+		dd.positive.add(dd.key(value), weight, dd.maxBins)
+	case value < -dd.minIndexable:
+		dd.negative.add(dd.key(-value), weight, dd.maxBins)
+	default:
+		dd.zeroCount += weight
+	}
+}
+
+// key returns the index of the bucket holding the positive value v.
+func (dd *DDSketch) key(v float64) int32 {
+	return int32(math.Ceil(math.Log(v) * dd.multiplier))
+}
+
+// value returns the representative value of the bucket with index key,
+// which is within the relative accuracy of every value in the bucket.
+func (dd *DDSketch) value(key int32) float64 {
+	return 2 * math.Pow(dd.gamma, float64(key)) / (1 + dd.gamma)
+}
+
+// Quantile returns a value such that the fraction of values in dd below
+// that value is approximately equal to quantile. Returns NaN if the sketch
+// is empty.
+func (dd *DDSketch) Quantile(quantile float64) float64 {
+	if quantile < 0 || quantile > 1 {
+		panic("quantile out of bounds")
+	}
+	if dd.count == 0 {
+		return math.NaN()
+	}
+	switch quantile {
+	case 0:
+		return dd.min
+	case 1:
+		return dd.max
+	}
+
+	rank := quantile * (dd.count - 1)
+	weightSoFar := 0.0
+	// negative values are in descending order of magnitude
+	for i := len(dd.negative.counts) - 1; i >= 0; i-- {
+		weightSoFar += dd.negative.counts[i]
+		if weightSoFar > rank {
+			return dd.clamp(-dd.value(dd.negative.offset + int32(i)))
+		}
+	}
+	weightSoFar += dd.zeroCount
+	if weightSoFar > rank {
+		return dd.clamp(0)
+	}
+	for i, c := range dd.positive.counts {
+		weightSoFar += c
+		if weightSoFar > rank {
+			return dd.clamp(dd.value(dd.positive.offset + int32(i)))
+		}
+	}
+	return dd.max
+}
+
+// clamp bounds v by the exact extremes of the sketch, since the
+// representative values of the outermost buckets may lie beyond them.
+func (dd *DDSketch) clamp(v float64) float64 {
+	return math.Max(dd.min, math.Min(dd.max, v))
+}
+
+func (dd *DDSketch) Min() float64 {
+	return dd.min
+}
+func (dd *DDSketch) Max() float64 {
+	return dd.max
+}
+func (dd *DDSketch) Count() float64 {
+	return dd.count
+}
+func (dd *DDSketch) Sum() float64 {
+	return dd.sum
+}
+func (dd *DDSketch) ReciprocalSum() float64 {
+	return dd.reciprocalSum
+}
+
+// RelativeAccuracy returns the relative accuracy the sketch was created
+// with.
+func (dd *DDSketch) RelativeAccuracy() float64 {
+	return dd.relativeAccuracy
+}
+
+// Merge another sketch into this one. Sketches with the same relative
+// accuracy merge without any loss of accuracy; otherwise, each of other's
+// buckets is added at its representative value. Neither dd nor other can be
+// shared concurrently during the execution of this method.
+func (dd *DDSketch) Merge(other *DDSketch) {
+	if other.count == 0 {
+		return
+	}
+	if other.gamma == dd.gamma {
+		dd.positive.merge(&other.positive, dd.maxBins)
+		dd.negative.merge(&other.negative, dd.maxBins)
+		dd.zeroCount += other.zeroCount
+	} else {
+		other.ForEach(dd.addToBucket)
+	}
+	dd.mergeStats(other.count, other.min, other.max, other.sum, other.reciprocalSum)
+}
+
+// MergeTDigest adds the contents of a t-digest to this sketch. Each
+// centroid is added at its mean, so quantiles are only as accurate as the
+// t-digest was; the count, sum and extremes are carried over exactly.
+func (dd *DDSketch) MergeTDigest(td *tdigest.MergingDigest) {
+	data := td.Data()
+	for _, c := range data.MainCentroids {
+		dd.addToBucket(c.Mean, c.Weight)
+	}
+	dd.mergeStats(td.Count(), td.Min(), td.Max(), td.Sum(), td.ReciprocalSum())
+}
+
+func (dd *DDSketch) mergeStats(count, min, max, sum, reciprocalSum float64) {
+	dd.count += count
+	dd.sum += sum
+	dd.reciprocalSum += reciprocalSum
+	dd.min = math.Min(dd.min, min)
+	dd.max = math.Max(dd.max, max)
+}
+
+// ForEach calls f with the representative value and the weight of every
+// non-empty bucket in the sketch, in no particular order.
+func (dd *DDSketch) ForEach(f func(value, weight float64)) {
+	if dd.zeroCount > 0 {
+		f(0, dd.zeroCount)
+	}
+	for i, c := range dd.positive.counts {
+		if c > 0 {
+			f(dd.value(dd.positive.offset+int32(i)), c)
+		}
+	}
+	for i, c := range dd.negative.counts {
+		if c > 0 {
+			f(-dd.value(dd.negative.offset+int32(i)), c)
+		}
+	}
+}
+
+// store is a dense run of bucket weights: counts[i] holds the weight of
+// the bucket with index offset+i.
+type store struct {
+	counts []float64
+	offset int32
+}
+
+// add adds weight to the bucket with index key, growing the store as
+// needed. If the store would need more than maxBins buckets, its lowest
+// buckets are collapsed into one.
+func (s *store) add(key int32, weight float64, maxBins int) {
+	if len(s.counts) == 0 {
+		s.counts = append(s.counts, weight)
+		s.offset = key
+		return
+	}
+
+	high := s.offset + int32(len(s.counts)) - 1
+	switch {
+	case key > high:
+		s.counts = append(s.counts, make([]float64, key-high)...)
+	case key < s.offset:
+		if lowest := high - int32(maxBins) + 1; key < lowest {
+			key = lowest
+		}
+		if key < s.offset {
+			grown := make([]float64, high-key+1)
+			copy(grown[s.offset-key:], s.counts)
+			s.counts = grown
+			s.offset = key
+		}
+	}
+	s.counts[key-s.offset] += weight
+	s.collapse(maxBins)
+}
+
+// collapse folds the lowest buckets together until at most maxBins remain.
+func (s *store) collapse(maxBins int) {
+	excess := len(s.counts) - maxBins
+	if excess <= 0 {
+		return
+	}
+	folded := 0.0
+	for _, c := range s.counts[:excess+1] {
+		folded += c
+	}
+	s.counts = s.counts[excess:]
+	s.counts[0] = folded
+	s.offset += int32(excess)
+}
+
+func (s *store) merge(other *store, maxBins int) {
+	for i, c := range other.counts {
+		if c > 0 {
+			s.add(other.offset+int32(i), c, maxBins)
+		}
+	}
+}
+
+func (s *store) total() float64 {
+	total := 0.0
+	for _, c := range s.counts {
+		total += c
+	}
+	return total
+}
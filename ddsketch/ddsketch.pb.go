@@ -0,0 +1,824 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: ddsketch/ddsketch.proto
+
+package ddsketch
+
+import (
+	encoding_binary "encoding/binary"
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
+// DDSketchData contains all fields necessary to generate a DDSketch. This
+// type should generally just be used when serializing DDSketches, and
+// doesn't have much of a purpose on its own.
+type DDSketchData struct {
+	RelativeAccuracy float64 `protobuf:"fixed64,1,opt,name=relative_accuracy,json=relativeAccuracy,proto3" json:"relative_accuracy,omitempty"`
+	MaxBins          int32   `protobuf:"varint,2,opt,name=max_bins,json=maxBins,proto3" json:"max_bins,omitempty"`
+	// positive and negative hold the weights of the buckets for values
+	// above and below zero, indexed by the logarithm of the value's
+	// magnitude.
+	Positive      Store   `protobuf:"bytes,3,opt,name=positive,proto3" json:"positive"`
+	Negative      Store   `protobuf:"bytes,4,opt,name=negative,proto3" json:"negative"`
+	ZeroCount     float64 `protobuf:"fixed64,5,opt,name=zero_count,json=zeroCount,proto3" json:"zero_count,omitempty"`
+	Min           float64 `protobuf:"fixed64,6,opt,name=min,proto3" json:"min,omitempty"`
+	Max           float64 `protobuf:"fixed64,7,opt,name=max,proto3" json:"max,omitempty"`
+	Sum           float64 `protobuf:"fixed64,8,opt,name=sum,proto3" json:"sum,omitempty"`
+	ReciprocalSum float64 `protobuf:"fixed64,9,opt,name=reciprocal_sum,json=reciprocalSum,proto3" json:"reciprocal_sum,omitempty"`
+}
+
+func (m *DDSketchData) Reset()         { *m = DDSketchData{} }
+func (m *DDSketchData) String() string { return proto.CompactTextString(m) }
+func (*DDSketchData) ProtoMessage()    {}
+func (*DDSketchData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_429c672cc4410d8d, []int{0}
+}
+func (m *DDSketchData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DDSketchData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DDSketchData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DDSketchData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DDSketchData.Merge(m, src)
+}
+func (m *DDSketchData) XXX_Size() int {
+	return m.Size()
+}
+func (m *DDSketchData) XXX_DiscardUnknown() {
+	xxx_messageInfo_DDSketchData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DDSketchData proto.InternalMessageInfo
+
+func (m *DDSketchData) GetRelativeAccuracy() float64 {
+	if m != nil {
+		return m.RelativeAccuracy
+	}
+	return 0
+}
+
+func (m *DDSketchData) GetMaxBins() int32 {
+	if m != nil {
+		return m.MaxBins
+	}
+	return 0
+}
+
+func (m *DDSketchData) GetPositive() Store {
+	if m != nil {
+		return m.Positive
+	}
+	return Store{}
+}
+
+func (m *DDSketchData) GetNegative() Store {
+	if m != nil {
+		return m.Negative
+	}
+	return Store{}
+}
+
+func (m *DDSketchData) GetZeroCount() float64 {
+	if m != nil {
+		return m.ZeroCount
+	}
+	return 0
+}
+
+func (m *DDSketchData) GetMin() float64 {
+	if m != nil {
+		return m.Min
+	}
+	return 0
+}
+
+func (m *DDSketchData) GetMax() float64 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
+}
+
+func (m *DDSketchData) GetSum() float64 {
+	if m != nil {
+		return m.Sum
+	}
+	return 0
+}
+
+func (m *DDSketchData) GetReciprocalSum() float64 {
+	if m != nil {
+		return m.ReciprocalSum
+	}
+	return 0
+}
+
+// Store is a dense run of bucket weights. counts[i] holds the weight of
+// the bucket with index offset+i.
+type Store struct {
+	Offset int32     `protobuf:"zigzag32,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Counts []float64 `protobuf:"fixed64,2,rep,packed,name=counts,proto3" json:"counts,omitempty"`
+}
+
+func (m *Store) Reset()         { *m = Store{} }
+func (m *Store) String() string { return proto.CompactTextString(m) }
+func (*Store) ProtoMessage()    {}
+func (*Store) Descriptor() ([]byte, []int) {
+	return fileDescriptor_429c672cc4410d8d, []int{1}
+}
+func (m *Store) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Store) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Store.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Store) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Store.Merge(m, src)
+}
+func (m *Store) XXX_Size() int {
+	return m.Size()
+}
+func (m *Store) XXX_DiscardUnknown() {
+	xxx_messageInfo_Store.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Store proto.InternalMessageInfo
+
+func (m *Store) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *Store) GetCounts() []float64 {
+	if m != nil {
+		return m.Counts
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*DDSketchData)(nil), "ddsketch.DDSketchData")
+	proto.RegisterType((*Store)(nil), "ddsketch.Store")
+}
+
+func init() { proto.RegisterFile("ddsketch/ddsketch.proto", fileDescriptor_429c672cc4410d8d) }
+
+var fileDescriptor_429c672cc4410d8d = []byte{
+	// 335 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x91, 0x4d, 0x4e, 0xfb, 0x30,
+	0x10, 0xc5, 0xe3, 0x7e, 0xa6, 0xfe, 0x7f, 0xd0, 0x7a, 0x01, 0x06, 0x89, 0x50, 0x55, 0x42, 0x8a,
+	0x84, 0x68, 0x05, 0x2c, 0x58, 0x53, 0x7a, 0x82, 0xf4, 0x00, 0x91, 0xe3, 0xba, 0x69, 0x44, 0x13,
+	0x57, 0xb1, 0x8d, 0x02, 0xa7, 0xe0, 0x38, 0x1c, 0xa1, 0xcb, 0x2e, 0x59, 0x21, 0xd4, 0x5e, 0x04,
+	0x79, 0x9a, 0x94, 0x1d, 0xbb, 0x79, 0xbf, 0x79, 0x4f, 0x7e, 0x1a, 0xe3, 0x93, 0xd9, 0x4c, 0x3d,
+	0x09, 0xcd, 0x17, 0xa3, 0x6a, 0x18, 0xae, 0x72, 0xa9, 0x25, 0x71, 0x2b, 0x7d, 0x76, 0x1d, 0x27,
+	0x7a, 0x61, 0xa2, 0x21, 0x97, 0xe9, 0x28, 0x96, 0xb1, 0x1c, 0x81, 0x21, 0x32, 0x73, 0x50, 0x20,
+	0x60, 0xda, 0x07, 0x07, 0xef, 0x35, 0xfc, 0x77, 0x32, 0x99, 0x42, 0x76, 0xc2, 0x34, 0x23, 0x57,
+	0xb8, 0x97, 0x8b, 0x25, 0xd3, 0xc9, 0xb3, 0x08, 0x19, 0xe7, 0x26, 0x67, 0xfc, 0x85, 0xa2, 0x3e,
+	0xf2, 0x51, 0xd0, 0xad, 0x16, 0x0f, 0x25, 0x27, 0xa7, 0xd8, 0x4d, 0x59, 0x11, 0x46, 0x49, 0xa6,
+	0x68, 0xad, 0x8f, 0xfc, 0x66, 0xd0, 0x4e, 0x59, 0x31, 0x4e, 0x32, 0x45, 0x6e, 0xb0, 0xbb, 0x92,
+	0x2a, 0xb1, 0x76, 0x5a, 0xef, 0x23, 0xff, 0xcf, 0xed, 0xd1, 0xf0, 0x50, 0x7a, 0xaa, 0x65, 0x2e,
+	0xc6, 0x8d, 0xf5, 0xe7, 0x85, 0x13, 0x1c, 0x6c, 0x36, 0x92, 0x89, 0x18, 0x5e, 0xa0, 0x8d, 0x5f,
+	0x23, 0x95, 0x8d, 0x9c, 0x63, 0xfc, 0x2a, 0x72, 0x19, 0x72, 0x69, 0x32, 0x4d, 0x9b, 0x50, 0xb3,
+	0x63, 0xc9, 0xa3, 0x05, 0xa4, 0x8b, 0xeb, 0x69, 0x92, 0xd1, 0x16, 0x70, 0x3b, 0x02, 0x61, 0x05,
+	0x6d, 0x97, 0x84, 0x15, 0x96, 0x28, 0x93, 0x52, 0x77, 0x4f, 0x94, 0x49, 0xc9, 0x25, 0xfe, 0x9f,
+	0x0b, 0x9e, 0xac, 0x72, 0xc9, 0xd9, 0x32, 0xb4, 0xcb, 0x0e, 0x2c, 0xff, 0xfd, 0xd0, 0xa9, 0x49,
+	0x07, 0xf7, 0xb8, 0x09, 0xa5, 0xc8, 0x31, 0x6e, 0xc9, 0xf9, 0x5c, 0x09, 0x0d, 0x77, 0xea, 0x05,
+	0xa5, 0xb2, 0x1c, 0x7a, 0xd9, 0xdb, 0xd4, 0x7d, 0x14, 0x94, 0x6a, 0x4c, 0xd7, 0x5b, 0x0f, 0x6d,
+	0xb6, 0x1e, 0xfa, 0xda, 0x7a, 0xe8, 0x6d, 0xe7, 0x39, 0x9b, 0x9d, 0xe7, 0x7c, 0xec, 0x3c, 0x27,
+	0x6a, 0xc1, 0xa7, 0xdc, 0x7d, 0x0f, 0x00, 0x37, 0x44, 0xc1, 0x2c, 0xe8, 0x01, 0x00, 0x00,
+}
+
+func (m *DDSketchData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DDSketchData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DDSketchData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ReciprocalSum != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.ReciprocalSum))))
+		i--
+		dAtA[i] = 0x49
+	}
+	if m.Sum != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Sum))))
+		i--
+		dAtA[i] = 0x41
+	}
+	if m.Max != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Max))))
+		i--
+		dAtA[i] = 0x39
+	}
+	if m.Min != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Min))))
+		i--
+		dAtA[i] = 0x31
+	}
+	if m.ZeroCount != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.ZeroCount))))
+		i--
+		dAtA[i] = 0x29
+	}
+	{
+		size, err := m.Negative.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintDdsketch(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x22
+	{
+		size, err := m.Positive.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintDdsketch(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if m.MaxBins != 0 {
+		i = encodeVarintDdsketch(dAtA, i, uint64(m.MaxBins))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.RelativeAccuracy != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.RelativeAccuracy))))
+		i--
+		dAtA[i] = 0x9
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Store) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Store) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Store) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Counts) > 0 {
+		for iNdEx := len(m.Counts) - 1; iNdEx >= 0; iNdEx-- {
+			f3 := math.Float64bits(float64(m.Counts[iNdEx]))
+			i -= 8
+			encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(f3))
+		}
+		i = encodeVarintDdsketch(dAtA, i, uint64(len(m.Counts)*8))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Offset != 0 {
+		i = encodeVarintDdsketch(dAtA, i, uint64((uint32(m.Offset)<<1)^uint32((m.Offset>>31))))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintDdsketch(dAtA []byte, offset int, v uint64) int {
+	offset -= sovDdsketch(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *DDSketchData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RelativeAccuracy != 0 {
+		n += 9
+	}
+	if m.MaxBins != 0 {
+		n += 1 + sovDdsketch(uint64(m.MaxBins))
+	}
+	l = m.Positive.Size()
+	n += 1 + l + sovDdsketch(uint64(l))
+	l = m.Negative.Size()
+	n += 1 + l + sovDdsketch(uint64(l))
+	if m.ZeroCount != 0 {
+		n += 9
+	}
+	if m.Min != 0 {
+		n += 9
+	}
+	if m.Max != 0 {
+		n += 9
+	}
+	if m.Sum != 0 {
+		n += 9
+	}
+	if m.ReciprocalSum != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *Store) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Offset != 0 {
+		n += 1 + sozDdsketch(uint64(m.Offset))
+	}
+	if len(m.Counts) > 0 {
+		n += 1 + sovDdsketch(uint64(len(m.Counts)*8)) + len(m.Counts)*8
+	}
+	return n
+}
+
+func sovDdsketch(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozDdsketch(x uint64) (n int) {
+	return sovDdsketch(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *DDSketchData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDdsketch
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DDSketchData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DDSketchData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RelativeAccuracy", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.RelativeAccuracy = float64(math.Float64frombits(v))
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBins", wireType)
+			}
+			m.MaxBins = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDdsketch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBins |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Positive", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDdsketch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDdsketch
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDdsketch
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Positive.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Negative", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDdsketch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDdsketch
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDdsketch
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Negative.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ZeroCount", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.ZeroCount = float64(math.Float64frombits(v))
+		case 6:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Min", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Min = float64(math.Float64frombits(v))
+		case 7:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Max", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Max = float64(math.Float64frombits(v))
+		case 8:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sum", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Sum = float64(math.Float64frombits(v))
+		case 9:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReciprocalSum", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.ReciprocalSum = float64(math.Float64frombits(v))
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDdsketch(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDdsketch
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Store) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDdsketch
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Store: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Store: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			var v int32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDdsketch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			v = int32((uint32(v) >> 1) ^ uint32(((v&1)<<31)>>31))
+			m.Offset = v
+		case 2:
+			if wireType == 1 {
+				var v uint64
+				if (iNdEx + 8) > l {
+					return io.ErrUnexpectedEOF
+				}
+				v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+				iNdEx += 8
+				v2 := float64(math.Float64frombits(v))
+				m.Counts = append(m.Counts, v2)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowDdsketch
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthDdsketch
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthDdsketch
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				elementCount = packedLen / 8
+				if elementCount != 0 && len(m.Counts) == 0 {
+					m.Counts = make([]float64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					if (iNdEx + 8) > l {
+						return io.ErrUnexpectedEOF
+					}
+					v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+					iNdEx += 8
+					v2 := float64(math.Float64frombits(v))
+					m.Counts = append(m.Counts, v2)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Counts", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDdsketch(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDdsketch
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipDdsketch(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowDdsketch
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowDdsketch
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowDdsketch
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthDdsketch
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupDdsketch
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthDdsketch
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthDdsketch        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowDdsketch          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupDdsketch = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,158 @@
+package ddsketch
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/veneur/tdigest"
+)
+
+// exactQuantile returns the value of the given rank in sorted, which is
+// what DDSketch approximates.
+func exactQuantile(sorted []float64, q float64) float64 {
+	return sorted[int(q*float64(len(sorted)-1))]
+}
+
+func TestDDSketchRelativeAccuracy(t *testing.T) {
+	rand.Seed(time.Now().Unix())
+
+	dd := New(0.01, 0)
+	values := make([]float64, 0, 100000)
+	for i := 0; i < 100000; i++ {
+		// latencies are typically long-tailed
+		v := math.Exp(rand.NormFloat64() * 2)
+		values = append(values, v)
+		dd.Add(v, 1.0)
+	}
+	sort.Float64s(values)
+
+	for _, q := range []float64{0.01, 0.25, 0.5, 0.75, 0.9, 0.99, 0.999, 0.9999} {
+		expected := exactQuantile(values, q)
+		assert.InEpsilon(t, expected, dd.Quantile(q), 0.0101, "quantile %v", q)
+	}
+	assert.Equal(t, values[0], dd.Quantile(0))
+	assert.Equal(t, values[len(values)-1], dd.Quantile(1))
+	assert.Equal(t, float64(len(values)), dd.Count())
+	assert.Equal(t, values[0], dd.Min())
+	assert.Equal(t, values[len(values)-1], dd.Max())
+}
+
+func TestDDSketchNegativeAndZero(t *testing.T) {
+	dd := New(0.01, 0)
+	for _, v := range []float64{-100, -10, 0, 0, 10, 100} {
+		dd.Add(v, 1.0)
+	}
+
+	assert.Equal(t, float64(-100), dd.Quantile(0))
+	assert.InEpsilon(t, -10, dd.Quantile(0.2), 0.01)
+	assert.Equal(t, float64(0), dd.Quantile(0.5))
+	assert.InEpsilon(t, 10, dd.Quantile(0.8), 0.01)
+	assert.Equal(t, float64(100), dd.Quantile(1))
+	assert.Equal(t, float64(0), dd.Sum())
+	assert.True(t, math.IsNaN(New(0.01, 0).Quantile(0.5)), "empty sketches should have no quantiles")
+}
+
+func TestDDSketchWeights(t *testing.T) {
+	dd := New(0.01, 0)
+	dd.Add(1, 1)
+	dd.Add(1000, 9)
+
+	assert.Equal(t, float64(10), dd.Count())
+	assert.Equal(t, float64(9001), dd.Sum())
+	assert.InEpsilon(t, 1000, dd.Quantile(0.5), 0.01)
+}
+
+func TestDDSketchCollapse(t *testing.T) {
+	dd := New(0.01, 64)
+	for i := 0; i < 1000; i++ {
+		dd.Add(math.Pow(1.1, float64(i)), 1.0)
+	}
+
+	assert.Len(t, dd.positive.counts, 64, "the sketch should not grow beyond its maximum number of bins")
+	assert.Equal(t, float64(1000), dd.positive.total(), "collapsing should not lose any weight")
+	// the highest values are unaffected by collapsing:
+	assert.InEpsilon(t, math.Pow(1.1, 998), dd.Quantile(0.999), 0.01)
+
+	// adding a value below the collapsed buckets keeps the size bounded
+	dd.Add(0.001, 1.0)
+	assert.Len(t, dd.positive.counts, 64)
+	assert.Equal(t, 0.001, dd.Quantile(0), "the minimum should still be exact")
+}
+
+func TestDDSketchMerge(t *testing.T) {
+	rand.Seed(time.Now().Unix())
+
+	all := New(0.01, 0)
+	parts := []*DDSketch{New(0.01, 0), New(0.01, 0)}
+	for i := 0; i < 10000; i++ {
+		v := rand.ExpFloat64()
+		all.Add(v, 1.0)
+		parts[i%2].Add(v, 1.0)
+	}
+
+	merged := New(0.01, 0)
+	for _, part := range parts {
+		merged.Merge(part)
+	}
+	for _, q := range []float64{0, 0.5, 0.99, 0.999, 1} {
+		assert.Equal(t, all.Quantile(q), merged.Quantile(q), "quantile %v", q)
+	}
+	assert.Equal(t, all.Count(), merged.Count())
+	assert.InEpsilon(t, all.Sum(), merged.Sum(), 1e-9)
+	assert.InEpsilon(t, all.ReciprocalSum(), merged.ReciprocalSum(), 1e-9)
+
+	// sketches with a different accuracy are still mergeable, with the
+	// accuracy of both combined
+	coarse := New(0.05, 0)
+	coarse.Merge(all)
+	assert.Equal(t, all.Count(), coarse.Count())
+	assert.InEpsilon(t, all.Quantile(0.99), coarse.Quantile(0.99), 0.0601)
+}
+
+func TestDDSketchMergeTDigest(t *testing.T) {
+	rand.Seed(time.Now().Unix())
+
+	td := tdigest.NewMerging(100, false)
+	values := make([]float64, 0, 10000)
+	for i := 0; i < 10000; i++ {
+		v := rand.Float64() * 100
+		values = append(values, v)
+		td.Add(v, 1.0)
+	}
+	sort.Float64s(values)
+
+	dd := New(0.01, 0)
+	dd.MergeTDigest(td)
+	assert.Equal(t, td.Count(), dd.Count())
+	assert.Equal(t, td.Sum(), dd.Sum())
+	assert.Equal(t, td.Min(), dd.Min())
+	assert.Equal(t, td.Max(), dd.Max())
+	assert.InEpsilon(t, exactQuantile(values, 0.5), dd.Quantile(0.5), 0.05)
+}
+
+func TestDDSketchData(t *testing.T) {
+	dd := New(0.02, 128)
+	for _, v := range []float64{-5, 0, 0.5, 3, 3, 1e6} {
+		dd.Add(v, 2.0)
+	}
+
+	buf, err := dd.Data().Marshal()
+	require.NoError(t, err)
+	data := &DDSketchData{}
+	require.NoError(t, data.Unmarshal(buf))
+
+	decoded := NewFromData(data)
+	assert.Equal(t, dd.RelativeAccuracy(), decoded.RelativeAccuracy())
+	assert.Equal(t, dd.maxBins, decoded.maxBins)
+	assert.Equal(t, dd.Count(), decoded.Count())
+	assert.Equal(t, dd.Sum(), decoded.Sum())
+	assert.Equal(t, dd.ReciprocalSum(), decoded.ReciprocalSum())
+	for _, q := range []float64{0, 0.1, 0.3, 0.5, 0.8, 1} {
+		assert.Equal(t, dd.Quantile(q), decoded.Quantile(q), "quantile %v", q)
+	}
+}
@@ -1,5 +1,13 @@
 package veneur
 
+// The names of the discoverers that veneur-proxy can be configured with.
+const (
+	consulDiscoverer     = "consul"
+	kubernetesDiscoverer = "kubernetes"
+	dnsSRVDiscoverer     = "dns_srv"
+	fileDiscoverer       = "file"
+)
+
 // Discoverer is an interface for various service discovery mechanisms.
 // You could implement your own by implementing this method! See consul.go
 type Discoverer interface {
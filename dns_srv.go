@@ -0,0 +1,62 @@
+package veneur
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// dnsSRVTimeout is how long a DNSSRVDiscoverer waits for its lookups.
+const dnsSRVTimeout = 10 * time.Second
+
+// DNSSRVDiscoverer is a Discoverer that looks up the DNS SRV records of a
+// service name, like "_veneur-global._tcp.example.com", and returns the
+// target and port of each.
+//
+// Only the records with the lowest priority are returned, since the others
+// are backups for when those are unavailable.
+type DNSSRVDiscoverer struct {
+	lookupSRV func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+}
+
+// NewDNSSRVDiscoverer creates a DNSSRVDiscoverer that looks records up with
+// resolver, or with the default resolver if it's nil.
+func NewDNSSRVDiscoverer(resolver *net.Resolver) *DNSSRVDiscoverer {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	return &DNSSRVDiscoverer{lookupSRV: resolver.LookupSRV}
+}
+
+// GetDestinationsForService returns the destinations in the SRV records of
+// serviceName, in the form "<host>:<port>".
+func (d *DNSSRVDiscoverer) GetDestinationsForService(serviceName string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dnsSRVTimeout)
+	defer cancel()
+
+	_, records, err := d.lookupSRV(ctx, "", "", serviceName)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) < 1 {
+		return nil, errors.New("Received no SRV records")
+	}
+
+	priority := records[0].Priority
+	for _, record := range records {
+		if record.Priority < priority {
+			priority = record.Priority
+		}
+	}
+	hosts := make([]string, 0, len(records))
+	for _, record := range records {
+		if record.Priority != priority {
+			continue
+		}
+		hosts = append(hosts, fmt.Sprintf("%s:%d", strings.TrimSuffix(record.Target, "."), record.Port))
+	}
+	return hosts, nil
+}
@@ -0,0 +1,44 @@
+package veneur
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDNSSRVDiscoverer(t *testing.T) {
+	d := &DNSSRVDiscoverer{
+		lookupSRV: func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+			assert.Equal(t, "_veneur-global._tcp.example.com", name)
+			return "", []*net.SRV{
+				{Target: "global-1.example.com.", Port: 8127, Priority: 10},
+				{Target: "backup.example.com.", Port: 8127, Priority: 20},
+				{Target: "global-2.example.com.", Port: 8128, Priority: 10},
+			}, nil
+		},
+	}
+
+	hosts, err := d.GetDestinationsForService("_veneur-global._tcp.example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"global-1.example.com:8127", "global-2.example.com:8128"}, hosts,
+		"only the records with the lowest priority should be used")
+}
+
+func TestDNSSRVDiscovererErrors(t *testing.T) {
+	d := &DNSSRVDiscoverer{
+		lookupSRV: func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+			return "", nil, errors.New("no such host")
+		},
+	}
+	_, err := d.GetDestinationsForService("_veneur-global._tcp.example.com")
+	assert.Error(t, err)
+
+	d.lookupSRV = func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+		return "", nil, nil
+	}
+	_, err = d.GetDestinationsForService("_veneur-global._tcp.example.com")
+	assert.Error(t, err, "no records should be an error")
+}
@@ -0,0 +1,83 @@
+package veneur
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// FileDiscoverer is a Discoverer that reads destinations from a YAML (or
+// JSON) file, which maps each service name to a list of destinations:
+//
+//	veneur-global: ["10.0.0.1:8127", "10.0.0.2:8127"]
+//	veneur-global-grpc: ["10.0.0.1:8128", "10.0.0.2:8128"]
+//
+// The file is read again when its modification time or size changes, so
+// destinations can be changed without restarting.
+type FileDiscoverer struct {
+	path string
+
+	mtx      sync.Mutex
+	modTime  time.Time
+	size     int64
+	services map[string][]string
+}
+
+// NewFileDiscoverer creates a FileDiscoverer, and reads its file for the
+// first time.
+func NewFileDiscoverer(path string) (*FileDiscoverer, error) {
+	fd := &FileDiscoverer{path: path}
+	if err := fd.reload(); err != nil {
+		return nil, err
+	}
+	return fd, nil
+}
+
+// GetDestinationsForService returns the destinations listed for serviceName
+// in the file.
+func (fd *FileDiscoverer) GetDestinationsForService(serviceName string) ([]string, error) {
+	if err := fd.reload(); err != nil {
+		return nil, err
+	}
+
+	fd.mtx.Lock()
+	defer fd.mtx.Unlock()
+	hosts, ok := fd.services[serviceName]
+	if !ok {
+		return nil, fmt.Errorf("the service %q isn't listed in %s", serviceName, fd.path)
+	}
+	return append([]string(nil), hosts...), nil
+}
+
+// reload reads the file if it changed since it was last read.
+func (fd *FileDiscoverer) reload() error {
+	info, err := os.Stat(fd.path)
+	if err != nil {
+		return err
+	}
+
+	fd.mtx.Lock()
+	defer fd.mtx.Unlock()
+	if fd.services != nil && info.ModTime().Equal(fd.modTime) && info.Size() == fd.size {
+		return nil
+	}
+
+	bts, err := ioutil.ReadFile(fd.path)
+	if err != nil {
+		return err
+	}
+	services := map[string][]string{}
+	if err := yaml.Unmarshal(bts, &services); err != nil {
+		return fmt.Errorf("could not parse the destinations in %s: %v", fd.path, err)
+	}
+
+	log.WithField("path", fd.path).Info("Read destinations from file")
+	fd.services = services
+	fd.modTime = info.ModTime()
+	fd.size = info.Size()
+	return nil
+}
@@ -0,0 +1,90 @@
+package veneur
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileDiscovererReloads(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test_file_discovery")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "destinations.yaml")
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`
+forwardServiceName:
+  - "10.1.10.12:8000"
+  - "10.1.10.13:8000"
+`), 0644))
+	fd, err := NewFileDiscoverer(path)
+	require.NoError(t, err)
+
+	hosts, err := fd.GetDestinationsForService("forwardServiceName")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"10.1.10.12:8000", "10.1.10.13:8000"}, hosts)
+	_, err = fd.GetDestinationsForService("traceServiceName")
+	assert.Error(t, err, "services that aren't listed should be an error")
+
+	// JSON works too, and the file is read again when it changes:
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"forwardServiceName": ["10.1.10.14:8000"]}`), 0644))
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, later, later))
+	hosts, err = fd.GetDestinationsForService("forwardServiceName")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"10.1.10.14:8000"}, hosts)
+
+	// a broken file is an error, which leaves the destinations as they were:
+	require.NoError(t, ioutil.WriteFile(path, []byte(`forwardServiceName: [`), 0644))
+	_, err = fd.GetDestinationsForService("forwardServiceName")
+	assert.Error(t, err)
+}
+
+func TestNewFileDiscovererErrors(t *testing.T) {
+	_, err := NewFileDiscoverer("/nonexistent/destinations.yaml")
+	assert.Error(t, err)
+}
+
+func TestProxyRefreshesFromFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test_file_discovery")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "destinations.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"forwardServiceName": ["10.1.10.12:8000", "10.1.10.13:8000"]}`), 0644))
+
+	proxyConfig := generateProxyConfig()
+	proxyConfig.ConsulTraceServiceName = ""
+	proxyConfig.Discoverer = "file"
+	proxyConfig.DiscovererFile = path
+	proxy, err := NewProxyFromConfig(logrus.New(), proxyConfig)
+	require.NoError(t, err)
+	assert.False(t, proxy.usingConsul, "Proxy isn't using consul")
+
+	proxy.Discoverer, err = NewFileDiscoverer(path)
+	require.NoError(t, err)
+	proxy.RefreshDestinations(proxy.ConsulForwardService, proxy.ForwardDestinations, &proxy.ForwardDestinationsMtx)
+	assert.ElementsMatch(t, []string{"10.1.10.12:8000", "10.1.10.13:8000"}, proxy.ForwardDestinations.Members())
+}
+
+func TestProxyDiscovererConfig(t *testing.T) {
+	proxyConfig := generateProxyConfig()
+	proxyConfig.Discoverer = "dns_srv"
+	proxy, err := NewProxyFromConfig(logrus.New(), proxyConfig)
+	assert.NoError(t, err)
+	assert.True(t, proxy.usingDNSSRV, "Proxy should use DNS SRV records")
+	assert.False(t, proxy.usingConsul, "Proxy isn't using consul")
+
+	proxyConfig.Discoverer = "file"
+	_, err = NewProxyFromConfig(logrus.New(), proxyConfig)
+	assert.Error(t, err, "the file discoverer needs a file")
+
+	proxyConfig.Discoverer = "zookeeper"
+	_, err = NewProxyFromConfig(logrus.New(), proxyConfig)
+	assert.Error(t, err, "unknown discoverers should be an error")
+}
@@ -3,6 +3,7 @@ package veneur
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"net/http"
 	"reflect"
 	"runtime"
@@ -21,6 +22,7 @@ import (
 	"github.com/stripe/veneur/ssf"
 	"github.com/stripe/veneur/trace"
 	"github.com/stripe/veneur/trace/metrics"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
@@ -55,8 +57,6 @@ func (s *Server) Flush(ctx context.Context) {
 
 	go s.flushTraces(span.Attach(ctx))
 
-	var finalMetrics []samplers.InterMetric
-
 	// This ensures that mixedscope histograms and timers behave correctly.
 	// That is, they should emit aggregates when forwarding, but no percentiles.
 	// Similarly, they should emit percentiles when global, but no aggregates.
@@ -66,15 +66,12 @@ func (s *Server) Flush(ctx context.Context) {
 	//   * Avoid double counting and breaking existing queries (if count is also
 	//     emitted globally, queries that sum over counts double!)
 	var percentiles []float64
-	aggregates := s.HistogramAggregates
 	if !s.IsLocal() {
 		percentiles = s.HistogramPercentiles
-		aggregates = samplers.HistogramAggregates{}
 	}
 
-	tempMetrics, ms := s.tallyMetrics(percentiles)
-
-	finalMetrics = s.generateInterMetrics(span.Attach(ctx), percentiles, aggregates, tempMetrics, ms)
+	tempMetrics, ms := s.tallyMetrics(span.Attach(ctx), percentiles)
+	finalMetrics, sketchMetrics := s.sinkMetrics(span.Attach(ctx), tempMetrics, ms)
 
 	s.reportMetricsFlushCounts(ms)
 
@@ -98,20 +95,14 @@ func (s *Server) Flush(ctx context.Context) {
 	}
 
 	// If there's nothing to flush, don't bother calling the plugins and stuff.
+	// There may still be something to forward, as on a regional veneur that
+	// only imports metrics, and returning cancels the forward's context.
 	if len(finalMetrics) == 0 {
+		wg.Wait()
 		return
 	}
 
-	for _, sink := range s.metricSinks {
-		wg.Add(1)
-		go func(ms sinks.MetricSink) {
-			err := ms.Flush(span.Attach(ctx), finalMetrics)
-			if err != nil {
-				log.WithError(err).WithField("sink", ms.Name()).Warn("Error flushing sink")
-			}
-			wg.Done()
-		}(sink)
-	}
+	s.flushSinks(span.Attach(ctx), finalMetrics, sketchMetrics)
 	wg.Wait()
 
 	go func() {
@@ -131,6 +122,49 @@ func (s *Server) Flush(ctx context.Context) {
 	}()
 }
 
+// sinkMetrics generates the InterMetrics that the metric sinks receive.
+// Sinks that accept sketches receive sketchMetrics, in which sketches take
+// the place of the percentiles in finalMetrics.
+func (s *Server) sinkMetrics(ctx context.Context, tempMetrics []WorkerMetrics, ms metricsSummary) (finalMetrics, sketchMetrics []samplers.InterMetric) {
+	sketches := false
+	for _, sink := range s.metricSinks {
+		if sinks.AcceptsSketches(sink) {
+			sketches = true
+			break
+		}
+	}
+
+	finalMetrics, percentileMetrics, sketchMetrics := s.generateInterMetrics(ctx, tempMetrics, ms, sketches)
+
+	if sketches {
+		common := finalMetrics[:len(finalMetrics):len(finalMetrics)]
+		finalMetrics = append(common, percentileMetrics...)
+		sketchMetrics = append(common, sketchMetrics...)
+	}
+	return finalMetrics, sketchMetrics
+}
+
+// flushSinks flushes metrics to every metric sink, and waits for them to
+// finish.
+func (s *Server) flushSinks(ctx context.Context, finalMetrics, sketchMetrics []samplers.InterMetric) {
+	wg := sync.WaitGroup{}
+	for _, sink := range s.metricSinks {
+		wg.Add(1)
+		go func(ms sinks.MetricSink) {
+			metrics := finalMetrics
+			if sinks.AcceptsSketches(ms) {
+				metrics = sketchMetrics
+			}
+			err := ms.Flush(ctx, metrics)
+			if err != nil {
+				log.WithError(err).WithField("sink", ms.Name()).Warn("Error flushing sink")
+			}
+			wg.Done()
+		}(sink)
+	}
+	wg.Wait()
+}
+
 func (s *Server) tallyTimeseries() int64 {
 	allTimeseries := hyperloglog.New()
 	for _, w := range s.Workers {
@@ -159,6 +193,8 @@ type metricsSummary struct {
 	totalLocalTimers       int
 	totalLocalStatusChecks int
 
+	totalDistributions int
+
 	totalLength int
 }
 
@@ -166,18 +202,35 @@ type metricsSummary struct {
 // of metrics we'll be reporting, so that we can pre-allocate
 // a slice of the correct length instead of constantly appending
 // for performance
-func (s *Server) tallyMetrics(percentiles []float64) ([]WorkerMetrics, metricsSummary) {
+//
+// During the grace period of a ring change announced by a proxy, the
+// metrics that moved to another global veneur are handed off to it here,
+// and aren't counted.
+func (s *Server) tallyMetrics(ctx context.Context, percentiles []float64) ([]WorkerMetrics, metricsSummary) {
 	// allocating this long array to count up the sizes is cheaper than appending
 	// the []WorkerMetrics together one at a time
-	tempMetrics := make([]WorkerMetrics, 0, len(s.Workers))
+	tempMetrics := make([]WorkerMetrics, 0, len(s.Workers)+1)
 
 	ms := metricsSummary{}
 
 	for i, w := range s.Workers {
 		log.WithField("worker", i).Debug("Flushing")
-		wm := w.Flush()
-		tempMetrics = append(tempMetrics, wm)
+		tempMetrics = append(tempMetrics, w.Flush())
+	}
 
+	if handoff := s.activeHandoff(); handoff != nil {
+		moved := map[string][]*metricpb.Metric{}
+		for _, wm := range tempMetrics {
+			for dest, batch := range wm.handOff(handoff.owner, s.TraceClient) {
+				moved[dest] = append(moved[dest], batch...)
+			}
+		}
+		if len(moved) > 0 {
+			tempMetrics = append(tempMetrics, s.handOff(ctx, moved))
+		}
+	}
+
+	for _, wm := range tempMetrics {
 		ms.totalCounters += len(wm.counters)
 		ms.totalGauges += len(wm.gauges)
 		ms.totalHistograms += len(wm.histograms)
@@ -194,6 +247,8 @@ func (s *Server) tallyMetrics(percentiles []float64) ([]WorkerMetrics, metricsSu
 		ms.totalLocalTimers += len(wm.localTimers)
 
 		ms.totalLocalStatusChecks += len(wm.localStatusChecks)
+
+		ms.totalDistributions += len(wm.distributions)
 	}
 
 	ms.totalLength = ms.totalCounters + ms.totalGauges +
@@ -214,6 +269,7 @@ func (s *Server) tallyMetrics(percentiles []float64) ([]WorkerMetrics, metricsSu
 		ms.totalLength += ms.totalGlobalGauges
 		ms.totalLength += ms.totalGlobalHistograms * (s.HistogramAggregates.Count + len(s.HistogramPercentiles))
 		ms.totalLength += ms.totalGlobalTimers * (s.HistogramAggregates.Count + len(s.HistogramPercentiles))
+		ms.totalLength += ms.totalDistributions * (s.HistogramAggregates.Count + len(s.HistogramPercentiles))
 	}
 
 	return tempMetrics, ms
@@ -222,12 +278,33 @@ func (s *Server) tallyMetrics(percentiles []float64) ([]WorkerMetrics, metricsSu
 // generateInterMetrics calls the Flush method on each
 // counter/gauge/histogram/timer/set in order to
 // generate an InterMetric corresponding to that value
-func (s *Server) generateInterMetrics(ctx context.Context, percentiles []float64, aggregates samplers.HistogramAggregates, tempMetrics []WorkerMetrics, ms metricsSummary) []samplers.InterMetric {
+//
+// The percentiles and aggregates of each histogram, timer and distribution
+// are those of the first of s.HistogramRules that matches it, if any.
+//
+// If sketches is set, the percentiles of histograms, timers and
+// distributions are returned separately from finalMetrics, alongside a
+// sketch of each, so that sinks that accept sketches can receive those in
+// place of the percentiles.
+func (s *Server) generateInterMetrics(ctx context.Context, tempMetrics []WorkerMetrics, ms metricsSummary, sketches bool) (finalMetrics, percentileMetrics, sketchMetrics []samplers.InterMetric) {
 
 	span, _ := trace.StartSpanFromContext(ctx, "")
 	defer span.ClientFinish(s.TraceClient)
 
-	finalMetrics := make([]samplers.InterMetric, 0, ms.totalLength)
+	finalMetrics = make([]samplers.InterMetric, 0, ms.totalLength)
+	flushHisto := func(h *samplers.Histo, withPercentiles, global bool) {
+		ps, aggs := s.HistogramRules.For(h.Name, h.Tags, s.HistogramPercentiles, s.HistogramAggregates)
+		if !withPercentiles {
+			ps = nil
+		}
+		if !sketches || len(ps) == 0 {
+			finalMetrics = append(finalMetrics, h.Flush(s.interval, ps, aggs, global)...)
+			return
+		}
+		finalMetrics = append(finalMetrics, h.Flush(s.interval, nil, aggs, global)...)
+		percentileMetrics = append(percentileMetrics, h.Flush(s.interval, ps, samplers.HistogramAggregates{}, global)...)
+		sketchMetrics = append(sketchMetrics, h.FlushSketch())
+	}
 	for _, wm := range tempMetrics {
 		for _, c := range wm.counters {
 			finalMetrics = append(finalMetrics, c.Flush(s.interval)...)
@@ -235,29 +312,38 @@ func (s *Server) generateInterMetrics(ctx context.Context, percentiles []float64
 		for _, g := range wm.gauges {
 			finalMetrics = append(finalMetrics, g.Flush()...)
 		}
-		// if we're a local veneur, then percentiles=nil, and only the local
-		// parts (count, min, max) will be flushed
+		// if we're a local veneur, then no percentiles are flushed, and only
+		// the local parts (count, min, max) will be flushed
 		//
 		// if we're a global veneur, aggregates will be nil.
+		//
+		// a local veneur that other veneurs forward to (a regional one) has
+		// nothing of its own to flush for the histograms it only imported;
+		// they're forwarded to the global veneur in their entirety
 		for _, h := range wm.histograms {
-			finalMetrics = append(finalMetrics, h.Flush(s.interval, percentiles, s.HistogramAggregates, false)...)
+			if s.IsLocal() && h.LocalWeight == 0 {
+				continue
+			}
+			flushHisto(h, !s.IsLocal(), false)
 		}
 		for _, t := range wm.timers {
-			finalMetrics = append(finalMetrics, t.Flush(s.interval, percentiles, s.HistogramAggregates, false)...)
+			if s.IsLocal() && t.LocalWeight == 0 {
+				continue
+			}
+			flushHisto(t, !s.IsLocal(), false)
 		}
 
 		// local-only samplers should be flushed in their entirety, since they
 		// will not be forwarded
-		// we still want percentiles for these, even if we're a local veneur, so
-		// we use the original percentile list when flushing them
+		// we still want percentiles for these, even if we're a local veneur
 		for _, h := range wm.localHistograms {
-			finalMetrics = append(finalMetrics, h.Flush(s.interval, s.HistogramPercentiles, s.HistogramAggregates, false)...)
+			flushHisto(h, true, false)
 		}
 		for _, s := range wm.localSets {
 			finalMetrics = append(finalMetrics, s.Flush()...)
 		}
 		for _, t := range wm.localTimers {
-			finalMetrics = append(finalMetrics, t.Flush(s.interval, s.HistogramPercentiles, s.HistogramAggregates, false)...)
+			flushHisto(t, true, false)
 		}
 
 		for _, status := range wm.localStatusChecks {
@@ -286,15 +372,20 @@ func (s *Server) generateInterMetrics(ctx context.Context, percentiles []float64
 			}
 
 			for _, h := range wm.globalHistograms {
-				finalMetrics = append(finalMetrics, h.Flush(s.interval, s.HistogramPercentiles, s.HistogramAggregates, true)...)
+				flushHisto(h, true, true)
 			}
 			for _, h := range wm.globalTimers {
-				finalMetrics = append(finalMetrics, h.Flush(s.interval, s.HistogramPercentiles, s.HistogramAggregates, true)...)
+				flushHisto(h, true, true)
+			}
+
+			// distributions are always global
+			for _, d := range wm.distributions {
+				flushHisto(d, true, true)
 			}
 		}
 	}
 
-	return finalMetrics
+	return finalMetrics, percentileMetrics, sketchMetrics
 }
 
 const flushTotalMetric = "worker.metrics_flushed_total"
@@ -333,11 +424,44 @@ func (s *Server) reportGlobalMetricsFlushCounts(ms metricsSummary) {
 	s.Statsd.Count(flushTotalMetric, int64(ms.totalHistograms), []string{"metric_type:histogram"}, 1.0)
 	s.Statsd.Count(flushTotalMetric, int64(ms.totalSets), []string{"metric_type:set"}, 1.0)
 	s.Statsd.Count(flushTotalMetric, int64(ms.totalTimers), []string{"metric_type:timer"}, 1.0)
+	s.Statsd.Count(flushTotalMetric, int64(ms.totalDistributions), []string{"metric_type:distribution"}, 1.0)
 }
 
 func (s *Server) flushForward(ctx context.Context, wms []WorkerMetrics) {
 	span, _ := trace.StartSpanFromContext(ctx, "")
 	defer span.ClientFinish(s.TraceClient)
+	timestamp := time.Now().Unix()
+
+	exportStart := time.Now()
+	jsonMetrics := exportJSONMetrics(wms)
+	s.Statsd.TimeInMilliseconds("forward.duration_ns", float64(time.Since(exportStart).Nanoseconds()), []string{"part:export"}, 1.0)
+	s.Statsd.Count("forward.post_metrics_total", int64(len(jsonMetrics)), nil, 1.0)
+	if len(jsonMetrics) == 0 {
+		log.Debug("Nothing to forward, skipping.")
+		return
+	}
+
+	// the error has already been logged (if there was one), so we only care
+	// about the success case
+	endpoint := fmt.Sprintf("%s/import", s.ForwardAddr)
+	if vhttp.PostHelper(span.Attach(ctx), s.HTTPClient, s.TraceClient, http.MethodPost, endpoint, jsonMetrics, "forward", true, nil, log) == nil {
+		log.WithFields(logrus.Fields{
+			"metrics":     len(jsonMetrics),
+			"endpoint":    endpoint,
+			"forwardAddr": s.ForwardAddr,
+		}).Info("Completed forward to upstream Veneur")
+	} else if s.forwardSpool != nil {
+		var metrics []*metricpb.Metric
+		for _, wm := range wms {
+			metrics = append(metrics, wm.ForwardableMetrics(s.TraceClient)...)
+		}
+		s.spoolForward(metrics, timestamp)
+	}
+}
+
+// exportJSONMetrics exports the metrics that a local veneur forwards over
+// HTTP.
+func exportJSONMetrics(wms []WorkerMetrics) []samplers.JSONMetric {
 	jmLength := 0
 	for _, wm := range wms {
 		jmLength += len(wm.globalCounters)
@@ -345,10 +469,10 @@ func (s *Server) flushForward(ctx context.Context, wms []WorkerMetrics) {
 		jmLength += len(wm.histograms)
 		jmLength += len(wm.sets)
 		jmLength += len(wm.timers)
+		jmLength += len(wm.distributions)
 	}
 
 	jsonMetrics := make([]samplers.JSONMetric, 0, jmLength)
-	exportStart := time.Now()
 	for _, wm := range wms {
 		for _, count := range wm.globalCounters {
 			jm, err := count.Export()
@@ -412,24 +536,21 @@ func (s *Server) flushForward(ctx context.Context, wms []WorkerMetrics) {
 			jm.Type = "timer"
 			jsonMetrics = append(jsonMetrics, jm)
 		}
+		for _, distribution := range wm.distributions {
+			jm, err := distribution.Export()
+			if err != nil {
+				log.WithFields(logrus.Fields{
+					logrus.ErrorKey: err,
+					"type":          distributionTypeName,
+					"name":          distribution.Name,
+				}).Error("Could not export metric")
+				continue
+			}
+			jm.Type = distributionTypeName
+			jsonMetrics = append(jsonMetrics, jm)
+		}
 	}
-	s.Statsd.TimeInMilliseconds("forward.duration_ns", float64(time.Since(exportStart).Nanoseconds()), []string{"part:export"}, 1.0)
-	s.Statsd.Count("forward.post_metrics_total", int64(len(jsonMetrics)), nil, 1.0)
-	if len(jsonMetrics) == 0 {
-		log.Debug("Nothing to forward, skipping.")
-		return
-	}
-
-	// the error has already been logged (if there was one), so we only care
-	// about the success case
-	endpoint := fmt.Sprintf("%s/import", s.ForwardAddr)
-	if vhttp.PostHelper(span.Attach(ctx), s.HTTPClient, s.TraceClient, http.MethodPost, endpoint, jsonMetrics, "forward", true, nil, log) == nil {
-		log.WithFields(logrus.Fields{
-			"metrics":     len(jsonMetrics),
-			"endpoint":    endpoint,
-			"forwardAddr": s.ForwardAddr,
-		}).Info("Completed forward to upstream Veneur")
-	}
+	return jsonMetrics
 }
 
 func (s *Server) flushTraces(ctx context.Context) {
@@ -470,12 +591,50 @@ func (s *Server) flushTraces(ctx context.Context) {
 	s.SpanWorker.Flush()
 }
 
+// minForwardBackoff is how long a local veneur waits at first before it
+// forwards metrics over gRPC again, after they were rejected because the
+// destination was overloaded.
+const minForwardBackoff = 100 * time.Millisecond
+
+// sendForward forwards metrics over gRPC. While the destination rejects them
+// with codes.ResourceExhausted, which veneur-proxy does when it's
+// overloaded, it sends them again after a jittered, exponentially growing
+// backoff, as long as it can do so before the deadline of ctx. Only the
+// metrics that weren't delivered yet are sent again. It returns the number
+// of times it sent them again, and the metrics that couldn't be delivered.
+func (s *Server) sendForward(ctx context.Context, metrics []*metricpb.Metric) (int, []*metricpb.Metric, error) {
+	c := forwardrpc.NewForwardClient(s.grpcForwardConn)
+	backoff := minForwardBackoff
+	for retries := 0; ; retries++ {
+		err := forwardrpc.Send(ctx, c, metrics, 0, s.grpcBatchSize)
+		metrics = forwardrpc.Unsent(err, metrics)
+		if status.Code(err) != codes.ResourceExhausted {
+			return retries, metrics, err
+		}
+
+		// wait between half the backoff and the whole backoff, so that
+		// the local veneurs rejected at the same time don't all come
+		// back at the same time
+		wait := backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			return retries, metrics, err
+		}
+		select {
+		case <-ctx.Done():
+			return retries, metrics, err
+		case <-time.After(wait):
+		}
+		backoff *= 2
+	}
+}
+
 // forwardGRPC forwards all input metrics to a downstream Veneur, over gRPC.
 func (s *Server) forwardGRPC(ctx context.Context, wms []WorkerMetrics) {
 	span, _ := trace.StartSpanFromContext(ctx, "")
 	span.SetTag("protocol", "grpc")
 	defer span.ClientFinish(s.TraceClient)
 
+	timestamp := time.Now().Unix()
 	exportStart := time.Now()
 
 	// Collect all of the forwardable metrics from the various WorkerMetrics.
@@ -504,12 +663,16 @@ func (s *Server) forwardGRPC(ctx context.Context, wms []WorkerMetrics) {
 		"grpcstate":   s.grpcForwardConn.GetState().String(),
 	})
 
-	c := forwardrpc.NewForwardClient(s.grpcForwardConn)
-
 	grpcStart := time.Now()
-	_, err := c.SendMetrics(ctx, &forwardrpc.MetricList{Metrics: metrics})
+	retries, unsent, err := s.sendForward(ctx, metrics)
+	span.Add(ssf.Count("forward.retries_total", float32(retries), nil))
 	if err != nil {
-		if ctx.Err() != nil {
+		if status.Code(err) == codes.ResourceExhausted {
+			// The proxy is overloaded, and spooling the metrics is how we
+			// back off.
+			span.Add(ssf.Count("forward.error_total", 1, map[string]string{"cause": "resource_exhausted"}))
+			entry.WithError(err).Warn("An upstream Veneur rejected the metrics it was forwarded")
+		} else if ctx.Err() != nil {
 			// We exceeded the deadline of the flush context.
 			span.Add(ssf.Count("forward.error_total", 1, map[string]string{"cause": "deadline_exceeded"}))
 		} else if statErr, ok := status.FromError(err); ok &&
@@ -522,6 +685,7 @@ func (s *Server) forwardGRPC(ctx context.Context, wms []WorkerMetrics) {
 			span.Add(ssf.Count("forward.error_total", 1, map[string]string{"cause": "send"}))
 			entry.WithError(err).Error("Failed to forward to an upstream Veneur")
 		}
+		s.spoolForward(unsent, timestamp)
 	} else {
 		entry.Info("Completed forward to an upstream Veneur")
 	}
@@ -2,9 +2,13 @@ package veneur
 
 import (
 	"context"
+	"net"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stripe/veneur/forwardrpc"
 	"github.com/stripe/veneur/samplers"
 	"github.com/stripe/veneur/ssf"
 	"github.com/stripe/veneur/trace"
@@ -13,6 +17,9 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stripe/veneur/internal/forwardtest"
 	"github.com/stripe/veneur/samplers/metricpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestServerFlushGRPC(t *testing.T) {
@@ -109,6 +116,53 @@ func TestServerFlushGRPCTimeout(t *testing.T) {
 	}
 }
 
+// rejectingForwardServer rejects the first forwards it gets as if it was an
+// overloaded proxy.
+type rejectingForwardServer struct {
+	rejections int32
+	received   chan []*metricpb.Metric
+}
+
+func (rs *rejectingForwardServer) SendMetrics(ctx context.Context, mlist *forwardrpc.MetricList) (*empty.Empty, error) {
+	if atomic.AddInt32(&rs.rejections, -1) >= 0 {
+		return nil, status.Error(codes.ResourceExhausted, "overloaded")
+	}
+	rs.received <- mlist.Metrics
+	return &empty.Empty{}, nil
+}
+
+func (rs *rejectingForwardServer) SendMetricsStream(stream forwardrpc.Forward_SendMetricsStreamServer) error {
+	return status.Error(codes.Unimplemented, "not implemented")
+}
+
+func TestServerFlushGRPCRetriesRejections(t *testing.T) {
+	rs := &rejectingForwardServer{rejections: 2, received: make(chan []*metricpb.Metric, 10)}
+	srv := grpc.NewServer()
+	forwardrpc.RegisterForwardServer(srv, rs)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go srv.Serve(ln)
+	defer srv.Stop()
+
+	localCfg := localConfig()
+	localCfg.Interval = "1s"
+	localCfg.ForwardAddress = ln.Addr().String()
+	localCfg.ForwardUseGrpc = true
+	local := setupVeneurServer(t, localCfg, nil, nil, nil, nil)
+	defer local.Shutdown()
+
+	local.Workers[0].ProcessMetric(forwardGRPCTestMetrics()[0])
+
+	select {
+	case ms := <-rs.received:
+		assert.Len(t, ms, 1)
+		assert.True(t, atomic.LoadInt32(&rs.rejections) < 0,
+			"the metrics should have been forwarded again after being rejected twice")
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the metrics to be forwarded again")
+	}
+}
+
 // Just test that a flushing to a bad address is handled without panicing
 func TestServerFlushGRPCBadAddress(t *testing.T) {
 	rcv := make(chan []samplers.InterMetric, 10)
@@ -179,6 +233,133 @@ func TestGlobalAcceptsHistogramsOverUDP(t *testing.T) {
 	}
 }
 
+// sketchChannelSink is a channelMetricSink that accepts sketches.
+type sketchChannelSink struct {
+	*channelMetricSink
+}
+
+func (d sketchChannelSink) Name() string {
+	return "sketch_channel"
+}
+
+func (d sketchChannelSink) AcceptsSketches() bool {
+	return true
+}
+
+func TestGlobalFlushesSketches(t *testing.T) {
+	rcv := make(chan []samplers.InterMetric, 10)
+	sink, err := NewChannelMetricSink(rcv)
+	require.NoError(t, err)
+	sketchRcv := make(chan []samplers.InterMetric, 10)
+	sketchSink, err := NewChannelMetricSink(sketchRcv)
+	require.NoError(t, err)
+
+	cfg := globalConfig()
+	cfg.Percentiles = []float64{0.5, 0.99}
+	cfg.Aggregates = []string{"count"}
+	global := setupVeneurServer(t, cfg, nil, sink, nil, nil)
+	defer global.Shutdown()
+	global.metricSinks = append(global.metricSinks, sketchChannelSink{sketchSink})
+
+	for _, typ := range []string{distributionTypeName, histogramTypeName} {
+		for _, v := range []float64{1, 2, 3} {
+			m := samplers.UDPMetric{
+				MetricKey: samplers.MetricKey{
+					Name: typ,
+					Type: typ,
+				},
+				Value:      v,
+				Digest:     12345,
+				SampleRate: 1.0,
+				Scope:      samplers.GlobalOnly,
+			}
+			global.Workers[0].ProcessMetric(&m)
+		}
+	}
+	global.Flush(context.Background())
+
+	names := func(results []samplers.InterMetric) []string {
+		ret := []string{}
+		for _, m := range results {
+			ret = append(ret, m.Name)
+		}
+		return ret
+	}
+
+	select {
+	case results := <-rcv:
+		assert.ElementsMatch(t, []string{
+			"distribution.50percentile", "distribution.99percentile", "distribution.count",
+			"histogram.50percentile", "histogram.99percentile", "histogram.count",
+		}, names(results))
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for global veneur flush")
+	}
+
+	select {
+	case results := <-sketchRcv:
+		assert.ElementsMatch(t, []string{
+			"distribution", "distribution.count",
+			"histogram", "histogram.count",
+		}, names(results))
+		for _, m := range results {
+			if m.Type != samplers.SketchMetric {
+				continue
+			}
+			assert.Equal(t, float64(3), m.Value)
+			require.NotNil(t, m.Sketch)
+			assert.Len(t, m.Sketch.TDigest.MainCentroids, 3)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for global veneur flush")
+	}
+}
+
+func TestFlushHistogramRules(t *testing.T) {
+	rcv := make(chan []samplers.InterMetric, 10)
+	sink, err := NewChannelMetricSink(rcv)
+	require.NoError(t, err)
+
+	cfg := globalConfig()
+	cfg.Percentiles = []float64{0.5}
+	cfg.Aggregates = []string{"count"}
+	global := setupVeneurServer(t, cfg, nil, sink, nil, nil)
+	defer global.Shutdown()
+
+	tail, err := samplers.NewHistogramRule("api.*.latency", "", []string{"service:checkout"}, []float64{0.999}, nil)
+	require.NoError(t, err)
+	quiet, err := samplers.NewHistogramRule("", "^db\\.", nil, []float64{}, []string{"max"})
+	require.NoError(t, err)
+	global.HistogramRules = samplers.HistogramRules{tail, quiet}
+
+	for _, m := range []samplers.UDPMetric{
+		{MetricKey: samplers.MetricKey{Name: "api.charge.latency", Type: histogramTypeName, JoinedTags: "service:checkout"}, Tags: []string{"service:checkout"}},
+		{MetricKey: samplers.MetricKey{Name: "api.refund.latency", Type: histogramTypeName}},
+		{MetricKey: samplers.MetricKey{Name: "db.reads", Type: "timer"}},
+	} {
+		m.Value = 1.0
+		m.SampleRate = 1.0
+		m.Scope = samplers.GlobalOnly
+		global.Workers[0].ProcessMetric(&m)
+	}
+	global.Flush(context.Background())
+
+	select {
+	case results := <-rcv:
+		names := []string{}
+		for _, m := range results {
+			names = append(names, m.Name)
+		}
+		assert.ElementsMatch(t, []string{
+			"api.charge.latency.99_9percentile", "api.charge.latency.count",
+			"api.refund.latency.50percentile", "api.refund.latency.count",
+			"db.reads.max",
+		}, names)
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for global veneur flush")
+	}
+}
+
 func TestFlushResetsWorkerUniqueMTS(t *testing.T) {
 	config := localConfig()
 	config.CountUniqueTimeseries = true
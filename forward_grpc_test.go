@@ -2,14 +2,22 @@ package veneur
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"net"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/veneur/forwardrpc"
 	"github.com/stripe/veneur/samplers"
+	"github.com/stripe/veneur/samplers/metricpb"
 	"github.com/stripe/veneur/sinks"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 )
 
 const (
@@ -26,8 +34,9 @@ type forwardGRPCFixture struct {
 // newForwardGRPCFixture creates a set of resources that forward to each other
 // over gRPC.  Specifically this includes a local Server, which forwards
 // metrics over gRPC to a Proxy, which then forwards over gRPC again to a
-// global Server.
-func newForwardGRPCFixture(t testing.TB, localConfig Config, sink sinks.MetricSink) *forwardGRPCFixture {
+// global Server. The proxy's configuration can be changed with
+// configureProxy.
+func newForwardGRPCFixture(t testing.TB, localConfig Config, sink sinks.MetricSink, configureProxy ...func(*ProxyConfig)) *forwardGRPCFixture {
 	// Create a global Veneur
 	globalCfg := globalConfig()
 	globalCfg.GrpcAddress = unusedLocalTCPAddress(t)
@@ -42,6 +51,9 @@ func newForwardGRPCFixture(t testing.TB, localConfig Config, sink sinks.MetricSi
 	proxyCfg.GrpcForwardAddress = globalCfg.GrpcAddress
 	proxyCfg.GrpcAddress = unusedLocalTCPAddress(t)
 	proxyCfg.ConsulForwardServiceName = ""
+	for _, configure := range configureProxy {
+		configure(&proxyCfg)
+	}
 	proxy, err := NewProxyFromConfig(logrus.New(), proxyCfg)
 	assert.NoError(t, err)
 	go func() {
@@ -186,6 +198,28 @@ func TestE2EForwardingGRPCMetrics(t *testing.T) {
 	ff := newForwardGRPCFixture(t, localConfig(), sink)
 	defer ff.stop()
 
+	testE2EForwardingGRPCMetrics(t, ff, ch)
+}
+
+// TestE2EForwardingGRPCMetricsStreamed forwards the same metrics in small,
+// compressed batches on both hops.
+func TestE2EForwardingGRPCMetricsStreamed(t *testing.T) {
+	ch := make(chan []samplers.InterMetric)
+	sink, _ := NewChannelMetricSink(ch)
+
+	cfg := localConfig()
+	cfg.ForwardGrpcBatchSize = 2
+	cfg.ForwardGrpcCompression = "gzip"
+	ff := newForwardGRPCFixture(t, cfg, sink, func(proxyCfg *ProxyConfig) {
+		proxyCfg.ForwardGrpcBatchSize = 3
+		proxyCfg.ForwardGrpcCompression = "snappy"
+	})
+	defer ff.stop()
+
+	testE2EForwardingGRPCMetrics(t, ff, ch)
+}
+
+func testE2EForwardingGRPCMetrics(t *testing.T, ff *forwardGRPCFixture, ch chan []samplers.InterMetric) {
 	input := forwardGRPCTestMetrics()
 	for _, metric := range input {
 		ff.IngestMetric(metric)
@@ -254,3 +288,121 @@ func TestE2EForwardingGRPCMetrics(t *testing.T) {
 		t.Fatal("Timed out waiting for a metric after 3 seconds")
 	}
 }
+
+// TestE2ERegionalForwarding forwards metrics from two local Veneurs to a
+// regional Veneur, which merges them and forwards them on to a global
+// Veneur, over gRPC.
+func TestE2ERegionalForwarding(t *testing.T) {
+	globalCh := make(chan []samplers.InterMetric, 100)
+	globalSink, _ := NewChannelMetricSink(globalCh)
+	globalCfg := globalConfig()
+	globalCfg.GrpcAddress = unusedLocalTCPAddress(t)
+	global := setupVeneurServer(t, globalCfg, nil, globalSink, nil, nil)
+	defer global.Shutdown()
+	go global.Serve()
+	waitForHTTPStart(t, global, 3*time.Second)
+
+	regionalCh := make(chan []samplers.InterMetric, 100)
+	regionalSink, _ := NewChannelMetricSink(regionalCh)
+	regionalCfg := localConfig()
+	regionalCfg.Aggregates = append(regionalCfg.Aggregates, "median")
+	regionalCfg.ForwardAddress = globalCfg.GrpcAddress
+	regionalCfg.ForwardUseGrpc = true
+	regionalCfg.GrpcAddress = unusedLocalTCPAddress(t)
+	regional := setupVeneurServer(t, regionalCfg, nil, regionalSink, nil, nil)
+	defer regional.Shutdown()
+	go regional.Serve()
+	waitForHTTPStart(t, regional, 3*time.Second)
+	waitForGRPCForward(t, regional)
+
+	for i, value := range []float64{10, 30} {
+		localCfg := localConfig()
+		localCfg.ForwardAddress = regionalCfg.GrpcAddress
+		localCfg.ForwardUseGrpc = true
+		local := setupVeneurServer(t, localCfg, nil, nil, nil, nil)
+		defer local.Shutdown()
+		waitForGRPCForward(t, local)
+
+		local.Workers[0].ProcessMetric(&samplers.UDPMetric{
+			MetricKey:  samplers.MetricKey{Name: testGRPCMetric("histogram"), Type: histogramTypeName},
+			Value:      value,
+			SampleRate: 1.0,
+			Scope:      samplers.MixedScope,
+		})
+		local.Workers[0].ProcessMetric(&samplers.UDPMetric{
+			MetricKey:  samplers.MetricKey{Name: testGRPCMetric("set"), Type: setTypeName},
+			Value:      fmt.Sprintf("member-%d", i),
+			SampleRate: 1.0,
+			Scope:      samplers.MixedScope,
+		})
+		local.Workers[0].ProcessMetric(&samplers.UDPMetric{
+			MetricKey:  samplers.MetricKey{Name: testGRPCMetric("counter"), Type: counterTypeName},
+			Value:      2.0,
+			SampleRate: 1.0,
+			Scope:      samplers.GlobalOnly,
+		})
+	}
+
+	// metrics replayed from a local's forward spool are forwarded on with
+	// their timestamp
+	conn, err := grpc.Dial(regionalCfg.GrpcAddress, grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = forwardrpc.NewForwardClient(conn).SendMetrics(context.Background(), &forwardrpc.MetricList{
+		Metrics: []*metricpb.Metric{{
+			Name:  testGRPCMetric("backfill"),
+			Type:  metricpb.Type_Counter,
+			Value: &metricpb.Metric_Counter{Counter: &metricpb.CounterValue{Value: 1}},
+		}},
+		Timestamp: 12345,
+	})
+	require.NoError(t, err)
+
+	var (
+		histogram99 float64
+		setSize     float64
+		counter     float64
+		backfill    bool
+	)
+	timeout := time.After(5 * time.Second)
+	for histogram99 < 29 || setSize != 2 || counter != 4 || !backfill {
+		select {
+		case metrics := <-globalCh:
+			for _, m := range metrics {
+				switch m.Name {
+				case testGRPCMetric("histogram.99percentile"):
+					histogram99 = math.Max(histogram99, m.Value)
+				case testGRPCMetric("set"):
+					setSize += m.Value
+				case testGRPCMetric("counter"):
+					counter += m.Value
+				case testGRPCMetric("backfill"):
+					backfill = true
+					assert.Equal(t, int64(12345), m.Timestamp)
+				}
+			}
+		case metrics := <-regionalCh:
+			for _, m := range metrics {
+				assert.False(t, strings.HasPrefix(m.Name, grpcTestMetricPrefix),
+					"the regional veneur flushed %s, which it only imported", m.Name)
+			}
+		case <-timeout:
+			t.Fatalf("Timed out waiting for the global veneur to flush the merged metrics: "+
+				"histogram 99th percentile %v, set size %v, counter %v, backfill %t",
+				histogram99, setSize, counter, backfill)
+		}
+	}
+}
+
+// waitForGRPCForward waits for the connection that a Server forwards over to
+// be ready, so that the first forward doesn't time out while connecting.
+func waitForGRPCForward(t testing.TB, s *Server) {
+	timeout := time.After(3 * time.Second)
+	for s.grpcForwardConn.GetState() != connectivity.Ready {
+		select {
+		case <-time.After(10 * time.Millisecond):
+		case <-timeout:
+			t.Fatalf("The gRPC connection to %s wasn't ready within 3 seconds", s.ForwardAddr)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package veneur
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stripe/veneur/forwardrpc"
+	vhttp "github.com/stripe/veneur/http"
+	"github.com/stripe/veneur/samplers/metricpb"
+	"github.com/stripe/veneur/ssf"
+	"github.com/stripe/veneur/trace"
+)
+
+// maxSpoolBackoff is the longest that the forward spool waits between
+// replays while the global veneur is unreachable.
+const maxSpoolBackoff = 2 * time.Minute
+
+// spoolForward persists metrics that could not be forwarded, along with the
+// timestamp of the interval they were aggregated in, so that they are
+// replayed later.
+func (s *Server) spoolForward(metrics []*metricpb.Metric, timestamp int64) {
+	if s.forwardSpool == nil || len(metrics) == 0 {
+		return
+	}
+	entry := log.WithFields(logrus.Fields{
+		"metrics":   len(metrics),
+		"timestamp": timestamp,
+	})
+	if err := s.forwardSpool.Write(&forwardrpc.MetricList{Metrics: metrics, Timestamp: timestamp}); err != nil {
+		entry.WithError(err).Error("Could not spool metrics that failed to forward")
+		return
+	}
+	entry.Info("Spooled metrics that failed to forward")
+}
+
+// replayForwardSpool replays the metrics in the forward spool to the global
+// veneur until the server shuts down.
+func (s *Server) replayForwardSpool() {
+	defer func() {
+		ConsumePanic(s.Sentry, s.TraceClient, s.Hostname, recover())
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-s.shutdown
+		cancel()
+	}()
+	s.forwardSpool.Run(ctx, s.forwardSpooled, s.interval, maxSpoolBackoff)
+}
+
+// forwardSpooled forwards metrics replayed from the forward spool to the
+// global veneur, which flushes them with the timestamp of the interval they
+// were aggregated in.
+//
+// If only some of them were delivered, the rest are spooled again, and the
+// list counts as delivered so that none of it is sent twice.
+func (s *Server) forwardSpooled(ctx context.Context, list *forwardrpc.MetricList) error {
+	ctx, cancel := context.WithTimeout(ctx, s.interval)
+	defer cancel()
+
+	if s.forwardUseGRPC {
+		err := forwardrpc.Send(ctx, forwardrpc.NewForwardClient(s.grpcForwardConn),
+			list.Metrics, list.Timestamp, s.grpcBatchSize)
+		if perr, ok := err.(*forwardrpc.PartialError); ok {
+			log.WithError(perr.Err).WithField("metrics", len(perr.Unsent)).
+				Warn("Could only forward some of the replayed metrics")
+			s.spoolForward(perr.Unsent, list.Timestamp)
+			return nil
+		}
+		return err
+	}
+
+	// re-aggregate the metrics to export them the way flushForward does
+	w := NewWorker(0, true, false, s.TraceClient, log, nil)
+	w.SetSketchSelector(s.sketches)
+	for _, m := range list.Metrics {
+		w.ImportMetricGRPC(m)
+	}
+	jsonMetrics := exportJSONMetrics([]WorkerMetrics{w.Flush()})
+	endpoint := fmt.Sprintf("%s/import?timestamp=%d", s.ForwardAddr, list.Timestamp)
+	return vhttp.PostHelper(ctx, s.HTTPClient, s.TraceClient, http.MethodPost, endpoint, jsonMetrics, "forward_spool", true, nil, log)
+}
+
+// backfillIngester receives the metrics that local veneurs replay from
+// their forward spools over gRPC.
+type backfillIngester struct {
+	s *Server
+}
+
+func (bi backfillIngester) IngestBackfill(timestamp int64, ms []*metricpb.Metric) {
+	bi.s.backfill(timestamp, func(w *Worker) {
+		for _, m := range ms {
+			w.ImportMetricGRPC(m)
+		}
+	})
+}
+
+// backfill aggregates metrics that a local veneur replayed from its forward
+// spool apart from the current interval's. Every local veneur replays its
+// own part of an interval, so the replays for each timestamp are merged in
+// one Worker for an interval after the first of them arrives, and then
+// flushed together; flushing each replay on its own would write partial
+// points for the same series and timestamp.
+func (s *Server) backfill(timestamp int64, importAll func(w *Worker)) {
+	s.backfillMtx.Lock()
+	defer s.backfillMtx.Unlock()
+
+	w, ok := s.backfills[timestamp]
+	if !ok {
+		w = NewWorker(0, s.IsLocal(), false, s.TraceClient, log, nil)
+		w.SetSketchSelector(s.sketches)
+		if s.backfills == nil {
+			s.backfills = map[int64]*Worker{}
+		}
+		s.backfills[timestamp] = w
+		time.AfterFunc(s.interval, func() {
+			s.flushBackfill(context.Background(), timestamp)
+		})
+	}
+	importAll(w)
+}
+
+// flushBackfill flushes the metrics replayed for the interval at timestamp
+// to the metric sinks, with that timestamp.
+//
+// A veneur that forwards itself (a regional one) forwards them on, along
+// with their timestamp, instead.
+func (s *Server) flushBackfill(ctx context.Context, timestamp int64) {
+	defer func() {
+		ConsumePanic(s.Sentry, s.TraceClient, s.Hostname, recover())
+	}()
+	span, _ := trace.StartSpanFromContext(ctx, "")
+	defer span.ClientFinish(s.TraceClient)
+
+	s.backfillMtx.Lock()
+	w := s.backfills[timestamp]
+	delete(s.backfills, timestamp)
+	s.backfillMtx.Unlock()
+	if w == nil {
+		return
+	}
+
+	if s.IsLocal() {
+		s.forwardBackfill(span.Attach(ctx), timestamp, w.Flush())
+		return
+	}
+
+	finalMetrics, sketchMetrics := s.sinkMetrics(span.Attach(ctx), []WorkerMetrics{w.Flush()}, metricsSummary{})
+	for i := range finalMetrics {
+		finalMetrics[i].Timestamp = timestamp
+	}
+	for i := range sketchMetrics {
+		sketchMetrics[i].Timestamp = timestamp
+	}
+	span.Add(ssf.Count("flush.backfill_metrics_total", float32(len(finalMetrics)), nil))
+	if len(finalMetrics) == 0 {
+		return
+	}
+	s.flushSinks(span.Attach(ctx), finalMetrics, sketchMetrics)
+}
+
+// forwardBackfill forwards metrics that were replayed to a regional veneur
+// on to the global veneur, with the timestamp of the interval they were
+// aggregated in. If that fails, they're spooled to be replayed again.
+func (s *Server) forwardBackfill(ctx context.Context, timestamp int64, wm WorkerMetrics) {
+	span, ctx := trace.StartSpanFromContext(ctx, "")
+	defer span.ClientFinish(s.TraceClient)
+
+	metrics := wm.ForwardableMetrics(s.TraceClient)
+	if len(metrics) == 0 {
+		return
+	}
+	entry := log.WithFields(logrus.Fields{
+		"metrics":   len(metrics),
+		"timestamp": timestamp,
+	})
+	err := s.forwardSpooled(ctx, &forwardrpc.MetricList{Metrics: metrics, Timestamp: timestamp})
+	if err != nil {
+		span.Add(ssf.Count("forward.backfill_metrics_total", float32(len(metrics)),
+			map[string]string{"status": "failure"}))
+		entry.WithError(err).Warn("Could not forward replayed metrics")
+		s.spoolForward(metrics, timestamp)
+		return
+	}
+	span.Add(ssf.Count("forward.backfill_metrics_total", float32(len(metrics)),
+		map[string]string{"status": "success"}))
+	entry.Debug("Forwarded replayed metrics")
+}
@@ -0,0 +1,114 @@
+package veneur
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/veneur/samplers"
+)
+
+func TestForwardSpoolReplaysToGlobal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test_forward_spool")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	globalAddr := unusedLocalTCPAddress(t)
+	localCfg := localConfig()
+	localCfg.Interval = "50ms"
+	localCfg.ForwardAddress = globalAddr
+	localCfg.ForwardUseGrpc = true
+	localCfg.ForwardSpoolDirectory = dir
+	local := setupVeneurServer(t, localCfg, nil, nil, nil, nil)
+	defer local.Shutdown()
+
+	// the global veneur isn't up yet, so this forward fails:
+	w := NewWorker(1, true, false, nil, logrus.New(), nil)
+	for _, m := range forwardGRPCTestMetrics() {
+		w.ProcessMetric(m)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	local.forwardGRPC(ctx, []WorkerMetrics{w.Flush()})
+	cancel()
+
+	spooled, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, spooled, 1, "the failed forward should be spooled")
+	timestamp, err := strconv.ParseInt(spooled[0].Name()[:20], 10, 64)
+	require.NoError(t, err)
+
+	rcv := make(chan []samplers.InterMetric, 10)
+	sink, err := NewChannelMetricSink(rcv)
+	require.NoError(t, err)
+	globalCfg := globalConfig()
+	globalCfg.GrpcAddress = globalAddr
+	global := setupVeneurServer(t, globalCfg, nil, sink, nil, nil)
+	defer global.Shutdown()
+	go global.Serve()
+
+	select {
+	case ms := <-rcv:
+		names := map[string]bool{}
+		for _, m := range ms {
+			names[m.Name] = true
+			assert.Equal(t, timestamp, m.Timestamp,
+				"%s should be flushed with the timestamp of the interval it was aggregated in", m.Name)
+		}
+		assert.True(t, names[testGRPCMetric("histogram")+".50percentile"])
+		assert.True(t, names[testGRPCMetric("counter")])
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the spooled metrics to be replayed")
+	}
+
+	// the replayed batch is removed once it's delivered:
+	for i := 0; i < 100; i++ {
+		if spooled, err = ioutil.ReadDir(dir); err == nil && len(spooled) == 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Empty(t, spooled)
+}
+
+func TestServerImportBackfill(t *testing.T) {
+	rcv := make(chan []samplers.InterMetric, 10)
+	sink, err := NewChannelMetricSink(rcv)
+	require.NoError(t, err)
+	s := setupVeneurServer(t, globalConfig(), nil, sink, nil, nil)
+	defer s.Shutdown()
+
+	counter := samplers.NewCounter("a.b.c", []string{"foo:bar"})
+	counter.Sample(5, 1.0)
+	jm, err := counter.Export()
+	require.NoError(t, err)
+	body, err := json.Marshal([]samplers.JSONMetric{jm})
+	require.NoError(t, err)
+
+	// two local veneurs replay their part of the same interval:
+	for i := 0; i < 2; i++ {
+		r := httptest.NewRequest(http.MethodPost, "/import?timestamp=1500000000", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		handleImport(s).ServeHTTP(w, r)
+		assert.Equal(t, http.StatusAccepted, w.Code)
+	}
+
+	select {
+	case ms := <-rcv:
+		require.Len(t, ms, 1)
+		assert.Equal(t, "a.b.c", ms[0].Name)
+		assert.Equal(t, float64(10), ms[0].Value, "replays of the same interval should be flushed together")
+		assert.Equal(t, int64(1500000000), ms[0].Timestamp)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the backfilled metrics to be flushed")
+	}
+}
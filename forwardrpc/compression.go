@@ -0,0 +1,73 @@
+package forwardrpc
+
+import (
+	"compress/gzip"
+	"io"
+	"sync"
+
+	"github.com/golang/snappy"
+	"google.golang.org/grpc/encoding"
+)
+
+// The compressors that forwarded metrics can be compressed with, to pass to
+// grpc.UseCompressor. Importing this package registers both of them with
+// gRPC, so servers accept and reply with whichever one a client picks.
+const (
+	CompressionGzip   = "gzip"
+	CompressionSnappy = "snappy"
+)
+
+func init() {
+	encoding.RegisterCompressor(&gzipCompressor{})
+	encoding.RegisterCompressor(snappyCompressor{})
+}
+
+// gzipCompressor reuses its writers, since each one allocates a large
+// buffer.
+type gzipCompressor struct {
+	writers sync.Pool
+}
+
+type gzipWriter struct {
+	*gzip.Writer
+	pool *sync.Pool
+}
+
+func (c *gzipCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	if gw, ok := c.writers.Get().(*gzipWriter); ok {
+		gw.Reset(w)
+		return gw, nil
+	}
+	return &gzipWriter{Writer: gzip.NewWriter(w), pool: &c.writers}, nil
+}
+
+func (w *gzipWriter) Close() error {
+	defer w.pool.Put(w)
+	return w.Writer.Close()
+}
+
+func (c *gzipCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return gr, nil
+}
+
+func (c *gzipCompressor) Name() string {
+	return CompressionGzip
+}
+
+type snappyCompressor struct{}
+
+func (snappyCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	return snappy.NewBufferedWriter(w), nil
+}
+
+func (snappyCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	return snappy.NewReader(r), nil
+}
+
+func (snappyCompressor) Name() string {
+	return CompressionSnappy
+}
@@ -5,6 +5,7 @@ package forwardrpc
 
 import (
 	context "context"
+	encoding_binary "encoding/binary"
 	fmt "fmt"
 	proto "github.com/gogo/protobuf/proto"
 	empty "github.com/golang/protobuf/ptypes/empty"
@@ -28,6 +29,10 @@ const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 // MetricList just wraps a list of metricpb.Metric's.
 type MetricList struct {
 	Metrics []*metricpb.Metric `protobuf:"bytes,1,rep,name=metrics,proto3" json:"metrics,omitempty"`
+	// timestamp is the Unix time of the interval that the metrics were
+	// aggregated in. It is only set on lists replayed from a forward spool,
+	// which were aggregated in an earlier interval than the current one.
+	Timestamp int64 `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 }
 
 func (m *MetricList) Reset()         { *m = MetricList{} }
@@ -70,27 +75,129 @@ func (m *MetricList) GetMetrics() []*metricpb.Metric {
 	return nil
 }
 
+func (m *MetricList) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+// Ring describes the hash ring that a proxy forwards metrics over.
+type Ring struct {
+	// self is the member of the ring that the receiving Veneur is.
+	Self string `protobuf:"bytes,1,opt,name=self,proto3" json:"self,omitempty"`
+	// members are all of the members of the ring.
+	Members []string `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	// strategy is the hashring strategy that the proxy uses, and
+	// load_factor the load factor it uses it with.
+	Strategy   string  `protobuf:"bytes,3,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	LoadFactor float64 `protobuf:"fixed64,4,opt,name=load_factor,json=loadFactor,proto3" json:"load_factor,omitempty"`
+	// grace_period_ns is how long after the change the receiving Veneur
+	// should keep handing off the metrics that moved away from it.
+	GracePeriodNs int64 `protobuf:"varint,5,opt,name=grace_period_ns,json=gracePeriodNs,proto3" json:"grace_period_ns,omitempty"`
+}
+
+func (m *Ring) Reset()         { *m = Ring{} }
+func (m *Ring) String() string { return proto.CompactTextString(m) }
+func (*Ring) ProtoMessage()    {}
+func (*Ring) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0f9bdf2b06f7b9ea, []int{1}
+}
+func (m *Ring) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Ring) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Ring.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Ring) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Ring.Merge(m, src)
+}
+func (m *Ring) XXX_Size() int {
+	return m.Size()
+}
+func (m *Ring) XXX_DiscardUnknown() {
+	xxx_messageInfo_Ring.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Ring proto.InternalMessageInfo
+
+func (m *Ring) GetSelf() string {
+	if m != nil {
+		return m.Self
+	}
+	return ""
+}
+
+func (m *Ring) GetMembers() []string {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
+func (m *Ring) GetStrategy() string {
+	if m != nil {
+		return m.Strategy
+	}
+	return ""
+}
+
+func (m *Ring) GetLoadFactor() float64 {
+	if m != nil {
+		return m.LoadFactor
+	}
+	return 0
+}
+
+func (m *Ring) GetGracePeriodNs() int64 {
+	if m != nil {
+		return m.GracePeriodNs
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*MetricList)(nil), "forwardrpc.MetricList")
+	proto.RegisterType((*Ring)(nil), "forwardrpc.Ring")
 }
 
 func init() { proto.RegisterFile("forwardrpc/forward.proto", fileDescriptor_0f9bdf2b06f7b9ea) }
 
 var fileDescriptor_0f9bdf2b06f7b9ea = []byte{
-	// 202 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x48, 0xcb, 0x2f, 0x2a,
-	0x4f, 0x2c, 0x4a, 0x29, 0x2a, 0x48, 0xd6, 0x87, 0x32, 0xf5, 0x0a, 0x8a, 0xf2, 0x4b, 0xf2, 0x85,
-	0xb8, 0x10, 0x32, 0x52, 0x72, 0xc5, 0x89, 0xb9, 0x05, 0x39, 0xa9, 0x45, 0xc5, 0xfa, 0xb9, 0xa9,
-	0x25, 0x45, 0x99, 0xc9, 0x05, 0x49, 0x50, 0x06, 0x44, 0xad, 0x94, 0x74, 0x7a, 0x7e, 0x7e, 0x7a,
-	0x4e, 0xaa, 0x3e, 0x98, 0x97, 0x54, 0x9a, 0xa6, 0x9f, 0x9a, 0x5b, 0x50, 0x52, 0x09, 0x91, 0x54,
-	0xb2, 0xe0, 0xe2, 0xf2, 0x05, 0x2b, 0xf6, 0xc9, 0x2c, 0x2e, 0x11, 0xd2, 0xe2, 0x62, 0x87, 0x68,
-	0x2d, 0x96, 0x60, 0x54, 0x60, 0xd6, 0xe0, 0x36, 0x12, 0xd0, 0x83, 0x99, 0xa9, 0x07, 0x51, 0x16,
-	0x04, 0x53, 0x60, 0xe4, 0xc5, 0xc5, 0xee, 0x06, 0x71, 0x84, 0x90, 0x3d, 0x17, 0x77, 0x70, 0x6a,
-	0x5e, 0x0a, 0x44, 0x45, 0xb1, 0x90, 0x98, 0x1e, 0xc2, 0x75, 0x7a, 0x08, 0xd3, 0xa5, 0xc4, 0xf4,
-	0x20, 0x2e, 0xd1, 0x83, 0xb9, 0x44, 0xcf, 0x15, 0xe4, 0x12, 0x25, 0x06, 0x27, 0x89, 0x13, 0x8f,
-	0xe4, 0x18, 0x2f, 0x3c, 0x92, 0x63, 0x7c, 0xf0, 0x48, 0x8e, 0x71, 0xc2, 0x63, 0x39, 0x86, 0x0b,
-	0x8f, 0xe5, 0x18, 0x6e, 0x3c, 0x96, 0x63, 0x48, 0x62, 0x03, 0xab, 0x35, 0x06, 0x04, 0x00, 0x00,
-	0xff, 0xff, 0xa5, 0x18, 0xbe, 0xc7, 0x0b, 0x01, 0x00, 0x00,
+	// 370 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x90, 0xb1, 0x6b, 0xdb, 0x40,
+	0x14, 0xc6, 0x75, 0x96, 0x5b, 0xd5, 0xcf, 0x94, 0xba, 0x37, 0x98, 0x43, 0x2d, 0xaa, 0xf0, 0x50,
+	0x44, 0x87, 0x13, 0xb8, 0x74, 0x6e, 0x29, 0xd4, 0xed, 0x50, 0x97, 0x22, 0x43, 0x56, 0x73, 0x92,
+	0x4e, 0x42, 0x20, 0xe9, 0xc4, 0xdd, 0x85, 0xe0, 0xff, 0x22, 0x90, 0x29, 0xff, 0x51, 0x46, 0x8f,
+	0x19, 0x83, 0xfd, 0x8f, 0x04, 0x9d, 0xac, 0xc8, 0x4b, 0x86, 0x6c, 0xef, 0xfb, 0xde, 0xf7, 0x3e,
+	0xe9, 0x7e, 0x40, 0x32, 0x21, 0xaf, 0x98, 0x4c, 0x65, 0x93, 0x84, 0xa7, 0x91, 0x36, 0x52, 0x68,
+	0x81, 0x61, 0xd8, 0xb8, 0x9e, 0x62, 0x55, 0x53, 0x72, 0xa9, 0xc2, 0x8a, 0x6b, 0x59, 0x24, 0x4d,
+	0x7c, 0x1a, 0xba, 0xac, 0xfb, 0x21, 0x17, 0x22, 0x2f, 0x79, 0x68, 0x54, 0x7c, 0x99, 0x85, 0xbc,
+	0x6a, 0xf4, 0xae, 0x5b, 0x2e, 0x2e, 0x00, 0xd6, 0x26, 0xfc, 0xb7, 0x50, 0x1a, 0x7f, 0x01, 0xa7,
+	0x3b, 0x55, 0x04, 0xf9, 0x76, 0x30, 0x5d, 0xce, 0x68, 0xdf, 0x49, 0xbb, 0x58, 0xd4, 0x07, 0xf0,
+	0x47, 0x98, 0xe8, 0xa2, 0xe2, 0x4a, 0xb3, 0xaa, 0x21, 0x23, 0x1f, 0x05, 0x76, 0x34, 0x18, 0x8b,
+	0x5b, 0x04, 0xe3, 0xa8, 0xa8, 0x73, 0x8c, 0x61, 0xac, 0x78, 0x99, 0x11, 0xe4, 0xa3, 0x60, 0x12,
+	0x99, 0x19, 0x93, 0xf6, 0x33, 0x55, 0xcc, 0xa5, 0x22, 0x23, 0xdf, 0x0e, 0x26, 0x51, 0x2f, 0xb1,
+	0x0b, 0x6f, 0x94, 0x96, 0x4c, 0xf3, 0x7c, 0x47, 0x6c, 0x73, 0xf1, 0xa4, 0xf1, 0x27, 0x98, 0x96,
+	0x82, 0xa5, 0xdb, 0x8c, 0x25, 0x5a, 0x48, 0x32, 0xf6, 0x51, 0x80, 0x22, 0x68, 0xad, 0x95, 0x71,
+	0xf0, 0x67, 0x78, 0x97, 0x4b, 0x96, 0xf0, 0x6d, 0xc3, 0x65, 0x21, 0xd2, 0x6d, 0xad, 0xc8, 0x2b,
+	0xf3, 0x5f, 0x6f, 0x8d, 0xfd, 0xdf, 0xb8, 0xff, 0xd4, 0xf2, 0x06, 0x81, 0xb3, 0xea, 0xf8, 0xe1,
+	0xef, 0x30, 0xdd, 0xf0, 0x3a, 0x5d, 0x9f, 0x1e, 0x35, 0xa7, 0x03, 0x58, 0x3a, 0x80, 0x71, 0xe7,
+	0xb4, 0x83, 0x48, 0x7b, 0x88, 0xf4, 0x57, 0x0b, 0x71, 0x61, 0xe1, 0xdf, 0xf0, 0xfe, 0xac, 0x60,
+	0xa3, 0x25, 0x67, 0xd5, 0xcb, 0x6b, 0x02, 0xb4, 0xfc, 0x01, 0xce, 0x1f, 0x56, 0xa7, 0x22, 0xcb,
+	0xf0, 0x37, 0x70, 0x36, 0x5c, 0x1b, 0x7c, 0xb3, 0xf3, 0xa6, 0xd6, 0x79, 0xbe, 0xe3, 0x27, 0xb9,
+	0x3b, 0x78, 0x68, 0x7f, 0xf0, 0xd0, 0xc3, 0xc1, 0x43, 0xd7, 0x47, 0xcf, 0xda, 0x1f, 0x3d, 0xeb,
+	0xfe, 0xe8, 0x59, 0xf1, 0x6b, 0x93, 0xfd, 0xfa, 0x38, 0x00, 0xf3, 0x6c, 0x16, 0x94, 0x51, 0x02,
+	0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -107,6 +214,11 @@ const _ = grpc.SupportPackageIsVersion4
 type ForwardClient interface {
 	// SendMetrics sends a batch of metrics at once, and returns no response.
 	SendMetrics(ctx context.Context, in *MetricList, opts ...grpc.CallOption) (*empty.Empty, error)
+	// SendMetricsStream sends the metrics of an interval as a stream of
+	// batches, so that neither side has to hold all of them in a single
+	// message, and returns no response once the stream is closed. All the
+	// batches of a stream must have the same timestamp.
+	SendMetricsStream(ctx context.Context, opts ...grpc.CallOption) (Forward_SendMetricsStreamClient, error)
 }
 
 type forwardClient struct {
@@ -126,10 +238,49 @@ func (c *forwardClient) SendMetrics(ctx context.Context, in *MetricList, opts ..
 	return out, nil
 }
 
+func (c *forwardClient) SendMetricsStream(ctx context.Context, opts ...grpc.CallOption) (Forward_SendMetricsStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Forward_serviceDesc.Streams[0], "/forwardrpc.Forward/SendMetricsStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &forwardSendMetricsStreamClient{stream}
+	return x, nil
+}
+
+type Forward_SendMetricsStreamClient interface {
+	Send(*MetricList) error
+	CloseAndRecv() (*empty.Empty, error)
+	grpc.ClientStream
+}
+
+type forwardSendMetricsStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *forwardSendMetricsStreamClient) Send(m *MetricList) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *forwardSendMetricsStreamClient) CloseAndRecv() (*empty.Empty, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(empty.Empty)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ForwardServer is the server API for Forward service.
 type ForwardServer interface {
 	// SendMetrics sends a batch of metrics at once, and returns no response.
 	SendMetrics(context.Context, *MetricList) (*empty.Empty, error)
+	// SendMetricsStream sends the metrics of an interval as a stream of
+	// batches, so that neither side has to hold all of them in a single
+	// message, and returns no response once the stream is closed. All the
+	// batches of a stream must have the same timestamp.
+	SendMetricsStream(Forward_SendMetricsStreamServer) error
 }
 
 func RegisterForwardServer(s *grpc.Server, srv ForwardServer) {
@@ -154,6 +305,32 @@ func _Forward_SendMetrics_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Forward_SendMetricsStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ForwardServer).SendMetricsStream(&forwardSendMetricsStreamServer{stream})
+}
+
+type Forward_SendMetricsStreamServer interface {
+	SendAndClose(*empty.Empty) error
+	Recv() (*MetricList, error)
+	grpc.ServerStream
+}
+
+type forwardSendMetricsStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *forwardSendMetricsStreamServer) SendAndClose(m *empty.Empty) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *forwardSendMetricsStreamServer) Recv() (*MetricList, error) {
+	m := new(MetricList)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 var _Forward_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "forwardrpc.Forward",
 	HandlerType: (*ForwardServer)(nil),
@@ -163,6 +340,80 @@ var _Forward_serviceDesc = grpc.ServiceDesc{
 			Handler:    _Forward_SendMetrics_Handler,
 		},
 	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SendMetricsStream",
+			Handler:       _Forward_SendMetricsStream_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "forwardrpc/forward.proto",
+}
+
+// HandoffClient is the client API for Handoff service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type HandoffClient interface {
+	// SetRing announces the new members of the ring, and returns no
+	// response.
+	SetRing(ctx context.Context, in *Ring, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type handoffClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewHandoffClient(cc *grpc.ClientConn) HandoffClient {
+	return &handoffClient{cc}
+}
+
+func (c *handoffClient) SetRing(ctx context.Context, in *Ring, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/forwardrpc.Handoff/SetRing", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HandoffServer is the server API for Handoff service.
+type HandoffServer interface {
+	// SetRing announces the new members of the ring, and returns no
+	// response.
+	SetRing(context.Context, *Ring) (*empty.Empty, error)
+}
+
+func RegisterHandoffServer(s *grpc.Server, srv HandoffServer) {
+	s.RegisterService(&_Handoff_serviceDesc, srv)
+}
+
+func _Handoff_SetRing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Ring)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandoffServer).SetRing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/forwardrpc.Handoff/SetRing",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandoffServer).SetRing(ctx, req.(*Ring))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Handoff_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "forwardrpc.Handoff",
+	HandlerType: (*HandoffServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetRing",
+			Handler:    _Handoff_SetRing_Handler,
+		},
+	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "forwardrpc/forward.proto",
 }
@@ -194,6 +445,67 @@ func (m *MetricList) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if m.Timestamp != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintForward(dAtA, i, uint64(m.Timestamp))
+	}
+	return i, nil
+}
+
+func (m *Ring) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Ring) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Self) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintForward(dAtA, i, uint64(len(m.Self)))
+		i += copy(dAtA[i:], m.Self)
+	}
+	if len(m.Members) > 0 {
+		for _, s := range m.Members {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Strategy) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintForward(dAtA, i, uint64(len(m.Strategy)))
+		i += copy(dAtA[i:], m.Strategy)
+	}
+	if m.LoadFactor != 0 {
+		dAtA[i] = 0x21
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.LoadFactor))))
+		i += 8
+	}
+	if m.GracePeriodNs != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintForward(dAtA, i, uint64(m.GracePeriodNs))
+	}
 	return i, nil
 }
 
@@ -218,6 +530,38 @@ func (m *MetricList) Size() (n int) {
 			n += 1 + l + sovForward(uint64(l))
 		}
 	}
+	if m.Timestamp != 0 {
+		n += 1 + sovForward(uint64(m.Timestamp))
+	}
+	return n
+}
+
+func (m *Ring) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Self)
+	if l > 0 {
+		n += 1 + l + sovForward(uint64(l))
+	}
+	if len(m.Members) > 0 {
+		for _, s := range m.Members {
+			l = len(s)
+			n += 1 + l + sovForward(uint64(l))
+		}
+	}
+	l = len(m.Strategy)
+	if l > 0 {
+		n += 1 + l + sovForward(uint64(l))
+	}
+	if m.LoadFactor != 0 {
+		n += 9
+	}
+	if m.GracePeriodNs != 0 {
+		n += 1 + sovForward(uint64(m.GracePeriodNs))
+	}
 	return n
 }
 
@@ -297,6 +641,25 @@ func (m *MetricList) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowForward
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipForward(dAtA[iNdEx:])
@@ -321,6 +684,182 @@ func (m *MetricList) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *Ring) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowForward
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Ring: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Ring: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Self", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowForward
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthForward
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthForward
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Self = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowForward
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthForward
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthForward
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Members = append(m.Members, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Strategy", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowForward
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthForward
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthForward
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Strategy = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LoadFactor", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.LoadFactor = float64(math.Float64frombits(v))
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GracePeriodNs", wireType)
+			}
+			m.GracePeriodNs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowForward
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GracePeriodNs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipForward(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthForward
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipForward(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
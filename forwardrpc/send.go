@@ -0,0 +1,108 @@
+package forwardrpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/stripe/veneur/samplers/metricpb"
+)
+
+// PartialError is the error Send returns when some of the metrics were
+// delivered before it failed. Only the Unsent ones should be sent again.
+type PartialError struct {
+	Unsent []*metricpb.Metric
+	Err    error
+}
+
+func (e *PartialError) Error() string {
+	return fmt.Sprintf("%d metrics weren't sent: %v", len(e.Unsent), e.Err)
+}
+
+// GRPCStatus returns the status of the underlying error, so that
+// status.Code and status.FromError see through a PartialError.
+func (e *PartialError) GRPCStatus() *status.Status {
+	return status.Convert(e.Err)
+}
+
+// Unsent returns the metrics that still need to be sent after Send failed
+// to send ms with err: all of ms, unless err is a *PartialError.
+func Unsent(err error, ms []*metricpb.Metric) []*metricpb.Metric {
+	if err == nil {
+		return nil
+	}
+	if perr, ok := err.(*PartialError); ok {
+		return perr.Unsent
+	}
+	return ms
+}
+
+// Send forwards ms over client, with the timestamp of the interval they were
+// aggregated in if it's an earlier one.
+//
+// If batchSize is positive, the metrics are streamed with SendMetricsStream
+// in lists of at most batchSize metrics, so that no single message has to
+// hold all of them; veneur and veneur-proxy only ingest or forward a stream
+// once it's complete, so a stream that fails, whenever it does, can be sent
+// again as a whole. Servers that don't
+// implement SendMetricsStream yet get the same lists with one SendMetrics
+// call each instead, and if one of them fails, Send returns a *PartialError
+// with the metrics that weren't delivered. Otherwise, the metrics are sent
+// with a single SendMetrics call.
+func Send(ctx context.Context, client ForwardClient, ms []*metricpb.Metric, timestamp int64, batchSize int) error {
+	if batchSize <= 0 {
+		_, err := client.SendMetrics(ctx, &MetricList{Metrics: ms, Timestamp: timestamp})
+		return err
+	}
+
+	err := sendStream(ctx, client, ms, timestamp, batchSize)
+	if status.Code(err) != codes.Unimplemented {
+		return err
+	}
+	for sent, batch := range batches(ms, batchSize) {
+		_, err := client.SendMetrics(ctx, &MetricList{Metrics: batch, Timestamp: timestamp})
+		if err != nil {
+			if sent == 0 {
+				return err
+			}
+			return &PartialError{Unsent: ms[sent*batchSize:], Err: err}
+		}
+	}
+	return nil
+}
+
+func sendStream(ctx context.Context, client ForwardClient, ms []*metricpb.Metric, timestamp int64, batchSize int) error {
+	stream, err := client.SendMetricsStream(ctx)
+	if err != nil {
+		return err
+	}
+	for _, batch := range batches(ms, batchSize) {
+		err := stream.Send(&MetricList{Metrics: batch, Timestamp: timestamp})
+		if err == io.EOF {
+			// the server ended the stream early, and CloseAndRecv
+			// returns why
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err = stream.CloseAndRecv()
+	return err
+}
+
+// batches splits ms into lists of at most size metrics.
+func batches(ms []*metricpb.Metric, size int) [][]*metricpb.Metric {
+	res := make([][]*metricpb.Metric, 0, (len(ms)+size-1)/size)
+	for len(ms) > size {
+		res = append(res, ms[:size])
+		ms = ms[size:]
+	}
+	if len(ms) > 0 {
+		res = append(res, ms)
+	}
+	return res
+}
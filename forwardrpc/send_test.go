@@ -0,0 +1,157 @@
+package forwardrpc_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/stripe/veneur/forwardrpc"
+	"github.com/stripe/veneur/internal/forwardtest"
+	"github.com/stripe/veneur/samplers/metricpb"
+)
+
+func testMetrics(n int) []*metricpb.Metric {
+	ms := make([]*metricpb.Metric, n)
+	for i := range ms {
+		ms[i] = &metricpb.Metric{
+			Name:  fmt.Sprintf("test.counter.%d", i),
+			Type:  metricpb.Type_Counter,
+			Value: &metricpb.Metric_Counter{Counter: &metricpb.CounterValue{Value: 1}},
+		}
+	}
+	return ms
+}
+
+// recorder records the sizes of the lists of metrics a server receives
+type recorder struct {
+	mtx   sync.Mutex
+	sizes []int
+}
+
+func (r *recorder) handle(ms []*metricpb.Metric) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.sizes = append(r.sizes, len(ms))
+}
+
+func dial(t *testing.T, addr string, opts ...grpc.DialOption) *grpc.ClientConn {
+	conn, err := grpc.Dial(addr, append(opts, grpc.WithInsecure())...)
+	require.NoError(t, err)
+	return conn
+}
+
+func TestSend(t *testing.T) {
+	r := &recorder{}
+	srv := forwardtest.NewServer(r.handle)
+	srv.Start(t)
+	defer srv.Stop()
+	conn := dial(t, srv.Addr().String())
+	defer conn.Close()
+	client := forwardrpc.NewForwardClient(conn)
+
+	require.NoError(t, forwardrpc.Send(context.Background(), client, testMetrics(5), 0, 0))
+	assert.Equal(t, []int{5}, r.sizes, "without a batch size, the metrics are sent at once")
+
+	r.sizes = nil
+	require.NoError(t, forwardrpc.Send(context.Background(), client, testMetrics(5), 0, 2))
+	assert.Equal(t, []int{2, 2, 1}, r.sizes)
+}
+
+func TestSendCompressed(t *testing.T) {
+	for _, compressor := range []string{forwardrpc.CompressionGzip, forwardrpc.CompressionSnappy} {
+		t.Run(compressor, func(t *testing.T) {
+			r := &recorder{}
+			srv := forwardtest.NewServer(r.handle)
+			srv.Start(t)
+			defer srv.Stop()
+			conn := dial(t, srv.Addr().String(),
+				grpc.WithDefaultCallOptions(grpc.UseCompressor(compressor)))
+			defer conn.Close()
+			client := forwardrpc.NewForwardClient(conn)
+
+			require.NoError(t, forwardrpc.Send(context.Background(), client, testMetrics(1000), 0, 300))
+			assert.Equal(t, []int{300, 300, 300, 100}, r.sizes)
+		})
+	}
+}
+
+// unaryServer implements SendMetrics only, like the servers that predate
+// SendMetricsStream. If failAfter is positive, it fails the calls after
+// that many.
+type unaryServer struct {
+	lists     []*forwardrpc.MetricList
+	failAfter int
+}
+
+func (s *unaryServer) SendMetrics(ctx context.Context, mlist *forwardrpc.MetricList) (*empty.Empty, error) {
+	if s.failAfter > 0 && len(s.lists) >= s.failAfter {
+		return nil, status.Error(codes.Unavailable, "shutting down")
+	}
+	s.lists = append(s.lists, mlist)
+	return &empty.Empty{}, nil
+}
+
+func (s *unaryServer) SendMetricsStream(stream forwardrpc.Forward_SendMetricsStreamServer) error {
+	return status.Error(codes.Unimplemented, "unknown method SendMetricsStream")
+}
+
+func TestSendFallsBackToUnary(t *testing.T) {
+	unary := &unaryServer{}
+	srv := grpc.NewServer()
+	forwardrpc.RegisterForwardServer(srv, unary)
+	ln, err := net.Listen("tcp", "127.0.0.1:")
+	require.NoError(t, err)
+	go srv.Serve(ln)
+	defer srv.Stop()
+	conn := dial(t, ln.Addr().String())
+	defer conn.Close()
+	client := forwardrpc.NewForwardClient(conn)
+
+	require.NoError(t, forwardrpc.Send(context.Background(), client, testMetrics(5), 12345, 2))
+	require.Len(t, unary.lists, 3)
+	for _, list := range unary.lists {
+		assert.Equal(t, int64(12345), list.Timestamp)
+	}
+	assert.Len(t, unary.lists[2].Metrics, 1)
+}
+
+func TestSendReturnsUnsent(t *testing.T) {
+	unary := &unaryServer{failAfter: 1}
+	srv := grpc.NewServer()
+	forwardrpc.RegisterForwardServer(srv, unary)
+	ln, err := net.Listen("tcp", "127.0.0.1:")
+	require.NoError(t, err)
+	go srv.Serve(ln)
+	defer srv.Stop()
+	conn := dial(t, ln.Addr().String())
+	defer conn.Close()
+	client := forwardrpc.NewForwardClient(conn)
+
+	ms := testMetrics(5)
+	err = forwardrpc.Send(context.Background(), client, ms, 0, 2)
+	require.Error(t, err)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	unsent := forwardrpc.Unsent(err, ms)
+	assert.Equal(t, ms[2:], unsent, "the batch that was delivered shouldn't be sent again")
+
+	unary.failAfter = 0
+	require.NoError(t, forwardrpc.Send(context.Background(), client, unsent, 0, 2))
+	delivered := 0
+	for _, list := range unary.lists {
+		delivered += len(list.Metrics)
+	}
+	assert.Equal(t, len(ms), delivered, "every metric should be delivered exactly once")
+
+	err = status.Error(codes.Unavailable, "down")
+	assert.Equal(t, ms, forwardrpc.Unsent(err, ms), "nothing was delivered")
+	assert.Empty(t, forwardrpc.Unsent(nil, ms))
+}
@@ -3,8 +3,14 @@ package veneur
 //go:generate protoc --gogofaster_out=Mssf/sample.proto=github.com/stripe/veneur/ssf,plugins=grpc:. sinks/grpsink/grpc_sink.proto
 //go:generate protoc --gogofaster_out=. ssf/sample.proto
 //go:generate protoc -I=. -I=$GOPATH/src -I=$GOPATH/src/github.com/gogo/protobuf/protobuf --gogofaster_out=. tdigest/tdigest.proto
-//go:generate protoc -I=. -I=$GOPATH/src -I=$GOPATH/src/github.com/gogo/protobuf/protobuf --gogofaster_out=Mtdigest/tdigest.proto=github.com/stripe/veneur/tdigest:. samplers/metricpb/metric.proto
-//go:generate protoc -I=. -I=$GOPATH/src -I=$GOPATH/src/github.com/gogo/protobuf/protobuf --gogofaster_out=Mtdigest/tdigest.proto=github.com/stripe/veneur/tdigest,Msamplers/metricpb/metric.proto=github.com/stripe/veneur/samplers/metricpb,Mgoogle/protobuf/empty.proto=github.com/golang/protobuf/ptypes/empty,plugins=grpc:. forwardrpc/forward.proto
+//go:generate protoc -I=. -I=$GOPATH/src -I=$GOPATH/src/github.com/gogo/protobuf/protobuf --gogofaster_out=. ddsketch/ddsketch.proto
+//go:generate protoc -I=. -I=$GOPATH/src -I=$GOPATH/src/github.com/gogo/protobuf/protobuf --gogofaster_out=Mtdigest/tdigest.proto=github.com/stripe/veneur/tdigest,Mddsketch/ddsketch.proto=github.com/stripe/veneur/ddsketch:. samplers/metricpb/metric.proto
+//go:generate protoc -I=. -I=$GOPATH/src -I=$GOPATH/src/github.com/gogo/protobuf/protobuf --gogofaster_out=Mtdigest/tdigest.proto=github.com/stripe/veneur/tdigest,Mddsketch/ddsketch.proto=github.com/stripe/veneur/ddsketch,Msamplers/metricpb/metric.proto=github.com/stripe/veneur/samplers/metricpb,Mgoogle/protobuf/empty.proto=github.com/golang/protobuf/ptypes/empty,plugins=grpc:. forwardrpc/forward.proto
+//go:generate protoc -I=. -I=$GOPATH/src -I=$GOPATH/src/github.com/gogo/protobuf/protobuf --gogofaster_out=. sinks/prometheus/prompb/remote.proto
+//go:generate protoc -I=. --gogofaster_out=$GOPATH/src otlp/commonpb/common.proto otlp/resourcepb/resource.proto otlp/tracepb/trace.proto otlp/metricspb/metrics.proto
+//go:generate protoc -I=. --gogofaster_out=plugins=grpc:$GOPATH/src otlp/collectortracepb/trace_service.proto otlp/collectormetricspb/metrics_service.proto
+//go:generate protoc -I=. --gogofaster_out=$GOPATH/src zipkin/zipkinpb/zipkin.proto
+//go:generate protoc -I=. --gogofaster_out=plugins=grpc:$GOPATH/src jaeger/jaegerpb/model.proto jaeger/jaegerpb/collector.proto
 //go:generate gojson -input example.yaml -o config.go -fmt yaml -pkg veneur -name Config
 //go:generate gojson -input example_proxy.yaml -o config_proxy.go -fmt yaml -pkg veneur -name ProxyConfig
 //go:generate stringer -type MetricType samplers
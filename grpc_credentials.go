@@ -0,0 +1,94 @@
+package veneur
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/stripe/veneur/forwardrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/encoding"
+)
+
+// grpcServerOptions returns the options of a gRPC server that receives
+// forwarded metrics. The server uses TLS if key is set, and then requires
+// clients to present a certificate signed by authority if that's set too.
+// If any tokens are set, every call must carry one of them, except the calls
+// to the services in exempt.
+func grpcServerOptions(certificate, key, authority string, tokens, exempt []string) ([]grpc.ServerOption, error) {
+	var opts []grpc.ServerOption
+	if key != "" {
+		if certificate == "" {
+			return nil, errors.New("grpc_tls_key is set; must set grpc_tls_certificate")
+		}
+		cert, err := tls.X509KeyPair([]byte(certificate), []byte(key))
+		if err != nil {
+			return nil, err
+		}
+
+		config := &tls.Config{
+			Certificates: []tls.Certificate{cert},
+		}
+		if authority != "" {
+			config.ClientAuth = tls.RequireAndVerifyClientCert
+			config.ClientCAs, err = grpcCertPool(authority)
+			if err != nil {
+				return nil, err
+			}
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(config)))
+	}
+
+	if len(tokens) > 0 {
+		opts = append(opts, forwardrpc.RequireBearerToken(tokens, exempt)...)
+	}
+	return opts, nil
+}
+
+// grpcDialOptions returns the options to connect to the gRPC server that
+// metrics are forwarded to. The connection uses TLS if authority is set,
+// verifying the server against it and presenting certificate and key as the
+// client certificate if they're set. If token is set, it's sent with every
+// RPC. If compression is set, RPCs are compressed with that compressor.
+func grpcDialOptions(certificate, key, authority, token, compression string) ([]grpc.DialOption, error) {
+	opts := []grpc.DialOption{grpc.WithInsecure()}
+	if authority != "" {
+		pool, err := grpcCertPool(authority)
+		if err != nil {
+			return nil, err
+		}
+		config := &tls.Config{RootCAs: pool}
+		if key != "" {
+			if certificate == "" {
+				return nil, errors.New("grpc_tls_key is set; must set grpc_tls_certificate")
+			}
+			cert, err := tls.X509KeyPair([]byte(certificate), []byte(key))
+			if err != nil {
+				return nil, err
+			}
+			config.Certificates = []tls.Certificate{cert}
+		}
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(config))}
+	}
+
+	if token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(forwardrpc.BearerToken(token)))
+	}
+	if compression != "" {
+		if encoding.GetCompressor(compression) == nil {
+			return nil, fmt.Errorf("forward_grpc_compression: unknown compressor %q", compression)
+		}
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.UseCompressor(compression)))
+	}
+	return opts, nil
+}
+
+func grpcCertPool(authority string) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(authority)) {
+		return nil, errors.New("grpc_tls_authority_certificate: Could not load any certificates")
+	}
+	return pool, nil
+}
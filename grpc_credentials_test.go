@@ -0,0 +1,169 @@
+package veneur
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/veneur/forwardrpc"
+	"github.com/stripe/veneur/importsrv"
+	"github.com/stripe/veneur/otlp/collectormetricspb"
+	"github.com/stripe/veneur/samplers"
+	"github.com/stripe/veneur/samplers/metricpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testCertificate returns a PEM-encoded certificate and key for 127.0.0.1,
+// signed by the parent certificate and key if they're set, or self-signed.
+func testCertificate(t *testing.T, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return cert, key,
+		string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+type countingIngester struct {
+	metrics int64
+}
+
+func (ci *countingIngester) IngestMetrics(ms []*metricpb.Metric) {
+	atomic.AddInt64(&ci.metrics, int64(len(ms)))
+}
+
+func TestGRPCCredentials(t *testing.T) {
+	ca, caKey, caPEM, _ := testCertificate(t, "ca", nil, nil)
+	_, _, serverCert, serverKey := testCertificate(t, "global", ca, caKey)
+	_, _, clientCert, clientKey := testCertificate(t, "local", ca, caKey)
+	_, _, otherCA, _ := testCertificate(t, "other", nil, nil)
+
+	serverOpts, err := grpcServerOptions(serverCert, serverKey, caPEM, []string{"token1", "token2"}, nil)
+	require.NoError(t, err)
+	ingester := &countingIngester{}
+	srv := importsrv.New([]importsrv.MetricIngester{ingester},
+		importsrv.WithServerOptions(serverOpts...))
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go srv.Server.Serve(ln)
+	defer srv.Stop()
+
+	send := func(certificate, key, authority, token string) error {
+		dialOpts, err := grpcDialOptions(certificate, key, authority, token, "")
+		require.NoError(t, err)
+		conn, err := grpc.Dial(ln.Addr().String(), dialOpts...)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, err = forwardrpc.NewForwardClient(conn).SendMetrics(ctx, &forwardrpc.MetricList{
+			Metrics: []*metricpb.Metric{{Name: "test.counter", Type: metricpb.Type_Counter}},
+		})
+		return err
+	}
+
+	assert.Error(t, send("", "", "", "token1"), "the server requires TLS")
+	assert.Error(t, send("", "", caPEM, "token1"), "the server requires a client certificate")
+	assert.Error(t, send(clientCert, clientKey, otherCA, "token1"), "the server isn't signed by the authority")
+
+	err = send(clientCert, clientKey, caPEM, "")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "no token")
+	err = send(clientCert, clientKey, caPEM, "token3")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "wrong token")
+	assert.Zero(t, atomic.LoadInt64(&ingester.metrics))
+
+	assert.NoError(t, send(clientCert, clientKey, caPEM, "token1"))
+	assert.NoError(t, send(clientCert, clientKey, caPEM, "token2"))
+	assert.Equal(t, int64(2), atomic.LoadInt64(&ingester.metrics))
+}
+
+type countingUDPIngester struct {
+	metrics int64
+}
+
+func (ci *countingUDPIngester) IngestUDP(m samplers.UDPMetric) {
+	atomic.AddInt64(&ci.metrics, 1)
+}
+
+func TestGRPCCredentialsOTLP(t *testing.T) {
+	export := func(exempt []string, token string) error {
+		serverOpts, err := grpcServerOptions("", "", "", []string{"token1"}, exempt)
+		require.NoError(t, err)
+		srv := importsrv.New([]importsrv.MetricIngester{},
+			importsrv.WithOTLPMetrics([]importsrv.UDPMetricIngester{&countingUDPIngester{}}),
+			importsrv.WithServerOptions(serverOpts...))
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		go srv.Server.Serve(ln)
+		defer srv.Stop()
+
+		dialOpts, err := grpcDialOptions("", "", "", token, "")
+		require.NoError(t, err)
+		conn, err := grpc.Dial(ln.Addr().String(), dialOpts...)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, err = collectormetricspb.NewMetricsServiceClient(conn).Export(ctx,
+			&collectormetricspb.ExportMetricsServiceRequest{})
+		return err
+	}
+
+	err := export(nil, "")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "OTLP exports need a token too")
+	assert.NoError(t, export(nil, "token1"))
+	assert.NoError(t, export([]string{"opentelemetry.proto.collector.metrics.v1.MetricsService"}, ""),
+		"exempt services don't need a token")
+}
+
+func TestGRPCCredentialsErrors(t *testing.T) {
+	_, _, cert, key := testCertificate(t, "global", nil, nil)
+
+	_, err := grpcServerOptions("", key, "", nil, nil)
+	assert.Error(t, err, "the key needs a certificate")
+	_, err = grpcServerOptions(cert, key, "not a certificate", nil, nil)
+	assert.Error(t, err)
+	_, err = grpcDialOptions(cert, key, "not a certificate", "", "")
+	assert.Error(t, err)
+	_, err = grpcDialOptions("", "", "", "", "lz4")
+	assert.Error(t, err, "lz4 isn't registered")
+
+	opts, err := grpcServerOptions("", "", "", nil, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, opts, "the server is plaintext and open by default")
+}
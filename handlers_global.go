@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
@@ -36,9 +37,10 @@ func handleProxy(p *Proxy) http.Handler {
 			log.WithError(err).Error("Error unmarshalling metrics in proxy import")
 			return
 		}
+		timestamp, _ := strconv.ParseInt(r.URL.Query().Get("timestamp"), 10, 64)
 		// the server usually waits for this to return before finalizing the
 		// response, so this part must be done asynchronously
-		go p.ProxyMetrics(span.Attach(ctx), jsonMetrics, strings.SplitN(r.RemoteAddr, ":", 2)[0])
+		go p.ProxyMetrics(span.Attach(ctx), jsonMetrics, strings.SplitN(r.RemoteAddr, ":", 2)[0], timestamp)
 	})
 }
 
@@ -65,6 +67,17 @@ func handleImport(s *Server) http.Handler {
 			span.Add(ssf.Count("import.unmarshal.errors_total", 1, nil))
 			return
 		}
+		// metrics replayed from a local veneur's forward spool carry the
+		// timestamp of the interval they were aggregated in
+		if timestamp, err := strconv.ParseInt(r.URL.Query().Get("timestamp"), 10, 64); err == nil && timestamp != 0 {
+			span.Add(ssf.Count("import.backfill_metrics_total", float32(len(jsonMetrics)), nil))
+			s.backfill(timestamp, func(w *Worker) {
+				for _, jm := range jsonMetrics {
+					w.ImportMetric(jm)
+				}
+			})
+			return
+		}
 		// the server usually waits for this to return before finalizing the
 		// response, so this part must be done asynchronously
 		go s.ImportMetrics(span.Attach(ctx), jsonMetrics)
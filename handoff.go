@@ -0,0 +1,138 @@
+package veneur
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stripe/veneur/forwardrpc"
+	"github.com/stripe/veneur/hashring"
+	"github.com/stripe/veneur/samplers"
+	"github.com/stripe/veneur/samplers/metricpb"
+	"github.com/stripe/veneur/ssf"
+	"github.com/stripe/veneur/trace"
+	"google.golang.org/grpc"
+)
+
+// ringHandoff is a change to the ring that a proxy hashes metrics over, as
+// announced by the proxy. Until it expires, each flush hands the metrics
+// that moved to another member of the ring off to that member, instead of
+// flushing the part of them that was aggregated here.
+type ringHandoff struct {
+	// self is the member of the ring that this veneur is
+	self    string
+	ring    hashring.Ring
+	expires time.Time
+}
+
+// owner returns the member of the ring that key moved to, unless it's this
+// veneur.
+func (h *ringHandoff) owner(key samplers.MetricKey) (string, bool) {
+	dest, err := h.ring.Get(key.String())
+	if err != nil || dest == h.self {
+		return "", false
+	}
+	return dest, true
+}
+
+// activeHandoff returns the ring change whose grace period is in progress,
+// if there is one.
+func (s *Server) activeHandoff() *ringHandoff {
+	s.handoffMtx.Lock()
+	defer s.handoffMtx.Unlock()
+	if s.handoff == nil || time.Now().After(s.handoff.expires) {
+		return nil
+	}
+	return s.handoff
+}
+
+// handOff sends metrics to the members of the ring they moved to. The
+// metrics that couldn't be sent are returned, to be flushed by this veneur
+// after all.
+func (s *Server) handOff(ctx context.Context, moved map[string][]*metricpb.Metric) WorkerMetrics {
+	span, _ := trace.StartSpanFromContext(ctx, "")
+	defer span.ClientFinish(s.TraceClient)
+
+	// leave time to flush the rest of the metrics if a destination hangs
+	ctx, cancel := context.WithTimeout(ctx, s.interval/2)
+	defer cancel()
+
+	var mtx sync.Mutex
+	var failed []*metricpb.Metric
+	wg := sync.WaitGroup{}
+	wg.Add(len(moved))
+	for dest, ms := range moved {
+		go func(dest string, ms []*metricpb.Metric) {
+			defer wg.Done()
+			entry := log.WithFields(logrus.Fields{
+				"destination": dest,
+				"metrics":     len(ms),
+			})
+			if err := handOffTo(ctx, dest, ms, s.grpcBatchSize, s.grpcDialOptions...); err != nil {
+				entry.WithError(err).Warn("Failed to hand off metrics; flushing them here")
+				mtx.Lock()
+				failed = append(failed, forwardrpc.Unsent(err, ms)...)
+				mtx.Unlock()
+				return
+			}
+			entry.Debug("Handed off metrics that moved to another member of the ring")
+		}(dest, ms)
+	}
+	wg.Wait()
+
+	total := 0
+	for _, ms := range moved {
+		total += len(ms)
+	}
+	span.Add(
+		ssf.Count("flush.handoff_metrics_total", float32(total-len(failed)), map[string]string{"status": "success"}),
+		ssf.Count("flush.handoff_metrics_total", float32(len(failed)), map[string]string{"status": "failure"}),
+	)
+
+	w := NewWorker(0, s.IsLocal(), false, s.TraceClient, log, nil)
+	w.SetSketchSelector(s.sketches)
+	for _, m := range failed {
+		w.ImportMetricGRPC(m)
+	}
+	return w.Flush()
+}
+
+func handOffTo(ctx context.Context, dest string, ms []*metricpb.Metric, batchSize int, opts ...grpc.DialOption) error {
+	conn, err := grpc.DialContext(ctx, dest, opts...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return forwardrpc.Send(ctx, forwardrpc.NewForwardClient(conn), ms, 0, batchSize)
+}
+
+// handoffReceiver receives the ring changes that proxies announce over
+// gRPC.
+type handoffReceiver struct {
+	s *Server
+}
+
+func (hr handoffReceiver) SetRing(r *forwardrpc.Ring) error {
+	ring, err := hashring.New(r.Strategy, r.LoadFactor)
+	if err != nil {
+		return err
+	}
+	ring.Set(r.Members)
+
+	grace := time.Duration(r.GracePeriodNs)
+	hr.s.handoffMtx.Lock()
+	hr.s.handoff = &ringHandoff{
+		self:    r.Self,
+		ring:    ring,
+		expires: time.Now().Add(grace),
+	}
+	hr.s.handoffMtx.Unlock()
+
+	log.WithFields(logrus.Fields{
+		"self":         r.Self,
+		"members":      len(r.Members),
+		"grace_period": grace,
+	}).Info("Handing off the metrics that moved to other members of the ring")
+	return nil
+}
@@ -0,0 +1,133 @@
+package veneur
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/veneur/forwardrpc"
+	"github.com/stripe/veneur/hashring"
+	"github.com/stripe/veneur/internal/forwardtest"
+	"github.com/stripe/veneur/samplers"
+	"github.com/stripe/veneur/samplers/metricpb"
+)
+
+func handoffTestCounters(n int) []*metricpb.Metric {
+	ms := make([]*metricpb.Metric, n)
+	for i := range ms {
+		ms[i] = &metricpb.Metric{
+			Name:  fmt.Sprintf("handoff.counter.%d", i),
+			Tags:  []string{"foo:bar"},
+			Type:  metricpb.Type_Counter,
+			Value: &metricpb.Metric_Counter{Counter: &metricpb.CounterValue{Value: 1}},
+		}
+	}
+	return ms
+}
+
+// flushedNames flushes s, and returns the names of the metrics that reach
+// its sink.
+func flushedNames(t *testing.T, s *Server, rcv chan []samplers.InterMetric) map[string]bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	s.Flush(ctx)
+
+	names := map[string]bool{}
+	select {
+	case ms := <-rcv:
+		for _, m := range ms {
+			names[m.Name] = true
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the flush")
+	}
+	return names
+}
+
+func TestHandoffMovedMetrics(t *testing.T) {
+	var mtx sync.Mutex
+	handedOff := map[string]bool{}
+	owner := forwardtest.NewServer(func(ms []*metricpb.Metric) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		for _, m := range ms {
+			handedOff[m.Name] = true
+		}
+	})
+	owner.Start(t)
+	defer owner.Stop()
+
+	rcv := make(chan []samplers.InterMetric, 10)
+	sink, err := NewChannelMetricSink(rcv)
+	require.NoError(t, err)
+	s := setupVeneurServer(t, globalConfig(), nil, sink, nil, nil)
+	defer s.Shutdown()
+
+	ring := &forwardrpc.Ring{
+		Self:          "localhost:8128",
+		Members:       []string{"localhost:8128", owner.Addr().String()},
+		Strategy:      hashring.RendezvousHashing,
+		GracePeriodNs: time.Minute.Nanoseconds(),
+	}
+	require.NoError(t, handoffReceiver{s}.SetRing(ring))
+
+	counters := handoffTestCounters(50)
+	for _, m := range counters {
+		s.Workers[0].ImportMetricGRPC(m)
+	}
+	flushed := flushedNames(t, s, rcv)
+
+	expected, err := hashring.New(ring.Strategy, ring.LoadFactor)
+	require.NoError(t, err)
+	expected.Set(ring.Members)
+	mtx.Lock()
+	defer mtx.Unlock()
+	for _, m := range counters {
+		dest, err := expected.Get(samplers.NewMetricKeyFromMetric(m).String())
+		require.NoError(t, err)
+		if dest == ring.Self {
+			assert.True(t, flushed[m.Name], "%s should be flushed here", m.Name)
+			assert.False(t, handedOff[m.Name], "%s shouldn't be handed off", m.Name)
+		} else {
+			assert.False(t, flushed[m.Name], "%s shouldn't be flushed here", m.Name)
+			assert.True(t, handedOff[m.Name], "%s should be handed off", m.Name)
+		}
+	}
+	assert.NotEmpty(t, handedOff)
+}
+
+func TestHandoffFailuresAndExpiry(t *testing.T) {
+	rcv := make(chan []samplers.InterMetric, 10)
+	sink, err := NewChannelMetricSink(rcv)
+	require.NoError(t, err)
+	s := setupVeneurServer(t, globalConfig(), nil, sink, nil, nil)
+	defer s.Shutdown()
+
+	counters := handoffTestCounters(20)
+	tests := map[string]time.Duration{
+		// nothing is listening on the new owner, so the metrics are
+		// flushed here after all
+		"unreachable": time.Minute,
+		"expired":     -time.Second,
+	}
+	for name, grace := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, handoffReceiver{s}.SetRing(&forwardrpc.Ring{
+				Self:          "localhost:8128",
+				Members:       []string{"127.0.0.1:1"},
+				GracePeriodNs: grace.Nanoseconds(),
+			}))
+			for _, m := range counters {
+				s.Workers[0].ImportMetricGRPC(m)
+			}
+			flushed := flushedNames(t, s, rcv)
+			for _, m := range counters {
+				assert.True(t, flushed[m.Name], "%s should be flushed here", m.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package hashring
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/segmentio/fasthash/fnv1a"
+)
+
+const (
+	// partitions is the number of parts that a BoundedLoad ring divides the
+	// key space into. Each part is assigned to a member as a whole.
+	partitions = 1021
+	// replicas is the number of points that each member has on a
+	// BoundedLoad ring.
+	replicas = 64
+)
+
+// BoundedLoad is a Ring using consistent hashing with bounded loads. The
+// key space is divided into a fixed number of partitions, and each one is
+// assigned to the first member after it on a consistent hash ring that
+// doesn't already have more than the load factor times its fair share of
+// the partitions.
+//
+// When the members change, partitions move as they would with consistent
+// hashing, plus the ones displaced by members reaching their bound.
+type BoundedLoad struct {
+	loadFactor float64
+
+	mtx     sync.RWMutex
+	members []string
+	// owners holds the member that each partition is assigned to
+	owners []string
+}
+
+// NewBoundedLoad creates an empty BoundedLoad ring. No member is assigned
+// more than loadFactor times an even share of the key space, so the load
+// factor must be at least 1.
+func NewBoundedLoad(loadFactor float64) (*BoundedLoad, error) {
+	if loadFactor < 1 || math.IsInf(loadFactor, 0) {
+		return nil, fmt.Errorf("the load factor of a bounded load hash ring must be at least 1, not %v", loadFactor)
+	}
+	return &BoundedLoad{loadFactor: loadFactor}, nil
+}
+
+// Get returns the member that owns key's partition.
+func (b *BoundedLoad) Get(key string) (string, error) {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	if len(b.owners) == 0 {
+		return "", ErrNoMembers
+	}
+	return b.owners[mix64(fnv1a.HashString64(key))%partitions], nil
+}
+
+// Add adds a member to the ring, unless it's already a member.
+func (b *BoundedLoad) Add(member string) {
+	b.Set(append(b.Members(), member))
+}
+
+// Set replaces the members of the ring, and reassigns the partitions.
+func (b *BoundedLoad) Set(members []string) {
+	members = uniqueSorted(append([]string(nil), members...))
+	owners := b.assign(members)
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.members = members
+	b.owners = owners
+}
+
+// Members returns the members of the ring, sorted.
+func (b *BoundedLoad) Members() []string {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return append([]string(nil), b.members...)
+}
+
+type point struct {
+	hash   uint64
+	member string
+}
+
+// assign assigns every partition to one of members.
+func (b *BoundedLoad) assign(members []string) []string {
+	if len(members) == 0 {
+		return nil
+	}
+
+	ring := make([]point, 0, len(members)*replicas)
+	for _, member := range members {
+		for i := 0; i < replicas; i++ {
+			ring = append(ring, point{
+				hash:   mix64(fnv1a.HashString64(member + "#" + strconv.Itoa(i))),
+				member: member,
+			})
+		}
+	}
+	sort.Slice(ring, func(i, j int) bool {
+		if ring[i].hash == ring[j].hash {
+			return ring[i].member < ring[j].member
+		}
+		return ring[i].hash < ring[j].hash
+	})
+
+	bound := int(math.Ceil(float64(partitions) * b.loadFactor / float64(len(members))))
+	loads := make(map[string]int, len(members))
+	owners := make([]string, partitions)
+	for p := range owners {
+		h := mix64(fnv1a.HashString64(strconv.Itoa(p)))
+		i := sort.Search(len(ring), func(i int) bool { return ring[i].hash >= h })
+		// there are enough slots for every partition, so this finds a
+		// member with room for it
+		for {
+			member := ring[i%len(ring)].member
+			if loads[member] < bound {
+				loads[member]++
+				owners[p] = member
+				break
+			}
+			i++
+		}
+	}
+	return owners
+}
@@ -0,0 +1,89 @@
+// Package hashring provides the strategies that veneur-proxy can use to
+// choose the destination of each metric or trace.
+//
+// Every strategy is a Ring, which maps keys to one of its members
+// deterministically: every proxy with the same members sends a key to the
+// same destination, which is what lets global veneurs aggregate each
+// timeseries in one place. The strategies differ in how evenly they spread
+// keys, and in how many keys move when the members change.
+package hashring
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"stathat.com/c/consistent"
+)
+
+// The names of the hashing strategies, as configured.
+const (
+	// Consistent is consistent hashing on a ring of virtual nodes, as in
+	// stathat.com/c/consistent. It was the only strategy before the
+	// others were added, and is the default.
+	Consistent = "consistent"
+	// RendezvousHashing is highest random weight hashing. Only the keys
+	// of removed members move, and only keys moving to added members move
+	// to them.
+	RendezvousHashing = "rendezvous"
+	// BoundedLoadHashing is consistent hashing with bounded loads: no
+	// member receives more than a load factor times its fair share of the
+	// key space.
+	BoundedLoadHashing = "bounded_load"
+)
+
+// ErrNoMembers is returned by Get if the ring has no members.
+var ErrNoMembers = errors.New("the hash ring has no members")
+
+// A Ring chooses a member for each key. Rings are safe for concurrent use.
+type Ring interface {
+	// Get returns the member that key maps to.
+	Get(key string) (string, error)
+	// Add adds a member to the ring.
+	Add(member string)
+	// Set replaces the members of the ring.
+	Set(members []string)
+	// Members returns the members of the ring.
+	Members() []string
+}
+
+// New creates an empty Ring using the named strategy. The load factor only
+// applies to BoundedLoadHashing.
+func New(strategy string, loadFactor float64) (Ring, error) {
+	switch strategy {
+	case "", Consistent:
+		return consistent.New(), nil
+	case RendezvousHashing:
+		return NewRendezvous(), nil
+	case BoundedLoadHashing:
+		return NewBoundedLoad(loadFactor)
+	default:
+		return nil, fmt.Errorf("unknown hashing strategy %q", strategy)
+	}
+}
+
+// probeKeys is the number of keys that Set samples to measure how much of
+// the key space moves.
+const probeKeys = 1000
+
+// Set replaces the members of a ring, and returns the fraction of the key
+// space that moved to a different member as a result. It's estimated from
+// a sample of keys, and is 1 if the ring had no members.
+func Set(r Ring, members []string) float64 {
+	before := make([]string, probeKeys)
+	for i := range before {
+		before[i], _ = r.Get(probeKey(i))
+	}
+	r.Set(members)
+	moved := 0
+	for i, dest := range before {
+		if after, _ := r.Get(probeKey(i)); after != dest {
+			moved++
+		}
+	}
+	return float64(moved) / probeKeys
+}
+
+func probeKey(i int) string {
+	return "veneur.hashring.probe." + strconv.Itoa(i)
+}
@@ -0,0 +1,114 @@
+package hashring
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func hosts(n int) []string {
+	ret := make([]string, n)
+	for i := range ret {
+		ret[i] = fmt.Sprintf("veneur-global-%d:8128", i)
+	}
+	return ret
+}
+
+func keys(n int) []string {
+	ret := make([]string, n)
+	for i := range ret {
+		ret[i] = fmt.Sprintf("api.requests.%dcounterhost:%d", i, i%7)
+	}
+	return ret
+}
+
+func assignments(t *testing.T, r Ring, keys []string) map[string]string {
+	ret := make(map[string]string, len(keys))
+	for _, key := range keys {
+		member, err := r.Get(key)
+		require.NoError(t, err)
+		ret[key] = member
+	}
+	return ret
+}
+
+func newRings(t *testing.T) map[string]Ring {
+	rings := map[string]Ring{}
+	for _, strategy := range []string{Consistent, RendezvousHashing, BoundedLoadHashing} {
+		r, err := New(strategy, 1.25)
+		require.NoError(t, err)
+		rings[strategy] = r
+	}
+	return rings
+}
+
+func TestRingsAreDeterministic(t *testing.T) {
+	for strategy := range newRings(t) {
+		a, _ := New(strategy, 1.25)
+		b, _ := New(strategy, 1.25)
+		a.Set(hosts(5))
+		// the order that members are added in doesn't matter:
+		for _, h := range []string{"veneur-global-3:8128", "veneur-global-0:8128", "veneur-global-4:8128", "veneur-global-1:8128", "veneur-global-2:8128"} {
+			b.Add(h)
+		}
+		assert.Equal(t, assignments(t, a, keys(1000)), assignments(t, b, keys(1000)), strategy)
+		assert.ElementsMatch(t, hosts(5), b.Members(), strategy)
+	}
+}
+
+func TestRingsWithoutMembers(t *testing.T) {
+	for strategy, r := range newRings(t) {
+		_, err := r.Get("foo")
+		assert.Error(t, err, strategy)
+	}
+}
+
+func TestRendezvousMovesOnlyRemovedKeys(t *testing.T) {
+	r := NewRendezvous()
+	r.Set(hosts(5))
+	before := assignments(t, r, keys(10000))
+
+	moved := Set(r, hosts(4))
+	after := assignments(t, r, keys(10000))
+	removed := hosts(5)[4]
+	for key, member := range before {
+		if member != removed {
+			assert.Equal(t, member, after[key], "only the keys of the removed member should move")
+		}
+	}
+	assert.InDelta(t, 0.2, moved, 0.05)
+}
+
+func TestBoundedLoadBoundsLoads(t *testing.T) {
+	b, err := NewBoundedLoad(1.1)
+	require.NoError(t, err)
+	b.Set(hosts(7))
+
+	loads := map[string]int{}
+	for _, owner := range b.owners {
+		loads[owner]++
+	}
+	assert.Len(t, loads, 7)
+	for member, load := range loads {
+		assert.True(t, float64(load) <= 1.1*partitions/7+1, "%s owns %d of %d partitions", member, load, partitions)
+	}
+
+	// adding a member shouldn't move much more than its share of keys:
+	moved := Set(b, hosts(8))
+	assert.True(t, moved < 0.25, "%v of the keys moved", moved)
+}
+
+func TestNewErrors(t *testing.T) {
+	_, err := New("jump", 1)
+	assert.Error(t, err)
+	_, err = New(BoundedLoadHashing, 0.5)
+	assert.Error(t, err)
+}
+
+func TestSetMovement(t *testing.T) {
+	r := NewRendezvous()
+	assert.Equal(t, float64(1), Set(r, hosts(3)), "every key moves away from an empty ring")
+	assert.Equal(t, float64(0), Set(r, hosts(3)))
+}
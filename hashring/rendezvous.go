@@ -0,0 +1,95 @@
+package hashring
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/segmentio/fasthash/fnv1a"
+)
+
+// Rendezvous is a Ring that maps each key to the member with the highest
+// score for it, where the score is a hash of the member and the key.
+//
+// When a member is removed, only its keys move, and they spread evenly
+// across the remaining members. When a member is added, it takes an even
+// share of keys from every other member. Get takes time linear in the
+// number of members.
+type Rendezvous struct {
+	mtx     sync.RWMutex
+	members []string
+}
+
+// NewRendezvous creates an empty Rendezvous ring.
+func NewRendezvous() *Rendezvous {
+	return &Rendezvous{}
+}
+
+// Get returns the member with the highest score for key.
+func (r *Rendezvous) Get(key string) (string, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	if len(r.members) == 0 {
+		return "", ErrNoMembers
+	}
+
+	keyHash := fnv1a.HashString64(key)
+	var (
+		best      string
+		bestScore uint64
+	)
+	for i, member := range r.members {
+		score := mix64(fnv1a.AddString64(keyHash, member))
+		// members are sorted, so ties go to the first one
+		if i == 0 || score > bestScore {
+			best, bestScore = member, score
+		}
+	}
+	return best, nil
+}
+
+// Add adds a member to the ring, unless it's already a member.
+func (r *Rendezvous) Add(member string) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.members = uniqueSorted(append(r.members, member))
+}
+
+// Set replaces the members of the ring.
+func (r *Rendezvous) Set(members []string) {
+	members = uniqueSorted(append([]string(nil), members...))
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.members = members
+}
+
+// Members returns the members of the ring, sorted.
+func (r *Rendezvous) Members() []string {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	return append([]string(nil), r.members...)
+}
+
+// mix64 is the finalizer of MurmurHash3. FNV-1a hashes of strings that
+// share a prefix are poorly distributed in their high bits, which this
+// fixes.
+func mix64(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+// uniqueSorted sorts members and removes duplicates, in place.
+func uniqueSorted(members []string) []string {
+	sort.Strings(members)
+	ret := members[:0]
+	for i, member := range members {
+		if i > 0 && member == members[i-1] {
+			continue
+		}
+		ret = append(ret, member)
+	}
+	return ret
+}
@@ -48,6 +48,10 @@ func (s *Server) Handler() http.Handler {
 
 	mux.Handle(pat.Post("/import"), handleImport(s))
 
+	if s.prometheusScrapeSink != nil {
+		mux.Handle(pat.Get("/metrics"), s.prometheusScrapeSink)
+	}
+
 	mux.Handle(pat.Get("/debug/pprof/cmdline"), http.HandlerFunc(pprof.Cmdline))
 	mux.Handle(pat.Get("/debug/pprof/profile"), http.HandlerFunc(pprof.Profile))
 	mux.Handle(pat.Get("/debug/pprof/symbol"), http.HandlerFunc(pprof.Symbol))
@@ -21,6 +21,27 @@ import (
 
 var tracer = trace.GlobalTracer
 
+// StatusError is the error PostHelper returns when the server responds with
+// a status other than 200 or 202.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return strconv.Itoa(e.StatusCode)
+}
+
+// Permanent returns whether sending the same request again would fail the
+// same way: the server rejected it with a 4xx status, other than a timeout
+// or a rate limit.
+func (e *StatusError) Permanent() bool {
+	switch e.StatusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return false
+	}
+	return e.StatusCode >= 400 && e.StatusCode < 500
+}
+
 // httpClientTracer is a wrapper around a `net/http/httptrace` that handles
 // proper tracing and metric emission.
 type httpClientTracer struct {
@@ -264,7 +285,7 @@ func PostHelper(ctx context.Context, httpClient *http.Client, tc *trace.Client,
 	})
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
-		err := fmt.Errorf("%v", resp.StatusCode)
+		err := &StatusError{StatusCode: resp.StatusCode}
 		span.Error(err)
 		span.Add(ssf.Count(action+".error_total", 1, mergeTags(extraTags, "cause", strconv.Itoa(resp.StatusCode))))
 		resultLogger.WithError(err).Warn("Could not POST")
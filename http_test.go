@@ -358,6 +358,33 @@ func TestVersion(t *testing.T) {
 	assert.Equal(t, string(bts), VERSION, "received invalid version")
 }
 
+func TestPrometheusScrapeEndpoint(t *testing.T) {
+	config := localConfig()
+	config.SsfListenAddresses = []string{}
+	s := setupVeneurServer(t, config, nil, nil, nil, nil)
+	w := httptest.NewRecorder()
+	s.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code, "/metrics should only be served when enabled")
+	s.Shutdown()
+
+	config.PrometheusScrapeEnabled = true
+	s = setupVeneurServer(t, config, nil, nil, nil, nil)
+	defer s.Shutdown()
+
+	err := s.prometheusScrapeSink.Flush(context.Background(), []samplers.InterMetric{{
+		Name:      "a.gauge",
+		Timestamp: time.Now().Unix(),
+		Value:     2,
+		Type:      samplers.GaugeMetric,
+	}})
+	assert.NoError(t, err)
+
+	w = httptest.NewRecorder()
+	s.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "a_gauge 2")
+}
+
 func testServerImportHelper(t *testing.T, data interface{}) {
 	var b bytes.Buffer
 	err := json.NewEncoder(&b).Encode(data)
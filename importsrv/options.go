@@ -1,6 +1,9 @@
 package importsrv
 
-import "github.com/stripe/veneur/trace"
+import (
+	"github.com/stripe/veneur/trace"
+	"google.golang.org/grpc"
+)
 
 // WithTraceClient sets the trace client for the server.  Otherwise it uses
 // trace.DefaultClient.
@@ -9,3 +12,63 @@ func WithTraceClient(c *trace.Client) Option {
 		opts.traceClient = c
 	}
 }
+
+// WithOTLPSpans enables the OTLP trace service, which translates the
+// spans it receives to SSF and sends them to si.
+func WithOTLPSpans(si SpanIngester) Option {
+	return func(opts *options) {
+		opts.spanOut = si
+	}
+}
+
+// WithJaegerSpans enables the Jaeger collector's CollectorService, which
+// translates the spans it receives to SSF and sends them to si.
+func WithJaegerSpans(si SpanIngester) Option {
+	return func(opts *options) {
+		opts.jaegerOut = si
+	}
+}
+
+// WithPeerSpans enables the SpanSink service that grpsink span sinks send
+// to, which passes the spans it receives to si as they are. Veneurs that
+// tail sample together use it to send each other the spans of the traces
+// that the other decides.
+func WithPeerSpans(si SpanIngester) Option {
+	return func(opts *options) {
+		opts.peerOut = si
+	}
+}
+
+// WithOTLPMetrics enables the OTLP metrics service, which translates the
+// data points it receives to UDPMetrics and hashes each one to one of
+// outs.
+func WithOTLPMetrics(outs []UDPMetricIngester) Option {
+	return func(opts *options) {
+		opts.udpOuts = outs
+	}
+}
+
+// WithBackfill sends the lists of metrics that have a timestamp, which local
+// veneurs replay from their forward spools, to bi instead of the
+// MetricIngesters.
+func WithBackfill(bi BackfillIngester) Option {
+	return func(opts *options) {
+		opts.backfill = bi
+	}
+}
+
+// WithHandoff enables the Handoff service, which passes the rings that
+// proxies announce on to h.
+func WithHandoff(h Handoffer) Option {
+	return func(opts *options) {
+		opts.handoff = h
+	}
+}
+
+// WithServerOptions sets the options of the underlying grpc.Server, like
+// its transport credentials.
+func WithServerOptions(serverOpts ...grpc.ServerOption) Option {
+	return func(opts *options) {
+		opts.serverOpts = append(opts.serverOpts, serverOpts...)
+	}
+}
@@ -0,0 +1,383 @@
+package importsrv
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/segmentio/fasthash/fnv1a"
+
+	"github.com/stripe/veneur/otlp/collectormetricspb"
+	"github.com/stripe/veneur/otlp/collectortracepb"
+	"github.com/stripe/veneur/otlp/commonpb"
+	"github.com/stripe/veneur/otlp/metricspb"
+	"github.com/stripe/veneur/otlp/tracepb"
+	"github.com/stripe/veneur/samplers"
+	"github.com/stripe/veneur/ssf"
+	"github.com/stripe/veneur/trace"
+)
+
+const (
+	// serviceNameAttribute is the resource attribute that OpenTelemetry
+	// SDKs use to name the service emitting telemetry.
+	serviceNameAttribute = "service.name"
+
+	// unknownService is the service name that the OpenTelemetry spec
+	// prescribes when a resource doesn't carry one.
+	unknownService = "unknown_service"
+
+	errorMessageTag = "error.msg"
+	spanKindTag     = "span.kind"
+)
+
+var otlpTags = map[string]string{"protocol": "otlp"}
+
+// SpanIngester receives spans that were translated from OTLP.
+type SpanIngester interface {
+	IngestSpan(*ssf.SSFSpan)
+}
+
+// UDPMetricIngester processes metrics as if they had been received from
+// a DogStatsD client.
+type UDPMetricIngester interface {
+	IngestUDP(samplers.UDPMetric)
+}
+
+// otlpTraceServer implements the OTLP TraceService on top of a Server.
+type otlpTraceServer struct {
+	*Server
+}
+
+// Export translates the spans in the request to SSF and hands them to
+// the server's SpanIngester. Spans without a span ID are rejected.
+func (s otlpTraceServer) Export(ctx context.Context, req *collectortracepb.ExportTraceServiceRequest) (*collectortracepb.ExportTraceServiceResponse, error) {
+	span, _ := trace.StartSpanFromContext(ctx, "veneur.opentracing.importsrv.handle_otlp_spans")
+	span.SetTag("protocol", "otlp")
+	defer span.ClientFinish(s.opts.traceClient)
+
+	var total, rejected int64
+	for _, rs := range req.ResourceSpans {
+		service, resourceTags := resourceAttributes(rs.GetResource().GetAttributes())
+		for _, ss := range rs.ScopeSpans {
+			for _, otelSpan := range ss.Spans {
+				total++
+				converted, ok := ssfFromOTLP(otelSpan, service, resourceTags)
+				if !ok {
+					rejected++
+					continue
+				}
+				s.opts.spanOut.IngestSpan(converted)
+			}
+		}
+	}
+
+	span.Add(
+		ssf.Count("import.spans_total", float32(total), otlpTags),
+		ssf.Count("import.rejected_total", float32(rejected), otlpTags),
+	)
+
+	res := &collectortracepb.ExportTraceServiceResponse{}
+	if rejected > 0 {
+		res.PartialSuccess = &collectortracepb.ExportTracePartialSuccess{
+			RejectedSpans: rejected,
+			ErrorMessage:  "spans must have an 8-byte span_id",
+		}
+	}
+	return res, nil
+}
+
+// otlpMetricsServer implements the OTLP MetricsService on top of a
+// Server.
+type otlpMetricsServer struct {
+	*Server
+}
+
+// Export translates the data points in the request to UDPMetrics and
+// hashes each one to a worker. Data points that veneur can't represent
+// are rejected.
+func (s otlpMetricsServer) Export(ctx context.Context, req *collectormetricspb.ExportMetricsServiceRequest) (*collectormetricspb.ExportMetricsServiceResponse, error) {
+	span, _ := trace.StartSpanFromContext(ctx, "veneur.opentracing.importsrv.handle_otlp_metrics")
+	span.SetTag("protocol", "otlp")
+	defer span.ClientFinish(s.opts.traceClient)
+
+	var total, rejected int64
+	var reasons []string
+	for _, rm := range req.ResourceMetrics {
+		service, _ := resourceAttributes(rm.GetResource().GetAttributes())
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				metrics, points, err := udpMetricsFromOTLP(m, service)
+				total += points
+				if err != nil {
+					rejected += points
+					reasons = append(reasons, err.Error())
+					continue
+				}
+				for _, metric := range metrics {
+					s.opts.udpOuts[metric.Digest%uint32(len(s.opts.udpOuts))].IngestUDP(metric)
+				}
+			}
+		}
+	}
+
+	span.Add(
+		ssf.Count("import.metrics_total", float32(total), otlpTags),
+		ssf.Count("import.rejected_total", float32(rejected), otlpTags),
+	)
+
+	res := &collectormetricspb.ExportMetricsServiceResponse{}
+	if rejected > 0 {
+		res.PartialSuccess = &collectormetricspb.ExportMetricsPartialSuccess{
+			RejectedDataPoints: rejected,
+			ErrorMessage:       strings.Join(reasons, "; "),
+		}
+	}
+	return res, nil
+}
+
+// resourceAttributes returns the service name of a resource, and its
+// other attributes as tags.
+func resourceAttributes(attrs []*commonpb.KeyValue) (string, map[string]string) {
+	service := unknownService
+	tags := make(map[string]string, len(attrs))
+	for _, kv := range attrs {
+		value, ok := attributeValue(kv.Value)
+		if !ok {
+			continue
+		}
+		if kv.Key == serviceNameAttribute {
+			service = value
+			continue
+		}
+		tags[kv.Key] = value
+	}
+	return service, tags
+}
+
+// attributeValue renders a scalar attribute value as a string. Arrays
+// and maps have no sensible representation as a tag, so they are
+// reported as not ok.
+func attributeValue(v *commonpb.AnyValue) (string, bool) {
+	switch val := v.GetValue().(type) {
+	case *commonpb.AnyValue_StringValue:
+		return val.StringValue, true
+	case *commonpb.AnyValue_BoolValue:
+		return strconv.FormatBool(val.BoolValue), true
+	case *commonpb.AnyValue_IntValue:
+		return strconv.FormatInt(val.IntValue, 10), true
+	case *commonpb.AnyValue_DoubleValue:
+		return strconv.FormatFloat(val.DoubleValue, 'f', -1, 64), true
+	case *commonpb.AnyValue_BytesValue:
+		return fmt.Sprintf("%x", val.BytesValue), true
+	}
+	return "", false
+}
+
+// idFromBytes converts an OTLP span ID to an SSF ID. SSF IDs are 63
+// bits wide, so only the low-order bits of the ID are kept; this is the
+// same truncation other 64-bit tracers apply to W3C trace IDs.
+func idFromBytes(id []byte) int64 {
+	if len(id) < 8 {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(id[len(id)-8:]) & math.MaxInt64)
+}
+
+// traceIDFromBytes splits a 128-bit OTLP trace ID into its high and
+// low 64 bits.
+func traceIDFromBytes(id []byte) (high, low uint64) {
+	if len(id) < 8 {
+		return 0, 0
+	}
+	low = binary.BigEndian.Uint64(id[len(id)-8:])
+	if len(id) >= 16 {
+		high = binary.BigEndian.Uint64(id[len(id)-16 : len(id)-8])
+	}
+	return high, low
+}
+
+// ssfFromOTLP converts an OTLP span to SSF. The span's attributes
+// override attributes of the same name on its resource. It returns
+// false if the span has no usable ID.
+func ssfFromOTLP(span *tracepb.Span, service string, resourceTags map[string]string) (*ssf.SSFSpan, bool) {
+	id := idFromBytes(span.SpanId)
+	if id == 0 {
+		return nil, false
+	}
+
+	tags := make(map[string]string, len(resourceTags)+len(span.Attributes)+2)
+	for k, v := range resourceTags {
+		tags[k] = v
+	}
+	for _, kv := range span.Attributes {
+		if value, ok := attributeValue(kv.Value); ok {
+			tags[kv.Key] = value
+		}
+	}
+	if span.Kind != tracepb.Span_SPAN_KIND_UNSPECIFIED {
+		kind := strings.TrimPrefix(span.Kind.String(), "SPAN_KIND_")
+		tags[spanKindTag] = strings.ToLower(kind)
+	}
+
+	isError := span.GetStatus().GetCode() == tracepb.Status_STATUS_CODE_ERROR
+	if isError && span.Status.Message != "" {
+		tags[errorMessageTag] = span.Status.Message
+	}
+
+	ret := &ssf.SSFSpan{
+		Id:             id,
+		ParentId:       idFromBytes(span.ParentSpanId),
+		StartTimestamp: int64(span.StartTimeUnixNano),
+		EndTimestamp:   int64(span.EndTimeUnixNano),
+		Error:          isError,
+		Service:        service,
+		Name:           span.Name,
+		Tags:           tags,
+	}
+	ret.SetFullTraceID(traceIDFromBytes(span.TraceId))
+	return ret, true
+}
+
+// udpMetricsFromOTLP converts the data points of an OTLP metric to
+// UDPMetrics, and returns the number of data points it read.
+//
+// Gauges and non-monotonic sums become gauges, and delta monotonic sums
+// become counters. Cumulative monotonic sums also become gauges, since
+// veneur counters are deltas. Each bucket of a delta histogram becomes a
+// weighted histogram sample at the bucket's midpoint. Summaries become
+// percentile, count and sum gauges, named like veneur's own histogram
+// aggregates. Cumulative histograms are rejected.
+func udpMetricsFromOTLP(m *metricspb.Metric, service string) ([]samplers.UDPMetric, int64, error) {
+	if m.Name == "" {
+		return nil, 0, fmt.Errorf("metric name cannot be empty")
+	}
+
+	var ret []samplers.UDPMetric
+	switch data := m.Data.(type) {
+	case *metricspb.Metric_Gauge:
+		for _, dp := range data.Gauge.DataPoints {
+			ret = append(ret, newUDPMetric(m.Name, "gauge", numberValue(dp), 1, dataPointTags(dp.Attributes, service)))
+		}
+		return ret, int64(len(data.Gauge.DataPoints)), nil
+
+	case *metricspb.Metric_Sum:
+		typ := "gauge"
+		if data.Sum.IsMonotonic && data.Sum.AggregationTemporality == metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA {
+			typ = "counter"
+		}
+		for _, dp := range data.Sum.DataPoints {
+			ret = append(ret, newUDPMetric(m.Name, typ, numberValue(dp), 1, dataPointTags(dp.Attributes, service)))
+		}
+		return ret, int64(len(data.Sum.DataPoints)), nil
+
+	case *metricspb.Metric_Histogram:
+		points := int64(len(data.Histogram.DataPoints))
+		if data.Histogram.AggregationTemporality != metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA {
+			return nil, points, fmt.Errorf("histogram %q must use delta temporality", m.Name)
+		}
+		for _, dp := range data.Histogram.DataPoints {
+			tags := dataPointTags(dp.Attributes, service)
+			for i, count := range dp.BucketCounts {
+				if count == 0 {
+					continue
+				}
+				value, ok := bucketValue(dp, i)
+				if !ok {
+					continue
+				}
+				ret = append(ret, newUDPMetric(m.Name, "histogram", value, float32(1/float64(count)), tags))
+			}
+		}
+		return ret, points, nil
+
+	case *metricspb.Metric_Summary:
+		for _, dp := range data.Summary.DataPoints {
+			tags := dataPointTags(dp.Attributes, service)
+			for _, q := range dp.QuantileValues {
+				name := fmt.Sprintf("%s.%spercentile", m.Name, samplers.PercentileName(q.Quantile))
+				ret = append(ret, newUDPMetric(name, "gauge", q.Value, 1, tags))
+			}
+			ret = append(ret,
+				newUDPMetric(m.Name+".count", "gauge", float64(dp.Count), 1, tags),
+				newUDPMetric(m.Name+".sum", "gauge", dp.Sum, 1, tags),
+			)
+		}
+		return ret, int64(len(data.Summary.DataPoints)), nil
+	}
+	return nil, 1, fmt.Errorf("metric %q has an unsupported type", m.Name)
+}
+
+func numberValue(dp *metricspb.NumberDataPoint) float64 {
+	if v, ok := dp.Value.(*metricspb.NumberDataPoint_AsInt); ok {
+		return float64(v.AsInt)
+	}
+	return dp.GetAsDouble()
+}
+
+// bucketValue returns the value that stands in for all the samples in
+// bucket i of a histogram data point: the midpoint of the bucket, or its
+// finite bound for the two outermost buckets.
+func bucketValue(dp *metricspb.HistogramDataPoint, i int) (float64, bool) {
+	bounds := dp.ExplicitBounds
+	switch {
+	case len(bounds) == 0:
+		// a single bucket holding everything
+		if dp.Count == 0 {
+			return 0, false
+		}
+		return dp.Sum / float64(dp.Count), true
+	case i == 0:
+		return bounds[0], true
+	case i >= len(bounds):
+		return bounds[len(bounds)-1], true
+	}
+	return (bounds[i-1] + bounds[i]) / 2, true
+}
+
+// dataPointTags returns the sorted tags of a data point. Besides the
+// data point's own attributes, only the service name of the resource is
+// kept, since resource attributes tend to identify individual processes.
+func dataPointTags(attrs []*commonpb.KeyValue, service string) []string {
+	tags := make([]string, 0, len(attrs)+1)
+	hasService := false
+	for _, kv := range attrs {
+		value, ok := attributeValue(kv.Value)
+		if !ok {
+			continue
+		}
+		if kv.Key == "service" {
+			hasService = true
+		}
+		tags = append(tags, kv.Key+":"+value)
+	}
+	if !hasService && service != unknownService {
+		tags = append(tags, "service:"+service)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// newUDPMetric constructs a UDPMetric with its digest computed the same
+// way as samplers.ParseMetric does.
+func newUDPMetric(name, typ string, value float64, sampleRate float32, tags []string) samplers.UDPMetric {
+	ret := samplers.UDPMetric{
+		MetricKey: samplers.MetricKey{
+			Name:       name,
+			Type:       typ,
+			JoinedTags: strings.Join(tags, ","),
+		},
+		Value:      value,
+		SampleRate: sampleRate,
+		Tags:       tags,
+	}
+	h := fnv1a.Init32
+	h = fnv1a.AddString32(h, ret.Name)
+	h = fnv1a.AddString32(h, ret.Type)
+	h = fnv1a.AddString32(h, ret.JoinedTags)
+	ret.Digest = h
+	return ret
+}
@@ -0,0 +1,223 @@
+package importsrv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/veneur/otlp/collectormetricspb"
+	"github.com/stripe/veneur/otlp/collectortracepb"
+	"github.com/stripe/veneur/otlp/commonpb"
+	"github.com/stripe/veneur/otlp/metricspb"
+	"github.com/stripe/veneur/otlp/resourcepb"
+	"github.com/stripe/veneur/otlp/tracepb"
+	"github.com/stripe/veneur/samplers"
+	"github.com/stripe/veneur/ssf"
+)
+
+type testSpanIngester struct {
+	spans []*ssf.SSFSpan
+}
+
+func (si *testSpanIngester) IngestSpan(span *ssf.SSFSpan) {
+	si.spans = append(si.spans, span)
+}
+
+type testUDPIngester struct {
+	metrics []samplers.UDPMetric
+}
+
+func (ui *testUDPIngester) IngestUDP(m samplers.UDPMetric) {
+	ui.metrics = append(ui.metrics, m)
+}
+
+func stringAttr(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}},
+	}
+}
+
+func testResource() *resourcepb.Resource {
+	return &resourcepb.Resource{Attributes: []*commonpb.KeyValue{
+		stringAttr("service.name", "checkout"),
+		stringAttr("deployment.environment", "prod"),
+	}}
+}
+
+func TestOTLPExportSpans(t *testing.T) {
+	ingester := &testSpanIngester{}
+	s := New([]MetricIngester{}, WithOTLPSpans(ingester))
+
+	req := &collectortracepb.ExportTraceServiceRequest{
+		ResourceSpans: []*tracepb.ResourceSpans{{
+			Resource: testResource(),
+			ScopeSpans: []*tracepb.ScopeSpans{{
+				Spans: []*tracepb.Span{
+					{
+						TraceId:           []byte{0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 2},
+						SpanId:            []byte{0, 0, 0, 0, 0, 0, 0, 3},
+						ParentSpanId:      []byte{0, 0, 0, 0, 0, 0, 0, 4},
+						Name:              "charge",
+						Kind:              tracepb.Span_SPAN_KIND_SERVER,
+						StartTimeUnixNano: 1000,
+						EndTimeUnixNano:   2000,
+						Attributes: []*commonpb.KeyValue{
+							stringAttr("deployment.environment", "canary"),
+							{Key: "retries", Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: 2}}},
+						},
+						Status: &tracepb.Status{Code: tracepb.Status_STATUS_CODE_ERROR, Message: "declined"},
+					},
+					// no span ID:
+					{TraceId: []byte{0, 0, 0, 0, 0, 0, 0, 1}, Name: "broken"},
+				},
+			}},
+		}},
+	}
+	res, err := otlpTraceServer{s}.Export(context.Background(), req)
+	require.NoError(t, err)
+	require.NotNil(t, res.PartialSuccess)
+	assert.Equal(t, int64(1), res.PartialSuccess.RejectedSpans)
+
+	require.Len(t, ingester.spans, 1)
+	assert.Equal(t, &ssf.SSFSpan{
+		TraceId:        2,
+		TraceIdHigh:    1,
+		TraceIdLow:     2,
+		Id:             3,
+		ParentId:       4,
+		StartTimestamp: 1000,
+		EndTimestamp:   2000,
+		Error:          true,
+		Service:        "checkout",
+		Name:           "charge",
+		Tags: map[string]string{
+			"deployment.environment": "canary",
+			"retries":                "2",
+			"span.kind":              "server",
+			"error.msg":              "declined",
+		},
+	}, ingester.spans[0])
+}
+
+func TestOTLPIDFromBytes(t *testing.T) {
+	assert.Equal(t, int64(0), idFromBytes(nil))
+	assert.Equal(t, int64(0x0102030405060708), idFromBytes([]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+	// the sign bit is cleared, since SSF IDs are positive:
+	assert.Equal(t, int64(1), idFromBytes([]byte{0x80, 0, 0, 0, 0, 0, 0, 1}))
+
+	high, low := traceIDFromBytes([]byte{1, 2, 3, 4, 5, 6, 7, 8, 0x80, 0, 0, 0, 0, 0, 0, 1})
+	assert.Equal(t, uint64(0x0102030405060708), high)
+	assert.Equal(t, uint64(0x8000000000000001), low, "trace IDs are kept in full")
+}
+
+func TestOTLPExportMetrics(t *testing.T) {
+	ingesters := []*testUDPIngester{{}, {}}
+	s := New([]MetricIngester{}, WithOTLPMetrics([]UDPMetricIngester{ingesters[0], ingesters[1]}))
+
+	attrs := []*commonpb.KeyValue{stringAttr("region", "us")}
+	number := func(v float64) []*metricspb.NumberDataPoint {
+		return []*metricspb.NumberDataPoint{{
+			Attributes: attrs,
+			Value:      &metricspb.NumberDataPoint_AsDouble{AsDouble: v},
+		}}
+	}
+	req := &collectormetricspb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricspb.ResourceMetrics{{
+			Resource: testResource(),
+			ScopeMetrics: []*metricspb.ScopeMetrics{{
+				Metrics: []*metricspb.Metric{
+					{Name: "queue.depth", Data: &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{DataPoints: number(7)}}},
+					{Name: "requests", Data: &metricspb.Metric_Sum{Sum: &metricspb.Sum{
+						DataPoints:             number(3),
+						IsMonotonic:            true,
+						AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA,
+					}}},
+					{Name: "requests.cumulative", Data: &metricspb.Metric_Sum{Sum: &metricspb.Sum{
+						DataPoints:             number(30),
+						IsMonotonic:            true,
+						AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+					}}},
+					{Name: "latency", Data: &metricspb.Metric_Histogram{Histogram: &metricspb.Histogram{
+						AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA,
+						DataPoints: []*metricspb.HistogramDataPoint{{
+							Attributes:     attrs,
+							Count:          6,
+							BucketCounts:   []uint64{1, 0, 4, 1},
+							ExplicitBounds: []float64{10, 20, 40},
+						}},
+					}}},
+					{Name: "latency.cumulative", Data: &metricspb.Metric_Histogram{Histogram: &metricspb.Histogram{
+						AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+						DataPoints:             []*metricspb.HistogramDataPoint{{Count: 1, BucketCounts: []uint64{1}}},
+					}}},
+					{Name: "size", Data: &metricspb.Metric_Summary{Summary: &metricspb.Summary{
+						DataPoints: []*metricspb.SummaryDataPoint{{
+							Attributes: attrs,
+							Count:      10,
+							Sum:        55,
+							QuantileValues: []*metricspb.SummaryDataPoint_ValueAtQuantile{
+								{Quantile: 0.29, Value: 3},
+								{Quantile: 0.99, Value: 9},
+								{Quantile: 0.999, Value: 10},
+							},
+						}},
+					}}},
+					{Name: "no.data"},
+				},
+			}},
+		}},
+	}
+	res, err := otlpMetricsServer{s}.Export(context.Background(), req)
+	require.NoError(t, err)
+	require.NotNil(t, res.PartialSuccess)
+	assert.Equal(t, int64(2), res.PartialSuccess.RejectedDataPoints)
+	assert.Contains(t, res.PartialSuccess.ErrorMessage, "latency.cumulative")
+
+	received := map[string][]samplers.UDPMetric{}
+	for i, ingester := range ingesters {
+		for _, m := range ingester.metrics {
+			assert.Equal(t, uint32(i), m.Digest%2, "metric %s went to the wrong ingester", m.Name)
+			assert.Equal(t, []string{"region:us", "service:checkout"}, m.Tags)
+			received[m.Name] = append(received[m.Name], m)
+		}
+	}
+
+	require.Len(t, received["queue.depth"], 1)
+	assert.Equal(t, "gauge", received["queue.depth"][0].Type)
+	assert.Equal(t, float64(7), received["queue.depth"][0].Value)
+
+	require.Len(t, received["requests"], 1)
+	assert.Equal(t, "counter", received["requests"][0].Type)
+	require.Len(t, received["requests.cumulative"], 1)
+	assert.Equal(t, "gauge", received["requests.cumulative"][0].Type)
+
+	require.Len(t, received["latency"], 3, "empty buckets should be skipped")
+	values := map[float64]float32{}
+	for _, m := range received["latency"] {
+		assert.Equal(t, "histogram", m.Type)
+		values[m.Value.(float64)] = m.SampleRate
+	}
+	assert.Equal(t, map[float64]float32{10: 1, 30: 0.25, 40: 1}, values)
+
+	require.Len(t, received["size.99percentile"], 1, "the 99.9th percentile shouldn't be named like the 99th")
+	assert.Equal(t, float64(9), received["size.99percentile"][0].Value)
+	require.Len(t, received["size.99_9percentile"], 1)
+	assert.Equal(t, float64(10), received["size.99_9percentile"][0].Value)
+	assert.Len(t, received["size.29percentile"], 1)
+	require.Len(t, received["size.count"], 1)
+	assert.Equal(t, float64(10), received["size.count"][0].Value)
+	assert.Len(t, received["size.sum"], 1)
+}
+
+// Test that OTLP metrics are hashed to the same worker as the equivalent
+// DogStatsD metric, so that they aggregate together.
+func TestOTLPMetricDigest(t *testing.T) {
+	parsed, err := samplers.ParseMetric([]byte("requests:1|c|#region:us,service:checkout"))
+	require.NoError(t, err)
+
+	m := newUDPMetric("requests", "counter", 1, 1, []string{"region:us", "service:checkout"})
+	assert.Equal(t, parsed.MetricKey, m.MetricKey)
+	assert.Equal(t, parsed.Digest, m.Digest)
+}
@@ -3,10 +3,17 @@
 // The Server wraps a grpc.Server, and implements the forwardrpc.Forward
 // service.  It receives batches of metrics, then hashes them to a specific
 // "MetricIngester" and forwards them on.
+//
+// If configured with the WithOTLP* options, the Server also implements the
+// OpenTelemetry (OTLP) trace and metrics services, translating what it
+// receives to SSF spans and DogStatsD-style metrics. WithJaegerSpans
+// likewise enables the Jaeger collector's span service, and WithPeerSpans
+// the SpanSink service that grpsink span sinks send SSF spans to.
 package importsrv
 
 import (
 	"fmt"
+	"io"
 	"net"
 	"time"
 
@@ -15,9 +22,16 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/segmentio/fasthash/fnv1a"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/stripe/veneur/forwardrpc"
+	"github.com/stripe/veneur/jaeger"
+	"github.com/stripe/veneur/jaeger/jaegerpb"
+	"github.com/stripe/veneur/otlp/collectormetricspb"
+	"github.com/stripe/veneur/otlp/collectortracepb"
 	"github.com/stripe/veneur/samplers/metricpb"
+	"github.com/stripe/veneur/sinks/grpsink"
 	"github.com/stripe/veneur/ssf"
 	"github.com/stripe/veneur/trace"
 )
@@ -31,6 +45,19 @@ type MetricIngester interface {
 	IngestMetrics([]*metricpb.Metric)
 }
 
+// BackfillIngester reads metrics that were aggregated in an earlier
+// interval, at the given Unix timestamp.
+type BackfillIngester interface {
+	IngestBackfill(timestamp int64, ms []*metricpb.Metric)
+}
+
+// Handoffer hands the metrics that moved away from it off to their new
+// owner, after a proxy announces a change to the ring it hashes metrics
+// over.
+type Handoffer interface {
+	SetRing(ring *forwardrpc.Ring) error
+}
+
 // Server wraps a gRPC server and implements the forwardrpc.Forward service.
 // It reads a list of metrics, and based on the provided key chooses a
 // MetricIngester to send it to.  A unique metric (name, tags, and type)
@@ -43,6 +70,13 @@ type Server struct {
 
 type options struct {
 	traceClient *trace.Client
+	spanOut     SpanIngester
+	jaegerOut   SpanIngester
+	peerOut     SpanIngester
+	udpOuts     []UDPMetricIngester
+	backfill    BackfillIngester
+	handoff     Handoffer
+	serverOpts  []grpc.ServerOption
 }
 
 // Option is returned by functions that serve as options to New, like
@@ -53,7 +87,6 @@ type Option func(*options)
 // output to.
 func New(metricOuts []MetricIngester, opts ...Option) *Server {
 	res := &Server{
-		Server:     grpc.NewServer(),
 		metricOuts: metricOuts,
 		opts:       &options{},
 	}
@@ -61,16 +94,44 @@ func New(metricOuts []MetricIngester, opts ...Option) *Server {
 	for _, opt := range opts {
 		opt(res.opts)
 	}
+	res.Server = grpc.NewServer(res.opts.serverOpts...)
 
 	if res.opts.traceClient == nil {
 		res.opts.traceClient = trace.DefaultClient
 	}
 
 	forwardrpc.RegisterForwardServer(res.Server, res)
+	if res.opts.handoff != nil {
+		forwardrpc.RegisterHandoffServer(res.Server, handoffServer{res})
+	}
+	if res.opts.spanOut != nil {
+		collectortracepb.RegisterTraceServiceServer(res.Server, otlpTraceServer{res})
+	}
+	if res.opts.jaegerOut != nil {
+		jaegerpb.RegisterCollectorServiceServer(res.Server,
+			jaeger.NewCollector(res.opts.jaegerOut, res.opts.traceClient))
+	}
+	if res.opts.peerOut != nil {
+		grpsink.RegisterSpanSinkServer(res.Server, peerSpanServer{res})
+	}
+	if len(res.opts.udpOuts) > 0 {
+		collectormetricspb.RegisterMetricsServiceServer(res.Server, otlpMetricsServer{res})
+	}
 
 	return res
 }
 
+// peerSpanServer implements the SpanSink service for WithPeerSpans.
+type peerSpanServer struct {
+	*Server
+}
+
+// SendSpan passes a span to the server's peer SpanIngester.
+func (s peerSpanServer) SendSpan(ctx context.Context, span *ssf.SSFSpan) (*grpsink.Empty, error) {
+	s.opts.peerOut.IngestSpan(span)
+	return &grpsink.Empty{}, nil
+}
+
 // Serve starts a gRPC listener on the specified address and blocks while
 // listening for requests. If listening is interrupted by some means other
 // than Stop or GracefulStop being called, it returns a non-nil error.
@@ -104,6 +165,12 @@ func (s *Server) SendMetrics(ctx context.Context, mlist *forwardrpc.MetricList)
 	span.SetTag("protocol", "grpc")
 	defer span.ClientFinish(s.opts.traceClient)
 
+	if mlist.Timestamp != 0 && s.opts.backfill != nil {
+		s.opts.backfill.IngestBackfill(mlist.Timestamp, mlist.Metrics)
+		span.Add(ssf.Count("import.backfill_metrics_total", float32(len(mlist.Metrics)), grpcTags))
+		return &empty.Empty{}, nil
+	}
+
 	dests := make([][]*metricpb.Metric, len(s.metricOuts))
 
 	// group metrics by their destination
@@ -132,6 +199,51 @@ func (s *Server) SendMetrics(ctx context.Context, mlist *forwardrpc.MetricList)
 	return &empty.Empty{}, nil
 }
 
+// SendMetricsStream handles each list of metrics in a stream the way
+// SendMetrics does, once the whole stream was received. A stream that fails
+// partway leaves nothing ingested, so that the client can send all of it
+// again without counting any of it twice.
+func (s *Server) SendMetricsStream(stream forwardrpc.Forward_SendMetricsStreamServer) error {
+	var mlists []*forwardrpc.MetricList
+	for {
+		mlist, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		mlists = append(mlists, mlist)
+	}
+	for _, mlist := range mlists {
+		if _, err := s.SendMetrics(stream.Context(), mlist); err != nil {
+			return err
+		}
+	}
+	return stream.SendAndClose(&empty.Empty{})
+}
+
+// handoffServer implements the forwardrpc.Handoff service on top of a
+// Server.
+type handoffServer struct {
+	*Server
+}
+
+// SetRing passes the ring that a proxy announced on to the server's
+// Handoffer.
+func (s handoffServer) SetRing(ctx context.Context, ring *forwardrpc.Ring) (*empty.Empty, error) {
+	span, _ := trace.StartSpanFromContext(ctx, "veneur.opentracing.importsrv.handle_set_ring")
+	span.SetTag("protocol", "grpc")
+	defer span.ClientFinish(s.opts.traceClient)
+
+	if err := s.opts.handoff.SetRing(ring); err != nil {
+		span.Error(err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	span.Add(ssf.Count("import.ring_changes_total", 1, grpcTags))
+	return &empty.Empty{}, nil
+}
+
 // hashMetric returns a 32-bit hash from the input metric based on its name,
 // type, and tags.
 //
@@ -2,11 +2,14 @@ package importsrv
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"math/rand"
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/stretchr/testify/assert"
 	"github.com/stripe/veneur/forwardrpc"
 	"github.com/stripe/veneur/samplers/metricpb"
@@ -47,7 +50,7 @@ func TestSendMetrics_ConsistentHash(t *testing.T) {
 
 	// Send the same inputs many times
 	for i := 0; i < 10; i++ {
-		s.SendMetrics(context.Background(), &forwardrpc.MetricList{inputs})
+		s.SendMetrics(context.Background(), &forwardrpc.MetricList{Metrics: inputs})
 
 		assert.Equal(t, []*metricpb.Metric{inputs[0], inputs[4]},
 			ingesters[0].metrics, "Ingester 0 has the wrong metrics")
@@ -69,6 +72,49 @@ func TestSendMetrics_Empty(t *testing.T) {
 		"any metrics")
 }
 
+// testStream is a stream of metric lists that ends with err.
+type testStream struct {
+	forwardrpc.Forward_SendMetricsStreamServer
+	lists []*forwardrpc.MetricList
+	err   error
+}
+
+func (s *testStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *testStream) Recv() (*forwardrpc.MetricList, error) {
+	if len(s.lists) == 0 {
+		return nil, s.err
+	}
+	list := s.lists[0]
+	s.lists = s.lists[1:]
+	return list, nil
+}
+
+func (s *testStream) SendAndClose(*empty.Empty) error {
+	return nil
+}
+
+func TestSendMetricsStream(t *testing.T) {
+	ingester := &testMetricIngester{}
+	s := New([]MetricIngester{ingester})
+	lists := func() []*forwardrpc.MetricList {
+		return []*forwardrpc.MetricList{
+			{Metrics: []*metricpb.Metric{{Name: "test.counter.1", Type: metricpb.Type_Counter}}},
+			{Metrics: []*metricpb.Metric{{Name: "test.counter.2", Type: metricpb.Type_Counter}}},
+		}
+	}
+
+	errBroken := errors.New("broken stream")
+	err := s.SendMetricsStream(&testStream{lists: lists(), err: errBroken})
+	assert.Equal(t, errBroken, err)
+	assert.Empty(t, ingester.metrics, "a stream that fails after its first batch shouldn't ingest any of it")
+
+	assert.NoError(t, s.SendMetricsStream(&testStream{lists: lists(), err: io.EOF}))
+	assert.Len(t, ingester.metrics, 2)
+}
+
 func TestOptions_WithTraceClient(t *testing.T) {
 	c, err := trace.NewClient(trace.DefaultVeneurAddress)
 	if err != nil {
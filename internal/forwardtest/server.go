@@ -1,6 +1,7 @@
 package forwardtest
 
 import (
+	"io"
 	"net"
 	"sync"
 	"testing"
@@ -18,12 +19,16 @@ import (
 // SendMetrics RPC
 type SendMetricHandler func([]*metricpb.Metric)
 
+// RingHandler is a handler that is called when a Server gets a SetRing RPC
+type RingHandler func(*forwardrpc.Ring)
+
 // Server is a gRPC server similar to httptest.Server
 type Server struct {
 	*grpc.Server
-	lis      net.Listener
-	handler  SendMetricHandler
-	startMtx sync.Mutex
+	lis         net.Listener
+	handler     SendMetricHandler
+	ringHandler RingHandler
+	startMtx    sync.Mutex
 }
 
 // NewServer creates an unstarted Server with the specified handler
@@ -37,6 +42,16 @@ func NewServer(handler SendMetricHandler) *Server {
 	return res
 }
 
+// NewHandoffServer creates an unstarted Server that also implements the
+// Handoff service, with the specified handlers
+func NewHandoffServer(handler SendMetricHandler, ringHandler RingHandler) *Server {
+	res := NewServer(handler)
+	res.ringHandler = ringHandler
+
+	forwardrpc.RegisterHandoffServer(res.Server, res)
+	return res
+}
+
 // Start starts the gRPC server listening on the loopback interface on a
 // random port.  The address it is listening on can be retrieved from
 // (*Server).Addr()
@@ -72,3 +87,24 @@ func (s *Server) SendMetrics(ctx context.Context, mlist *forwardrpc.MetricList)
 	s.handler(mlist.Metrics)
 	return &empty.Empty{}, nil
 }
+
+// SendMetricsStream calls the input SendMetricsHandler with each list of
+// metrics in a stream
+func (s *Server) SendMetricsStream(stream forwardrpc.Forward_SendMetricsStreamServer) error {
+	for {
+		mlist, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&empty.Empty{})
+		}
+		if err != nil {
+			return err
+		}
+		s.handler(mlist.Metrics)
+	}
+}
+
+// SetRing calls the input RingHandler whenever it receives an RPC
+func (s *Server) SetRing(ctx context.Context, ring *forwardrpc.Ring) (*empty.Empty, error) {
+	s.ringHandler(ring)
+	return &empty.Empty{}, nil
+}
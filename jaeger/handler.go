@@ -0,0 +1,113 @@
+package jaeger
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/stripe/veneur/jaeger/jaegerpb"
+	"github.com/stripe/veneur/ssf"
+	"github.com/stripe/veneur/trace"
+)
+
+// TracesPath is the path that Jaeger clients post batches to.
+const TracesPath = "/api/traces"
+
+// DefaultMaxBodyBytes is the size of the largest batch that a handler
+// accepts if NewHandler's maxBodyBytes is zero.
+const DefaultMaxBodyBytes = 4 << 20
+
+type handler struct {
+	ingester     SpanIngester
+	maxBodyBytes int64
+	traceClient  *trace.Client
+	log          *logrus.Logger
+}
+
+// NewHandler returns an HTTP handler that implements the Jaeger
+// collector's HTTP API: it accepts POSTs of Thrift-encoded batches to
+// TracesPath, and hands each of their spans to the ingester. Batches
+// larger than maxBodyBytes are rejected.
+func NewHandler(ingester SpanIngester, maxBodyBytes int64, cl *trace.Client, log *logrus.Logger) http.Handler {
+	if maxBodyBytes <= 0 {
+		maxBodyBytes = DefaultMaxBodyBytes
+	}
+	mux := http.NewServeMux()
+	mux.Handle(TracesPath, &handler{
+		ingester:     ingester,
+		maxBodyBytes: maxBodyBytes,
+		traceClient:  cl,
+		log:          log,
+	})
+	return mux
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "batches must be POSTed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	span, _ := trace.StartSpanFromContext(r.Context(), "veneur.opentracing.jaeger.handle_batch")
+	span.SetTag("protocol", "jaeger")
+	defer span.ClientFinish(h.traceClient)
+
+	batch, err := ReadThriftBatch(http.MaxBytesReader(w, r.Body, h.maxBodyBytes))
+	if err != nil {
+		span.Error(err)
+		span.Add(ssf.Count("import.request_error_total", 1, jaegerTags))
+		h.log.WithError(err).Warn("Could not decode Jaeger batch")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	total, rejected := ingest(h.ingester, batch)
+	span.Add(
+		ssf.Count("import.spans_total", float32(total), jaegerTags),
+		ssf.Count("import.rejected_total", float32(rejected), jaegerTags),
+	)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// Collector implements the Jaeger collector's gRPC CollectorService.
+type Collector struct {
+	ingester    SpanIngester
+	traceClient *trace.Client
+}
+
+var _ jaegerpb.CollectorServiceServer = &Collector{}
+
+// NewCollector returns a Collector that hands the spans of each batch it
+// receives to the ingester.
+func NewCollector(ingester SpanIngester, cl *trace.Client) *Collector {
+	return &Collector{ingester: ingester, traceClient: cl}
+}
+
+// PostSpans translates the spans of a batch to SSF. Spans without an ID
+// or a start time are dropped.
+func (c *Collector) PostSpans(ctx context.Context, req *jaegerpb.PostSpansRequest) (*jaegerpb.PostSpansResponse, error) {
+	span, _ := trace.StartSpanFromContext(ctx, "veneur.opentracing.jaeger.handle_post_spans")
+	span.SetTag("protocol", "jaeger")
+	defer span.ClientFinish(c.traceClient)
+
+	if req.Batch != nil {
+		total, rejected := ingest(c.ingester, req.Batch)
+		span.Add(
+			ssf.Count("import.spans_total", float32(total), jaegerTags),
+			ssf.Count("import.rejected_total", float32(rejected), jaegerTags),
+		)
+	}
+	return &jaegerpb.PostSpansResponse{}, nil
+}
+
+// ingest hands the spans of a batch to an ingester, and returns how many
+// spans the batch had and how many of them were rejected.
+func ingest(ingester SpanIngester, batch *jaegerpb.Batch) (int, int) {
+	spans, rejected := ToSSF(batch)
+	for _, span := range spans {
+		ingester.IngestSpan(span)
+	}
+	return len(batch.Spans), rejected
+}
@@ -0,0 +1,153 @@
+// Package jaeger converts batches of spans from Jaeger clients to SSF. It
+// receives them on an HTTP handler that implements the Jaeger collector's
+// /api/traces endpoint, which takes batches encoded with Thrift's binary
+// protocol, and on a gRPC server that implements the collector's
+// CollectorService.
+package jaeger
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/stripe/veneur/jaeger/jaegerpb"
+	"github.com/stripe/veneur/ssf"
+)
+
+const (
+	errorTag = "error"
+)
+
+var jaegerTags = map[string]string{"protocol": "jaeger"}
+
+// SpanIngester receives spans that were translated from Jaeger.
+type SpanIngester interface {
+	IngestSpan(*ssf.SSFSpan)
+}
+
+// ToSSF converts the spans of a batch to SSF, and returns the number of
+// spans that it rejected because they have no usable ID or timestamp. SSF
+// span IDs are 63 bits wide, so only the low-order bits of the spans' IDs
+// are kept, as they are for the OTLP spans that veneur receives. 128-bit
+// trace IDs are kept in full.
+//
+// The parent of a span is the first span it references as CHILD_OF, or
+// the first span it references at all. The tags of a span's process are
+// added to its own tags, and it's an error if it has an "error" tag that
+// is true.
+func ToSSF(batch *jaegerpb.Batch) ([]*ssf.SSFSpan, int) {
+	var spans []*ssf.SSFSpan
+	var rejected int
+	for _, span := range batch.Spans {
+		process := batch.Process
+		if span.Process != nil {
+			process = span.Process
+		}
+		converted, ok := spanToSSF(span, process)
+		if !ok {
+			rejected++
+			continue
+		}
+		spans = append(spans, converted)
+	}
+	return spans, rejected
+}
+
+func spanToSSF(span *jaegerpb.Span, process *jaegerpb.Process) (*ssf.SSFSpan, bool) {
+	id := idFromBytes(span.SpanId)
+	traceID := idFromBytes(span.TraceId)
+	if id == 0 || traceID == 0 || span.StartTime == nil {
+		return nil, false
+	}
+
+	var parentID int64
+	for _, ref := range span.References {
+		if ref.RefType == jaegerpb.SpanRefType_CHILD_OF {
+			parentID = idFromBytes(ref.SpanId)
+			break
+		}
+		if parentID == 0 {
+			parentID = idFromBytes(ref.SpanId)
+		}
+	}
+
+	tags := make(map[string]string, len(span.Tags))
+	var service string
+	if process != nil {
+		service = process.ServiceName
+		for _, kv := range process.Tags {
+			tags[kv.Key] = tagValue(kv)
+		}
+	}
+	isError := false
+	for _, kv := range span.Tags {
+		if kv.Key == errorTag {
+			isError = tagValue(kv) == "true"
+			continue
+		}
+		tags[kv.Key] = tagValue(kv)
+	}
+
+	start := span.StartTime.Seconds*1e9 + int64(span.StartTime.Nanos)
+	end := start
+	if span.Duration != nil {
+		end += span.Duration.Seconds*1e9 + int64(span.Duration.Nanos)
+	}
+	ret := &ssf.SSFSpan{
+		Id:             id,
+		ParentId:       parentID,
+		StartTimestamp: start,
+		EndTimestamp:   end,
+		Error:          isError,
+		Service:        service,
+		Name:           span.OperationName,
+		Tags:           tags,
+	}
+	ret.SetFullTraceID(traceIDFromBytes(span.TraceId))
+	return ret, true
+}
+
+// tagValue renders the value of a tag as a string.
+func tagValue(kv *jaegerpb.KeyValue) string {
+	switch kv.VType {
+	case jaegerpb.ValueType_BOOL:
+		return strconv.FormatBool(kv.VBool)
+	case jaegerpb.ValueType_INT64:
+		return strconv.FormatInt(kv.VInt64, 10)
+	case jaegerpb.ValueType_FLOAT64:
+		return strconv.FormatFloat(kv.VFloat64, 'f', -1, 64)
+	case jaegerpb.ValueType_BINARY:
+		return fmt.Sprintf("%x", kv.VBinary)
+	}
+	return kv.VStr
+}
+
+// idFromBytes converts a big-endian Jaeger trace or span ID to an SSF ID,
+// keeping its low-order 63 bits.
+func idFromBytes(id []byte) int64 {
+	if len(id) < 8 {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(id[len(id)-8:]) & math.MaxInt64)
+}
+
+// traceIDFromBytes splits a big-endian 128-bit Jaeger trace ID into its
+// high and low 64 bits.
+func traceIDFromBytes(id []byte) (high, low uint64) {
+	if len(id) < 8 {
+		return 0, 0
+	}
+	low = binary.BigEndian.Uint64(id[len(id)-8:])
+	if len(id) >= 16 {
+		high = binary.BigEndian.Uint64(id[len(id)-16 : len(id)-8])
+	}
+	return high, low
+}
+
+// idToBytes encodes a 64-bit ID as a big-endian Jaeger span ID.
+func idToBytes(id int64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, uint64(id))
+	return buf
+}
@@ -0,0 +1,220 @@
+package jaeger
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/lightstep/lightstep-tracer-go/thrift_0_9_2/lib/go/thrift"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/veneur/jaeger/jaegerpb"
+	"github.com/stripe/veneur/ssf"
+	"github.com/stripe/veneur/trace"
+)
+
+type recordingIngester struct {
+	mtx   sync.Mutex
+	spans []*ssf.SSFSpan
+}
+
+func (ri *recordingIngester) IngestSpan(span *ssf.SSFSpan) {
+	ri.mtx.Lock()
+	defer ri.mtx.Unlock()
+	ri.spans = append(ri.spans, span)
+}
+
+// thriftBatch encodes a batch of a root span and its child, which is an
+// error, the way Jaeger clients do.
+func thriftBatch(t *testing.T) []byte {
+	buf := thrift.NewTMemoryBuffer()
+	p := thrift.NewTBinaryProtocolTransport(buf)
+	check := func(err error) { require.NoError(t, err) }
+
+	writeTag := func(key string, vType int32, write func()) {
+		check(p.WriteStructBegin("Tag"))
+		check(p.WriteFieldBegin("key", thrift.STRING, 1))
+		check(p.WriteString(key))
+		check(p.WriteFieldBegin("vType", thrift.I32, 2))
+		check(p.WriteI32(vType))
+		write()
+		check(p.WriteFieldStop())
+		check(p.WriteStructEnd())
+	}
+	writeI64 := func(id int16, v int64) {
+		check(p.WriteFieldBegin("", thrift.I64, id))
+		check(p.WriteI64(v))
+	}
+
+	check(p.WriteStructBegin("Batch"))
+	check(p.WriteFieldBegin("process", thrift.STRUCT, 1))
+	check(p.WriteStructBegin("Process"))
+	check(p.WriteFieldBegin("serviceName", thrift.STRING, 1))
+	check(p.WriteString("frontend"))
+	check(p.WriteFieldBegin("tags", thrift.LIST, 2))
+	check(p.WriteListBegin(thrift.STRUCT, 1))
+	writeTag("hostname", thriftTagString, func() {
+		check(p.WriteFieldBegin("vStr", thrift.STRING, 3))
+		check(p.WriteString("web-1"))
+	})
+	check(p.WriteFieldStop())
+	check(p.WriteStructEnd())
+
+	check(p.WriteFieldBegin("spans", thrift.LIST, 2))
+	check(p.WriteListBegin(thrift.STRUCT, 2))
+	for _, id := range []int64{1, 2} {
+		check(p.WriteStructBegin("Span"))
+		writeI64(1, 0x1234) // traceIdLow
+		writeI64(2, 0x5678) // traceIdHigh
+		writeI64(3, id)
+		writeI64(4, id-1) // parentSpanId
+		check(p.WriteFieldBegin("operationName", thrift.STRING, 5))
+		check(p.WriteString("request"))
+		check(p.WriteFieldBegin("flags", thrift.I32, 7))
+		check(p.WriteI32(1))
+		writeI64(8, 1000000+id) // startTime
+		writeI64(9, 2000)       // duration
+		if id == 2 {
+			check(p.WriteFieldBegin("tags", thrift.LIST, 10))
+			check(p.WriteListBegin(thrift.STRUCT, 2))
+			writeTag("error", thriftTagBool, func() {
+				check(p.WriteFieldBegin("vBool", thrift.BOOL, 5))
+				check(p.WriteBool(true))
+			})
+			writeTag("http.status_code", thriftTagLong, func() {
+				check(p.WriteFieldBegin("vLong", thrift.I64, 6))
+				check(p.WriteI64(500))
+			})
+		}
+		// an unknown field, which should be skipped
+		check(p.WriteFieldBegin("incomplete", thrift.BOOL, 12))
+		check(p.WriteBool(false))
+		check(p.WriteFieldStop())
+		check(p.WriteStructEnd())
+	}
+	writeI64(3, 7) // seqNo
+	check(p.WriteFieldStop())
+	check(p.WriteStructEnd())
+	return buf.Bytes()
+}
+
+func TestReadThriftBatch(t *testing.T) {
+	batch, err := ReadThriftBatch(bytes.NewReader(thriftBatch(t)))
+	require.NoError(t, err)
+	spans, rejected := ToSSF(batch)
+	assert.Zero(t, rejected)
+	require.Len(t, spans, 2)
+
+	root, child := spans[0], spans[1]
+	assert.Equal(t, int64(0x1234), root.TraceId)
+	assert.Equal(t, uint64(0x5678), root.TraceIdHigh, "the high bits of the trace ID should be kept")
+	assert.Equal(t, uint64(0x1234), root.TraceIdLow)
+	assert.Equal(t, int64(1), root.Id)
+	assert.Zero(t, root.ParentId)
+	assert.Equal(t, int64(1000001000), root.StartTimestamp)
+	assert.Equal(t, int64(1002001000), root.EndTimestamp)
+	assert.Equal(t, "frontend", root.Service)
+	assert.Equal(t, "request", root.Name)
+	assert.False(t, root.Error)
+	assert.Equal(t, map[string]string{"hostname": "web-1"}, root.Tags)
+
+	assert.Equal(t, int64(0x1234), child.TraceId)
+	assert.Equal(t, int64(1), child.ParentId)
+	assert.True(t, child.Error)
+	assert.Equal(t, map[string]string{"hostname": "web-1", "http.status_code": "500"}, child.Tags)
+
+	_, err = ReadThriftBatch(bytes.NewReader([]byte{thrift.STRUCT, 0}))
+	assert.Error(t, err, "a truncated batch should fail to decode")
+}
+
+func TestReadThriftBatchLengths(t *testing.T) {
+	for name, body := range map[string][]byte{
+		// a string field that claims to be almost 2GiB long
+		"string": {thrift.STRING, 0, 1, 0x7f, 0xff, 0xff, 0xf0},
+		"list":   {thrift.LIST, 0, 2, thrift.STRUCT, 0x7f, 0xff, 0xff, 0xf0},
+		"map":    {thrift.MAP, 0, 9, thrift.STRING, thrift.STRING, 0x7f, 0xff, 0xff, 0xf0},
+		// the process's service name
+		"nested": {thrift.STRUCT, 0, 1, thrift.STRING, 0, 1, 0x7f, 0xff, 0xff, 0xf0},
+	} {
+		_, err := ReadThriftBatch(bytes.NewReader(body))
+		assert.Equal(t, errLengthTooLong, err, name)
+	}
+}
+
+func TestToSSFReferences(t *testing.T) {
+	batch := &jaegerpb.Batch{
+		Process: &jaegerpb.Process{ServiceName: "batch"},
+		Spans: []*jaegerpb.Span{{
+			TraceId:   traceIDToBytes(0, 1),
+			SpanId:    idToBytes(3),
+			StartTime: &jaegerpb.Timestamp{Seconds: 1},
+			References: []*jaegerpb.SpanRef{
+				{SpanId: idToBytes(1), RefType: jaegerpb.SpanRefType_FOLLOWS_FROM},
+				{SpanId: idToBytes(2), RefType: jaegerpb.SpanRefType_CHILD_OF},
+			},
+			Process: &jaegerpb.Process{ServiceName: "span"},
+		}, {
+			TraceId:    traceIDToBytes(0, 1),
+			SpanId:     idToBytes(4),
+			StartTime:  &jaegerpb.Timestamp{Seconds: 1},
+			References: []*jaegerpb.SpanRef{{SpanId: idToBytes(1), RefType: jaegerpb.SpanRefType_FOLLOWS_FROM}},
+		}, {
+			TraceId: traceIDToBytes(0, 1),
+			SpanId:  idToBytes(5),
+		}},
+	}
+
+	spans, rejected := ToSSF(batch)
+	assert.Equal(t, 1, rejected, "the span without a start time should be rejected")
+	require.Len(t, spans, 2)
+	assert.Equal(t, int64(2), spans[0].ParentId, "CHILD_OF references come first")
+	assert.Equal(t, "span", spans[0].Service)
+	assert.Equal(t, int64(1), spans[1].ParentId)
+	assert.Equal(t, "batch", spans[1].Service)
+}
+
+func TestHandler(t *testing.T) {
+	ingester := &recordingIngester{}
+	srv := httptest.NewServer(NewHandler(ingester, 1024, trace.DefaultClient, logrus.New()))
+	defer srv.Close()
+
+	res, err := http.Post(srv.URL+TracesPath, "application/x-thrift", bytes.NewReader(thriftBatch(t)))
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusAccepted, res.StatusCode)
+	assert.Len(t, ingester.spans, 2)
+
+	res, err = http.Post(srv.URL+TracesPath, "application/x-thrift", bytes.NewReader([]byte{1, 2, 3}))
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	// a batch whose process's service name claims to be 2GiB long
+	res, err = http.Post(srv.URL+TracesPath, "application/x-thrift",
+		bytes.NewReader([]byte{thrift.STRUCT, 0, 1, thrift.STRING, 0, 1, 0x7f, 0xff, 0xff, 0xf0}))
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	res, err = http.Post(srv.URL+TracesPath, "application/x-thrift", bytes.NewReader(make([]byte, 1025)))
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode, "batches larger than the limit should be rejected")
+	assert.Len(t, ingester.spans, 2)
+}
+
+func TestCollector(t *testing.T) {
+	ingester := &recordingIngester{}
+	batch, err := ReadThriftBatch(bytes.NewReader(thriftBatch(t)))
+	require.NoError(t, err)
+
+	_, err = NewCollector(ingester, trace.DefaultClient).PostSpans(context.Background(),
+		&jaegerpb.PostSpansRequest{Batch: batch})
+	require.NoError(t, err)
+	assert.Len(t, ingester.spans, 2)
+}
@@ -0,0 +1,531 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: jaeger/jaegerpb/collector.proto
+
+package jaegerpb
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
+type PostSpansRequest struct {
+	Batch *Batch `protobuf:"bytes,1,opt,name=batch,proto3" json:"batch,omitempty"`
+}
+
+func (m *PostSpansRequest) Reset()         { *m = PostSpansRequest{} }
+func (m *PostSpansRequest) String() string { return proto.CompactTextString(m) }
+func (*PostSpansRequest) ProtoMessage()    {}
+func (*PostSpansRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b02930fc6499eaa4, []int{0}
+}
+func (m *PostSpansRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PostSpansRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PostSpansRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PostSpansRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PostSpansRequest.Merge(m, src)
+}
+func (m *PostSpansRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *PostSpansRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PostSpansRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PostSpansRequest proto.InternalMessageInfo
+
+func (m *PostSpansRequest) GetBatch() *Batch {
+	if m != nil {
+		return m.Batch
+	}
+	return nil
+}
+
+type PostSpansResponse struct {
+}
+
+func (m *PostSpansResponse) Reset()         { *m = PostSpansResponse{} }
+func (m *PostSpansResponse) String() string { return proto.CompactTextString(m) }
+func (*PostSpansResponse) ProtoMessage()    {}
+func (*PostSpansResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b02930fc6499eaa4, []int{1}
+}
+func (m *PostSpansResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PostSpansResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PostSpansResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PostSpansResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PostSpansResponse.Merge(m, src)
+}
+func (m *PostSpansResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *PostSpansResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PostSpansResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PostSpansResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*PostSpansRequest)(nil), "jaeger.api_v2.PostSpansRequest")
+	proto.RegisterType((*PostSpansResponse)(nil), "jaeger.api_v2.PostSpansResponse")
+}
+
+func init() { proto.RegisterFile("jaeger/jaegerpb/collector.proto", fileDescriptor_b02930fc6499eaa4) }
+
+var fileDescriptor_b02930fc6499eaa4 = []byte{
+	// 229 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0xcf, 0x4a, 0x4c, 0x4d,
+	0x4f, 0x2d, 0xd2, 0x87, 0x50, 0x05, 0x49, 0xfa, 0xc9, 0xf9, 0x39, 0x39, 0xa9, 0xc9, 0x25, 0xf9,
+	0x45, 0x7a, 0x05, 0x45, 0xf9, 0x25, 0xf9, 0x42, 0xbc, 0x10, 0x19, 0xbd, 0xc4, 0x82, 0xcc, 0xf8,
+	0x32, 0x23, 0x29, 0x69, 0x74, 0xf5, 0xb9, 0xf9, 0x29, 0xa9, 0x39, 0x10, 0xb5, 0x4a, 0x76, 0x5c,
+	0x02, 0x01, 0xf9, 0xc5, 0x25, 0xc1, 0x05, 0x89, 0x79, 0xc5, 0x41, 0xa9, 0x85, 0xa5, 0xa9, 0xc5,
+	0x25, 0x42, 0x5a, 0x5c, 0xac, 0x49, 0x89, 0x25, 0xc9, 0x19, 0x12, 0x8c, 0x0a, 0x8c, 0x1a, 0xdc,
+	0x46, 0x22, 0x7a, 0x28, 0xe6, 0xe9, 0x39, 0x81, 0xe4, 0x82, 0x20, 0x4a, 0x94, 0x84, 0xb9, 0x04,
+	0x91, 0xf4, 0x17, 0x17, 0xe4, 0xe7, 0x15, 0xa7, 0x1a, 0xa5, 0x70, 0x09, 0x38, 0xc3, 0xdc, 0x14,
+	0x9c, 0x5a, 0x54, 0x96, 0x99, 0x9c, 0x2a, 0x14, 0xc0, 0xc5, 0x09, 0x57, 0x28, 0x24, 0x8f, 0x66,
+	0x24, 0xba, 0x13, 0xa4, 0x14, 0x70, 0x2b, 0x80, 0xd8, 0xa1, 0xc4, 0xe0, 0xe4, 0x74, 0xe2, 0x91,
+	0x1c, 0xe3, 0x85, 0x47, 0x72, 0x8c, 0x0f, 0x1e, 0xc9, 0x31, 0x4e, 0x78, 0x2c, 0xc7, 0x70, 0xe1,
+	0xb1, 0x1c, 0xc3, 0x8d, 0xc7, 0x72, 0x0c, 0x51, 0x1a, 0xe9, 0x99, 0x25, 0x19, 0xa5, 0x49, 0x7a,
+	0xc9, 0xf9, 0xb9, 0xfa, 0xc5, 0x25, 0x45, 0x99, 0x05, 0xa9, 0xfa, 0x65, 0xa9, 0x79, 0xa9, 0xa5,
+	0xb0, 0x30, 0x80, 0x07, 0x45, 0x12, 0x1b, 0x38, 0x14, 0x8c, 0x01, 0x03, 0x00, 0x00, 0xef, 0x56,
+	0x2f, 0x54, 0x01, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// CollectorServiceClient is the client API for CollectorService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type CollectorServiceClient interface {
+	PostSpans(ctx context.Context, in *PostSpansRequest, opts ...grpc.CallOption) (*PostSpansResponse, error)
+}
+
+type collectorServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewCollectorServiceClient(cc *grpc.ClientConn) CollectorServiceClient {
+	return &collectorServiceClient{cc}
+}
+
+func (c *collectorServiceClient) PostSpans(ctx context.Context, in *PostSpansRequest, opts ...grpc.CallOption) (*PostSpansResponse, error) {
+	out := new(PostSpansResponse)
+	err := c.cc.Invoke(ctx, "/jaeger.api_v2.CollectorService/PostSpans", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CollectorServiceServer is the server API for CollectorService service.
+type CollectorServiceServer interface {
+	PostSpans(context.Context, *PostSpansRequest) (*PostSpansResponse, error)
+}
+
+// UnimplementedCollectorServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedCollectorServiceServer struct {
+}
+
+func (*UnimplementedCollectorServiceServer) PostSpans(ctx context.Context, req *PostSpansRequest) (*PostSpansResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostSpans not implemented")
+}
+
+func RegisterCollectorServiceServer(s *grpc.Server, srv CollectorServiceServer) {
+	s.RegisterService(&_CollectorService_serviceDesc, srv)
+}
+
+func _CollectorService_PostSpans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostSpansRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectorServiceServer).PostSpans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/jaeger.api_v2.CollectorService/PostSpans",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectorServiceServer).PostSpans(ctx, req.(*PostSpansRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _CollectorService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "jaeger.api_v2.CollectorService",
+	HandlerType: (*CollectorServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PostSpans",
+			Handler:    _CollectorService_PostSpans_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "jaeger/jaegerpb/collector.proto",
+}
+
+func (m *PostSpansRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PostSpansRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PostSpansRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Batch != nil {
+		{
+			size, err := m.Batch.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCollector(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PostSpansResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PostSpansResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PostSpansResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintCollector(dAtA []byte, offset int, v uint64) int {
+	offset -= sovCollector(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *PostSpansRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Batch != nil {
+		l = m.Batch.Size()
+		n += 1 + l + sovCollector(uint64(l))
+	}
+	return n
+}
+
+func (m *PostSpansResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func sovCollector(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozCollector(x uint64) (n int) {
+	return sovCollector(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *PostSpansRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCollector
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PostSpansRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PostSpansRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Batch", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCollector
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCollector
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCollector
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Batch == nil {
+				m.Batch = &Batch{}
+			}
+			if err := m.Batch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCollector(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCollector
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PostSpansResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCollector
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PostSpansResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PostSpansResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCollector(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCollector
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipCollector(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowCollector
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowCollector
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowCollector
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthCollector
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupCollector
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthCollector
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthCollector        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowCollector          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupCollector = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,333 @@
+package jaeger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/ioutil"
+
+	"github.com/lightstep/lightstep-tracer-go/thrift_0_9_2/lib/go/thrift"
+
+	"github.com/stripe/veneur/jaeger/jaegerpb"
+)
+
+// The types of tag values in jaeger.thrift, which are numbered
+// differently from jaegerpb.ValueType.
+const (
+	thriftTagString = iota
+	thriftTagDouble
+	thriftTagBool
+	thriftTagLong
+	thriftTagBinary
+)
+
+// maxSkipDepth is how deeply nested the structs, lists, sets and maps
+// that a batch has in fields that veneur skips may be.
+const maxSkipDepth = 64
+
+var errLengthTooLong = errors.New("thrift: length is longer than the rest of the batch")
+
+// boundedProtocol reads Thrift's binary protocol from a buffer, and rejects
+// the lengths of strings, lists, sets and maps that are longer than the
+// rest of the buffer before it allocates anything for them: since each
+// byte or element takes at least a byte, only a corrupt or hostile batch
+// can have them.
+type boundedProtocol struct {
+	*thrift.TBinaryProtocol
+	buf *bytes.Buffer
+}
+
+func newBoundedProtocol(body []byte) *boundedProtocol {
+	buf := bytes.NewBuffer(body)
+	return &boundedProtocol{
+		TBinaryProtocol: thrift.NewTBinaryProtocolTransport(&thrift.TMemoryBuffer{Buffer: buf}),
+		buf:             buf,
+	}
+}
+
+func (p *boundedProtocol) check(size int) error {
+	if size > p.buf.Len() {
+		return errLengthTooLong
+	}
+	return nil
+}
+
+func (p *boundedProtocol) ReadBinary() ([]byte, error) {
+	size, err := p.ReadI32()
+	if err != nil {
+		return nil, err
+	}
+	if size < 0 {
+		return nil, errors.New("thrift: negative length")
+	}
+	if err := p.check(int(size)); err != nil {
+		return nil, err
+	}
+	return p.buf.Next(int(size)), nil
+}
+
+func (p *boundedProtocol) ReadString() (string, error) {
+	value, err := p.ReadBinary()
+	return string(value), err
+}
+
+func (p *boundedProtocol) ReadListBegin() (thrift.TType, int, error) {
+	typ, size, err := p.TBinaryProtocol.ReadListBegin()
+	if err == nil {
+		err = p.check(size)
+	}
+	return typ, size, err
+}
+
+func (p *boundedProtocol) ReadSetBegin() (thrift.TType, int, error) {
+	typ, size, err := p.TBinaryProtocol.ReadSetBegin()
+	if err == nil {
+		err = p.check(size)
+	}
+	return typ, size, err
+}
+
+func (p *boundedProtocol) ReadMapBegin() (thrift.TType, thrift.TType, int, error) {
+	kType, vType, size, err := p.TBinaryProtocol.ReadMapBegin()
+	if err == nil {
+		err = p.check(size)
+	}
+	return kType, vType, size, err
+}
+
+// Skip skips a value with the bounded methods above, rather than the
+// embedded protocol's.
+func (p *boundedProtocol) Skip(typ thrift.TType) error {
+	return thrift.Skip(p, typ, maxSkipDepth)
+}
+
+// ReadThriftBatch decodes a Batch from jaeger.thrift, encoded with
+// Thrift's binary protocol, into its jaegerpb equivalent. Fields that
+// veneur has no use for are skipped. r is read to its end first, so it
+// should be limited to the largest batch the caller accepts.
+func ReadThriftBatch(r io.Reader) (*jaegerpb.Batch, error) {
+	body, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var p thrift.TProtocol = newBoundedProtocol(body)
+	batch := &jaegerpb.Batch{}
+	err = readStruct(p, func(id int16, typ thrift.TType) error {
+		switch {
+		case id == 1 && typ == thrift.STRUCT:
+			process, err := readProcess(p)
+			batch.Process = process
+			return err
+		case id == 2 && typ == thrift.LIST:
+			return readList(p, thrift.STRUCT, func() error {
+				span, err := readSpan(p)
+				batch.Spans = append(batch.Spans, span)
+				return err
+			})
+		}
+		return p.Skip(typ)
+	})
+	return batch, err
+}
+
+func readProcess(p thrift.TProtocol) (*jaegerpb.Process, error) {
+	process := &jaegerpb.Process{}
+	err := readStruct(p, func(id int16, typ thrift.TType) error {
+		var err error
+		switch {
+		case id == 1 && typ == thrift.STRING:
+			process.ServiceName, err = p.ReadString()
+		case id == 2 && typ == thrift.LIST:
+			process.Tags, err = readTags(p)
+		default:
+			err = p.Skip(typ)
+		}
+		return err
+	})
+	return process, err
+}
+
+func readSpan(p thrift.TProtocol) (*jaegerpb.Span, error) {
+	var traceIDLow, traceIDHigh, spanID, parentID, start, duration int64
+	span := &jaegerpb.Span{}
+	err := readStruct(p, func(id int16, typ thrift.TType) error {
+		var err error
+		switch {
+		case id == 1 && typ == thrift.I64:
+			traceIDLow, err = p.ReadI64()
+		case id == 2 && typ == thrift.I64:
+			traceIDHigh, err = p.ReadI64()
+		case id == 3 && typ == thrift.I64:
+			spanID, err = p.ReadI64()
+		case id == 4 && typ == thrift.I64:
+			parentID, err = p.ReadI64()
+		case id == 5 && typ == thrift.STRING:
+			span.OperationName, err = p.ReadString()
+		case id == 6 && typ == thrift.LIST:
+			err = readList(p, thrift.STRUCT, func() error {
+				ref, err := readSpanRef(p)
+				span.References = append(span.References, ref)
+				return err
+			})
+		case id == 7 && typ == thrift.I32:
+			var flags int32
+			flags, err = p.ReadI32()
+			span.Flags = uint32(flags)
+		case id == 8 && typ == thrift.I64:
+			start, err = p.ReadI64()
+		case id == 9 && typ == thrift.I64:
+			duration, err = p.ReadI64()
+		case id == 10 && typ == thrift.LIST:
+			span.Tags, err = readTags(p)
+		default:
+			err = p.Skip(typ)
+		}
+		return err
+	})
+
+	span.TraceId = traceIDToBytes(traceIDHigh, traceIDLow)
+	span.SpanId = idToBytes(spanID)
+	if parentID != 0 {
+		// the parent is implicitly a CHILD_OF reference in Jaeger's
+		// own model
+		span.References = append([]*jaegerpb.SpanRef{{
+			TraceId: span.TraceId,
+			SpanId:  idToBytes(parentID),
+			RefType: jaegerpb.SpanRefType_CHILD_OF,
+		}}, span.References...)
+	}
+	// start and duration are in microseconds
+	span.StartTime = &jaegerpb.Timestamp{Seconds: start / 1e6, Nanos: int32(start%1e6) * 1e3}
+	span.Duration = &jaegerpb.Duration{Seconds: duration / 1e6, Nanos: int32(duration%1e6) * 1e3}
+	return span, err
+}
+
+func readSpanRef(p thrift.TProtocol) (*jaegerpb.SpanRef, error) {
+	var traceIDLow, traceIDHigh, spanID int64
+	ref := &jaegerpb.SpanRef{}
+	err := readStruct(p, func(id int16, typ thrift.TType) error {
+		var err error
+		switch {
+		case id == 1 && typ == thrift.I32:
+			var refType int32
+			refType, err = p.ReadI32()
+			ref.RefType = jaegerpb.SpanRefType(refType)
+		case id == 2 && typ == thrift.I64:
+			traceIDLow, err = p.ReadI64()
+		case id == 3 && typ == thrift.I64:
+			traceIDHigh, err = p.ReadI64()
+		case id == 4 && typ == thrift.I64:
+			spanID, err = p.ReadI64()
+		default:
+			err = p.Skip(typ)
+		}
+		return err
+	})
+	ref.TraceId = traceIDToBytes(traceIDHigh, traceIDLow)
+	ref.SpanId = idToBytes(spanID)
+	return ref, err
+}
+
+func readTags(p thrift.TProtocol) ([]*jaegerpb.KeyValue, error) {
+	var tags []*jaegerpb.KeyValue
+	err := readList(p, thrift.STRUCT, func() error {
+		kv, err := readTag(p)
+		tags = append(tags, kv)
+		return err
+	})
+	return tags, err
+}
+
+func readTag(p thrift.TProtocol) (*jaegerpb.KeyValue, error) {
+	kv := &jaegerpb.KeyValue{}
+	err := readStruct(p, func(id int16, typ thrift.TType) error {
+		var err error
+		switch {
+		case id == 1 && typ == thrift.STRING:
+			kv.Key, err = p.ReadString()
+		case id == 2 && typ == thrift.I32:
+			var vType int32
+			vType, err = p.ReadI32()
+			switch vType {
+			case thriftTagDouble:
+				kv.VType = jaegerpb.ValueType_FLOAT64
+			case thriftTagBool:
+				kv.VType = jaegerpb.ValueType_BOOL
+			case thriftTagLong:
+				kv.VType = jaegerpb.ValueType_INT64
+			case thriftTagBinary:
+				kv.VType = jaegerpb.ValueType_BINARY
+			default:
+				kv.VType = jaegerpb.ValueType_STRING
+			}
+		case id == 3 && typ == thrift.STRING:
+			kv.VStr, err = p.ReadString()
+		case id == 4 && typ == thrift.DOUBLE:
+			kv.VFloat64, err = p.ReadDouble()
+		case id == 5 && typ == thrift.BOOL:
+			kv.VBool, err = p.ReadBool()
+		case id == 6 && typ == thrift.I64:
+			kv.VInt64, err = p.ReadI64()
+		case id == 7 && typ == thrift.STRING:
+			kv.VBinary, err = p.ReadBinary()
+		default:
+			err = p.Skip(typ)
+		}
+		return err
+	})
+	return kv, err
+}
+
+// readStruct reads a struct, calling field to read each of its fields.
+// field must skip the fields that it doesn't read.
+func readStruct(p thrift.TProtocol, field func(id int16, typ thrift.TType) error) error {
+	if _, err := p.ReadStructBegin(); err != nil {
+		return err
+	}
+	for {
+		_, typ, id, err := p.ReadFieldBegin()
+		if err != nil {
+			return err
+		}
+		if typ == thrift.STOP {
+			break
+		}
+		if err := field(id, typ); err != nil {
+			return err
+		}
+		if err := p.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	return p.ReadStructEnd()
+}
+
+// readList reads a list, calling elem to read each of its elements. The
+// elements of a list that aren't of type want are skipped.
+func readList(p thrift.TProtocol, want thrift.TType, elem func() error) error {
+	typ, size, err := p.ReadListBegin()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < size; i++ {
+		if typ == want {
+			err = elem()
+		} else {
+			err = p.Skip(typ)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return p.ReadListEnd()
+}
+
+// traceIDToBytes encodes the halves of a 128-bit trace ID as a big-endian
+// Jaeger trace ID.
+func traceIDToBytes(high, low int64) []byte {
+	buf := make([]byte, 16)
+	binary.BigEndian.PutUint64(buf, uint64(high))
+	binary.BigEndian.PutUint64(buf[8:], uint64(low))
+	return buf
+}
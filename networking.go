@@ -4,6 +4,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"sync"
 
@@ -204,6 +205,41 @@ func startSSFUDP(s *Server, addr *net.UDPAddr, tracePool *sync.Pool) net.Addr {
 	return startProcessingOnUDP(s, "ssf", addr, tracePool, s.ReadSSFPacketSocket)
 }
 
+// startSpanHTTP serves h, which receives spans in another tracing
+// protocol over HTTP, on a TCP address until the server shuts down. It
+// returns the concrete address that the server is listening on.
+func startSpanHTTP(s *Server, protocol string, addr string, h http.Handler) net.Addr {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		panic(fmt.Sprintf("couldn't listen for %s spans on %v: %v", protocol, addr, err))
+	}
+
+	go func() {
+		<-s.shutdown
+		if err := listener.Close(); err != nil {
+			log.WithError(err).Warn("Ignoring error closing HTTP span listener")
+		}
+	}()
+
+	log.WithFields(logrus.Fields{
+		"address":  listener.Addr(),
+		"protocol": protocol,
+	}).Info("Listening for spans over HTTP")
+
+	go func() {
+		defer func() {
+			ConsumePanic(s.Sentry, s.TraceClient, s.Hostname, recover())
+		}()
+		err := http.Serve(listener, h)
+		select {
+		case <-s.shutdown:
+		default:
+			log.WithError(err).WithField("protocol", protocol).Error("HTTP span listener stopped")
+		}
+	}()
+	return listener.Addr()
+}
+
 // startSSFUnix starts listening for connections that send framed SSF
 // spans on a UNIX domain socket address. It does so until the
 // server's shutdown socket is closed. startSSFUnix returns a channel
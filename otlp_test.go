@@ -0,0 +1,104 @@
+package veneur
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/stripe/veneur/otlp/collectormetricspb"
+	"github.com/stripe/veneur/otlp/collectortracepb"
+	"github.com/stripe/veneur/otlp/commonpb"
+	"github.com/stripe/veneur/otlp/metricspb"
+	"github.com/stripe/veneur/otlp/resourcepb"
+	"github.com/stripe/veneur/otlp/tracepb"
+	"github.com/stripe/veneur/samplers"
+)
+
+// TestOTLPGRPCIngestion exports a span and a metric to a Server's gRPC
+// address with the OTLP services, and checks that they come out of its
+// span sinks and its metric flush.
+func TestOTLPGRPCIngestion(t *testing.T) {
+	ch := make(chan []samplers.InterMetric, 1)
+	metricSink, _ := NewChannelMetricSink(ch)
+	spanSink := &fakeSpanSink{wg: &sync.WaitGroup{}}
+
+	config := localConfig()
+	config.GrpcAddress = unusedLocalTCPAddress(t)
+	s := setupVeneurServer(t, config, nil, metricSink, spanSink, nil)
+	defer s.Shutdown()
+	go s.Serve()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, config.GrpcAddress, grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	resource := &resourcepb.Resource{Attributes: []*commonpb.KeyValue{{
+		Key:   "service.name",
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: "checkout"}},
+	}}}
+
+	spanSink.wg.Add(1)
+	_, err = collectortracepb.NewTraceServiceClient(conn).Export(ctx, &collectortracepb.ExportTraceServiceRequest{
+		ResourceSpans: []*tracepb.ResourceSpans{{
+			Resource: resource,
+			ScopeSpans: []*tracepb.ScopeSpans{{
+				Spans: []*tracepb.Span{{
+					TraceId:           []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+					SpanId:            []byte{0, 0, 0, 0, 0, 0, 0, 1},
+					Name:              "charge",
+					StartTimeUnixNano: uint64(time.Now().Add(-time.Second).UnixNano()),
+					EndTimeUnixNano:   uint64(time.Now().UnixNano()),
+				}},
+			}},
+		}},
+	})
+	require.NoError(t, err)
+	spanSink.wg.Wait()
+	assert.Equal(t, "checkout", spanSink.latestSpan().Service)
+	assert.Equal(t, "charge", spanSink.latestSpan().Name)
+
+	_, err = collectormetricspb.NewMetricsServiceClient(conn).Export(ctx, &collectormetricspb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricspb.ResourceMetrics{{
+			Resource: resource,
+			ScopeMetrics: []*metricspb.ScopeMetrics{{
+				Metrics: []*metricspb.Metric{{
+					Name: "otlp.requests",
+					Data: &metricspb.Metric_Sum{Sum: &metricspb.Sum{
+						IsMonotonic:            true,
+						AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA,
+						DataPoints: []*metricspb.NumberDataPoint{{
+							Value: &metricspb.NumberDataPoint_AsInt{AsInt: 3},
+						}},
+					}},
+				}},
+			}},
+		}},
+	})
+	require.NoError(t, err)
+
+	// the worker processes metrics asynchronously; flush until the
+	// counter shows up.
+	for {
+		s.Flush(ctx)
+		select {
+		case metrics := <-ch:
+			for _, m := range metrics {
+				if m.Name == "otlp.requests" {
+					assert.Equal(t, float64(3), m.Value)
+					assert.Equal(t, samplers.CounterMetric, m.Type)
+					assert.Contains(t, m.Tags, "service:checkout")
+					return
+				}
+			}
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for the OTLP counter to be flushed")
+		}
+	}
+}
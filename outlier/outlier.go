@@ -0,0 +1,283 @@
+// Package outlier implements passive outlier detection for the destinations
+// of veneur-proxy.
+//
+// A Detector watches the outcome and latency of every request to a
+// destination. Destinations that fail too often, or respond too slowly, are
+// ejected: a Router skips them and sends their keys to the next-best
+// destination instead. After a cooldown, an ejected destination receives
+// requests again, and the first of them decides whether it's back, or
+// ejected for another cooldown.
+package outlier
+
+import (
+	"sync"
+	"time"
+
+	"github.com/stripe/veneur/hashring"
+)
+
+// The defaults for the Options that are zero.
+const (
+	DefaultConsecutiveFailures = 5
+	DefaultErrorRate           = 0.5
+	DefaultMinRequests         = 20
+	DefaultCooldown            = 30 * time.Second
+	DefaultMaxEjectedRatio     = 0.5
+)
+
+// decay is the weight of each request in the moving averages of the error
+// rate and latency of a destination.
+const decay = 0.1
+
+// Options configures when a Detector ejects destinations.
+type Options struct {
+	// ConsecutiveFailures is the number of failed requests in a row after
+	// which a destination is ejected.
+	ConsecutiveFailures int
+	// ErrorRate is the fraction of failed requests, as a moving average,
+	// above which a destination is ejected.
+	ErrorRate float64
+	// MinRequests is the number of requests to a destination before its
+	// error rate and latency are taken into account.
+	MinRequests int
+	// Latency is the latency, as a moving average, above which a
+	// destination is ejected. Latencies are ignored if it's zero.
+	Latency time.Duration
+	// Cooldown is how long destinations stay ejected.
+	Cooldown time.Duration
+	// MaxEjectedRatio is the largest fraction of the destinations that can
+	// be ejected at once. Ejecting every destination because they all
+	// fail wouldn't help.
+	MaxEjectedRatio float64
+}
+
+// A Detector tracks the health of destinations. Its methods can be called
+// on a nil Detector, which never ejects anything. Detectors are safe for
+// concurrent use.
+type Detector struct {
+	opts Options
+	now  func() time.Time
+
+	mtx   sync.Mutex
+	hosts map[string]*host
+	// members is the number of destinations in the ring of the last Router
+	members int
+}
+
+type host struct {
+	requests            int
+	consecutiveFailures int
+	errorRate           float64
+	latency             float64
+	// ejectedUntil is zero unless the destination is ejected
+	ejectedUntil time.Time
+}
+
+// New creates a Detector, using the defaults for any zero options.
+func New(opts Options) *Detector {
+	if opts.ConsecutiveFailures == 0 {
+		opts.ConsecutiveFailures = DefaultConsecutiveFailures
+	}
+	if opts.ErrorRate == 0 {
+		opts.ErrorRate = DefaultErrorRate
+	}
+	if opts.MinRequests == 0 {
+		opts.MinRequests = DefaultMinRequests
+	}
+	if opts.Cooldown == 0 {
+		opts.Cooldown = DefaultCooldown
+	}
+	if opts.MaxEjectedRatio == 0 {
+		opts.MaxEjectedRatio = DefaultMaxEjectedRatio
+	}
+	return &Detector{
+		opts:  opts,
+		now:   time.Now,
+		hosts: make(map[string]*host),
+	}
+}
+
+// Observe records the outcome of a request to dest, which failed if err is
+// not nil, and returns whether it got dest ejected.
+func (d *Detector) Observe(dest string, latency time.Duration, err error) bool {
+	if d == nil {
+		return false
+	}
+	now := d.now()
+
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	h, ok := d.hosts[dest]
+	if !ok {
+		h = &host{}
+		d.hosts[dest] = h
+	}
+
+	if !h.ejectedUntil.IsZero() {
+		if now.Before(h.ejectedUntil) {
+			// the request was sent before dest was ejected
+			return false
+		}
+		// dest was probed after its cooldown
+		if err == nil {
+			*h = host{}
+			return false
+		}
+		h.ejectedUntil = now.Add(d.opts.Cooldown)
+		return true
+	}
+
+	failed := 0.0
+	if err != nil {
+		failed = 1
+		h.consecutiveFailures++
+	} else {
+		h.consecutiveFailures = 0
+	}
+	if h.requests == 0 {
+		h.errorRate = failed
+		h.latency = float64(latency)
+	} else {
+		h.errorRate += decay * (failed - h.errorRate)
+		h.latency += decay * (float64(latency) - h.latency)
+	}
+	h.requests++
+
+	if !d.unhealthy(h) || !d.canEject(now) {
+		return false
+	}
+	h.ejectedUntil = now.Add(d.opts.Cooldown)
+	return true
+}
+
+// unhealthy returns whether h should be ejected.
+func (d *Detector) unhealthy(h *host) bool {
+	if h.consecutiveFailures >= d.opts.ConsecutiveFailures {
+		return true
+	}
+	if h.requests < d.opts.MinRequests {
+		return false
+	}
+	return h.errorRate >= d.opts.ErrorRate ||
+		(d.opts.Latency > 0 && h.latency >= float64(d.opts.Latency))
+}
+
+// canEject returns whether one more destination can be ejected without
+// going over MaxEjectedRatio.
+func (d *Detector) canEject(now time.Time) bool {
+	ejected := 0
+	for _, h := range d.hosts {
+		if h.ejectedAt(now) {
+			ejected++
+		}
+	}
+	return float64(ejected+1) <= d.opts.MaxEjectedRatio*float64(d.members)
+}
+
+// ejectedAt returns whether the host is ejected at the time now.
+func (h *host) ejectedAt(now time.Time) bool {
+	return now.Before(h.ejectedUntil)
+}
+
+// Ejected returns the destinations that are currently ejected.
+func (d *Detector) Ejected() []string {
+	if d == nil {
+		return nil
+	}
+	now := d.now()
+
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	var ejected []string
+	for dest, h := range d.hosts {
+		if h.ejectedAt(now) {
+			ejected = append(ejected, dest)
+		}
+	}
+	return ejected
+}
+
+// Router returns a Router that maps keys to the members of ring, skipping
+// the ones that are ejected and the ones in exclude. The members of ring
+// are read once, so each batch of keys should get its own Router. The
+// Detector forgets about the destinations that are no longer in ring.
+func (d *Detector) Router(ring hashring.Ring, exclude ...string) *Router {
+	r := &Router{ring: ring}
+	members := ring.Members()
+
+	var ejected map[string]bool
+	if d != nil {
+		now := d.now()
+		d.mtx.Lock()
+		d.members = len(members)
+		current := make(map[string]bool, len(members))
+		for _, member := range members {
+			current[member] = true
+			if h, ok := d.hosts[member]; ok && h.ejectedAt(now) {
+				if ejected == nil {
+					ejected = make(map[string]bool)
+				}
+				ejected[member] = true
+			}
+		}
+		for dest := range d.hosts {
+			if !current[dest] {
+				delete(d.hosts, dest)
+			}
+		}
+		d.mtx.Unlock()
+	}
+	if len(ejected) == 0 && len(exclude) == 0 {
+		return r
+	}
+
+	r.skip = make(map[string]bool, len(ejected)+len(exclude))
+	for _, dest := range exclude {
+		r.skip[dest] = true
+	}
+	var healthy, included []string
+	for _, member := range members {
+		if r.skip[member] {
+			continue
+		}
+		included = append(included, member)
+		if !ejected[member] {
+			healthy = append(healthy, member)
+		}
+	}
+
+	// If every destination that's left is ejected, sending to one of them
+	// beats dropping the keys
+	remaining := healthy
+	if len(healthy) == 0 {
+		remaining = included
+	} else {
+		for member := range ejected {
+			r.skip[member] = true
+		}
+	}
+	fallback := hashring.NewRendezvous()
+	fallback.Set(remaining)
+	r.fallback = fallback
+	return r
+}
+
+// A Router maps keys to destinations, away from the ones that a Detector
+// ejected.
+type Router struct {
+	ring     hashring.Ring
+	skip     map[string]bool
+	fallback hashring.Ring
+}
+
+// Get returns the destination for key. That's the member of the ring that
+// key maps to, unless it's skipped. The keys of skipped members are spread
+// across the others with rendezvous hashing, so that they move as little
+// as possible as members are ejected and come back.
+func (r *Router) Get(key string) (string, error) {
+	dest, err := r.ring.Get(key)
+	if err != nil || !r.skip[dest] {
+		return dest, err
+	}
+	return r.fallback.Get(key)
+}
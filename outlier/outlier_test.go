@@ -0,0 +1,144 @@
+package outlier
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/veneur/hashring"
+)
+
+var errTest = errors.New("test")
+
+func newTestDetector(opts Options, members ...string) (*Detector, hashring.Ring, *time.Time) {
+	d := New(opts)
+	now := time.Unix(1000, 0)
+	d.now = func() time.Time { return now }
+
+	ring := hashring.NewRendezvous()
+	ring.Set(members)
+	d.Router(ring)
+	return d, ring, &now
+}
+
+func TestConsecutiveFailures(t *testing.T) {
+	d, _, _ := newTestDetector(Options{ConsecutiveFailures: 3}, "a", "b", "c", "d")
+
+	assert.False(t, d.Observe("a", 0, errTest))
+	assert.False(t, d.Observe("a", 0, errTest))
+	assert.False(t, d.Observe("a", 0, nil), "a success should reset the failures")
+	assert.False(t, d.Observe("a", 0, errTest))
+	assert.False(t, d.Observe("a", 0, errTest))
+	assert.True(t, d.Observe("a", 0, errTest))
+	assert.Equal(t, []string{"a"}, d.Ejected())
+
+	assert.False(t, d.Observe("a", 0, errTest), "a is already ejected")
+}
+
+func TestErrorRateAndLatency(t *testing.T) {
+	d, _, _ := newTestDetector(Options{
+		ConsecutiveFailures: 100,
+		MinRequests:         10,
+		ErrorRate:           0.3,
+		Latency:             time.Second,
+	}, "a", "b", "c", "d")
+
+	ejected := false
+	for i := 0; i < 20 && !ejected; i++ {
+		var err error
+		if i%2 == 0 {
+			err = errTest
+		}
+		ejected = d.Observe("a", 0, err)
+	}
+	assert.True(t, ejected, "half of the requests to a failed")
+
+	for i := 0; i < 9; i++ {
+		require.False(t, d.Observe("b", 2*time.Second, nil), "too few requests to b")
+	}
+	assert.True(t, d.Observe("b", 2*time.Second, nil), "b is too slow")
+	assert.ElementsMatch(t, []string{"a", "b"}, d.Ejected())
+}
+
+func TestMaxEjectedRatio(t *testing.T) {
+	d, _, _ := newTestDetector(Options{ConsecutiveFailures: 1}, "a", "b", "c", "d")
+
+	assert.True(t, d.Observe("a", 0, errTest))
+	assert.True(t, d.Observe("b", 0, errTest))
+	assert.False(t, d.Observe("c", 0, errTest), "only half of the destinations can be ejected")
+	assert.ElementsMatch(t, []string{"a", "b"}, d.Ejected())
+
+	var nilDetector *Detector
+	assert.False(t, nilDetector.Observe("a", 0, errTest))
+	assert.Empty(t, nilDetector.Ejected())
+}
+
+func TestCooldown(t *testing.T) {
+	d, _, now := newTestDetector(Options{ConsecutiveFailures: 1, Cooldown: time.Minute}, "a", "b")
+
+	require.True(t, d.Observe("a", 0, errTest))
+	*now = now.Add(time.Minute)
+	assert.Empty(t, d.Ejected(), "a should be probed after the cooldown")
+	assert.True(t, d.Observe("a", 0, errTest), "a failed the probe")
+	assert.Equal(t, []string{"a"}, d.Ejected())
+
+	*now = now.Add(time.Minute)
+	assert.False(t, d.Observe("a", 0, nil), "a passed the probe")
+	assert.Empty(t, d.Ejected())
+	assert.True(t, d.Observe("a", 0, errTest), "a starts over")
+}
+
+func TestRouter(t *testing.T) {
+	d, ring, _ := newTestDetector(Options{ConsecutiveFailures: 1}, "a", "b", "c", "d")
+	require.True(t, d.Observe("a", 0, errTest))
+
+	before := New(Options{}).Router(ring)
+	after := d.Router(ring)
+	for i := 0; i < 1000; i++ {
+		key := strconv.Itoa(i)
+		owner, err := before.Get(key)
+		require.NoError(t, err)
+		dest, err := after.Get(key)
+		require.NoError(t, err)
+
+		assert.NotEqual(t, "a", dest)
+		if owner != "a" {
+			assert.Equal(t, owner, dest, "only the keys of a should move")
+		}
+	}
+
+	// excluded destinations are skipped too
+	retry := d.Router(ring, "b")
+	for i := 0; i < 1000; i++ {
+		dest, err := retry.Get(strconv.Itoa(i))
+		require.NoError(t, err)
+		assert.Contains(t, []string{"c", "d"}, dest)
+	}
+
+	// with nothing else left, the ejected destinations are used
+	retry = d.Router(ring, "b", "c", "d")
+	dest, err := retry.Get("key")
+	require.NoError(t, err)
+	assert.Equal(t, "a", dest)
+
+	retry = New(Options{}).Router(ring, "a", "b", "c", "d")
+	_, err = retry.Get("key")
+	assert.Equal(t, hashring.ErrNoMembers, err)
+}
+
+func TestRouterForgetsRemovedDestinations(t *testing.T) {
+	d, ring, _ := newTestDetector(Options{ConsecutiveFailures: 1}, "a", "b", "c", "d")
+	require.True(t, d.Observe("a", 0, errTest))
+	d.Observe("b", 0, nil)
+	assert.Equal(t, []string{"a"}, d.Ejected())
+
+	ring.Set([]string{"b", "c", "d"})
+	d.Router(ring)
+	assert.Empty(t, d.Ejected())
+	d.mtx.Lock()
+	assert.Len(t, d.hosts, 1, "only the destinations still in the ring should be tracked")
+	d.mtx.Unlock()
+}
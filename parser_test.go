@@ -541,7 +541,15 @@ func TestParserDistribution(t *testing.T) {
 	assert.NotNil(t, m, "Got nil metric!")
 	assert.Equal(t, "a.b.c", m.Name, "Name")
 	assert.Equal(t, float64(0.1716441474854946), m.Value, "Value")
-	assert.Equal(t, "histogram", m.Type, "Type")
+	assert.Equal(t, "distribution", m.Type, "Type")
+	assert.Equal(t, samplers.GlobalOnly, m.Scope, "distributions are always global")
+
+	m, _ = samplers.ParseMetric([]byte("a.b.c:1|d|#veneurlocalonly"))
+	assert.NotNil(t, m, "Got nil metric!")
+	assert.Equal(t, samplers.GlobalOnly, m.Scope, "distributions can't be local")
+
+	h, _ := samplers.ParseMetric([]byte("a.b.c:1|h"))
+	assert.NotEqual(t, h.MetricKey, m.MetricKey, "distributions and histograms should not aggregate together")
 }
 
 func TestParserTimerFloat(t *testing.T) {
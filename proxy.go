@@ -23,7 +23,9 @@ import (
 	"github.com/hashicorp/consul/api"
 	"github.com/pkg/profile"
 	"github.com/sirupsen/logrus"
+	"github.com/stripe/veneur/hashring"
 	vhttp "github.com/stripe/veneur/http"
+	"github.com/stripe/veneur/outlier"
 	"github.com/stripe/veneur/proxysrv"
 	"github.com/stripe/veneur/samplers"
 	"github.com/stripe/veneur/ssf"
@@ -31,7 +33,6 @@ import (
 	"github.com/stripe/veneur/trace/metrics"
 	"github.com/zenazn/goji/bind"
 	"github.com/zenazn/goji/graceful"
-	"stathat.com/c/consistent"
 
 	"goji.io"
 	"goji.io/pat"
@@ -40,9 +41,9 @@ import (
 type Proxy struct {
 	Sentry                     *raven.Client
 	Hostname                   string
-	ForwardDestinations        *consistent.Consistent
-	TraceDestinations          *consistent.Consistent
-	ForwardGRPCDestinations    *consistent.Consistent
+	ForwardDestinations        hashring.Ring
+	TraceDestinations          hashring.Ring
+	ForwardGRPCDestinations    hashring.Ring
 	Discoverer                 Discoverer
 	ConsulForwardService       string
 	ConsulTraceService         string
@@ -61,9 +62,14 @@ type Proxy struct {
 
 	usingConsul     bool
 	usingKubernetes bool
+	usingDNSSRV     bool
+	discovererFile  string
 	enableProfiling bool
-	shutdown        chan struct{}
-	TraceClient     *trace.Client
+	// forwardEjector is nil unless ejection is configured
+	forwardEjector *outlier.Detector
+	mirrors        []*proxyMirror
+	shutdown       chan struct{}
+	TraceClient    *trace.Client
 
 	// gRPC
 	grpcServer        *proxysrv.Server
@@ -132,18 +138,55 @@ func NewProxyFromConfig(logger *logrus.Logger, conf ProxyConfig) (p Proxy, err e
 		p.AcceptingGRPCForwards = true
 	}
 
-	// We need a convenient way to know if we're even using Consul later
-	if p.ConsulForwardService != "" || p.ConsulTraceService != "" || p.ConsulForwardGRPCService != "" {
-		log.WithFields(logrus.Fields{
-			"consulForwardService":     p.ConsulForwardService,
-			"consulTraceService":       p.ConsulTraceService,
-			"consulGRPCForwardService": p.ConsulForwardGRPCService,
-		}).Info("Using consul for service discovery")
-		p.usingConsul = true
+	p.mirrors, err = newProxyMirrors(conf)
+	if err != nil {
+		logger.WithError(err).Error("Invalid forward mirror configuration")
+		return
+	}
+
+	// We need a convenient way to know if we're even using service discovery
+	// later, and which kind
+	discovering := p.ConsulForwardService != "" || p.ConsulTraceService != "" || p.ConsulForwardGRPCService != ""
+	for _, m := range p.mirrors {
+		discovering = discovering || m.discovering()
+	}
+	discoveryFields := logrus.Fields{
+		"consulForwardService":     p.ConsulForwardService,
+		"consulTraceService":       p.ConsulTraceService,
+		"consulGRPCForwardService": p.ConsulForwardGRPCService,
+	}
+	switch conf.Discoverer {
+	case "", consulDiscoverer:
+		if discovering {
+			log.WithFields(discoveryFields).Info("Using consul for service discovery")
+			p.usingConsul = true
+		}
+	case dnsSRVDiscoverer:
+		if discovering {
+			log.WithFields(discoveryFields).Info("Using DNS SRV records for service discovery")
+			p.usingDNSSRV = true
+		}
+	case fileDiscoverer:
+		if conf.DiscovererFile == "" {
+			err = errors.New("the file discoverer requires discoverer_file")
+			logger.WithError(err).Error("Invalid discoverer configuration")
+			return
+		}
+		if discovering {
+			log.WithFields(discoveryFields).WithField("path", conf.DiscovererFile).
+				Info("Using a file for service discovery")
+			p.discovererFile = conf.DiscovererFile
+		}
+	case kubernetesDiscoverer:
+	default:
+		err = fmt.Errorf("unknown discoverer %q", conf.Discoverer)
+		logger.WithError(err).Error("Invalid discoverer configuration")
+		return
 	}
 
 	// check if we are running on Kubernetes
-	if _, err := os.Stat("/var/run/secrets/kubernetes.io/serviceaccount"); !os.IsNotExist(err) {
+	_, statErr := os.Stat("/var/run/secrets/kubernetes.io/serviceaccount")
+	if conf.Discoverer == kubernetesDiscoverer || (conf.Discoverer == "" && !os.IsNotExist(statErr)) {
 		log.Info("Using Kubernetes for service discovery")
 		p.usingKubernetes = true
 
@@ -153,9 +196,15 @@ func NewProxyFromConfig(logger *logrus.Logger, conf ProxyConfig) (p Proxy, err e
 		}
 	}
 
-	p.ForwardDestinations = consistent.New()
-	p.TraceDestinations = consistent.New()
-	p.ForwardGRPCDestinations = consistent.New()
+	for _, ring := range []*hashring.Ring{&p.ForwardDestinations, &p.TraceDestinations, &p.ForwardGRPCDestinations} {
+		*ring, err = hashring.New(conf.ForwardHashing, conf.ForwardHashingLoadFactor)
+		if err != nil {
+			logger.WithError(err).
+				WithField("forward_hashing", conf.ForwardHashing).
+				Error("Could not create the destination hash rings")
+			return
+		}
+	}
 
 	if conf.ForwardTimeout != "" {
 		p.ForwardTimeout, err = time.ParseDuration(conf.ForwardTimeout)
@@ -167,6 +216,43 @@ func NewProxyFromConfig(logger *logrus.Logger, conf ProxyConfig) (p Proxy, err e
 		}
 	}
 
+	var handoffGracePeriod time.Duration
+	if conf.ForwardHandoffGracePeriod != "" {
+		handoffGracePeriod, err = time.ParseDuration(conf.ForwardHandoffGracePeriod)
+		if err != nil {
+			logger.WithError(err).
+				WithField("value", conf.ForwardHandoffGracePeriod).
+				Error("Could not parse forward handoff grace period")
+			return
+		}
+	}
+
+	var ejection *outlier.Options
+	if conf.ForwardEjectionCooldown != "" {
+		ejection = &outlier.Options{
+			ConsecutiveFailures: conf.ForwardEjectionConsecutiveFailures,
+			ErrorRate:           conf.ForwardEjectionErrorRate,
+			MaxEjectedRatio:     conf.ForwardEjectionMaxRatio,
+		}
+		ejection.Cooldown, err = time.ParseDuration(conf.ForwardEjectionCooldown)
+		if err != nil {
+			logger.WithError(err).
+				WithField("value", conf.ForwardEjectionCooldown).
+				Error("Could not parse forward ejection cooldown")
+			return
+		}
+		if conf.ForwardEjectionLatency != "" {
+			ejection.Latency, err = time.ParseDuration(conf.ForwardEjectionLatency)
+			if err != nil {
+				logger.WithError(err).
+					WithField("value", conf.ForwardEjectionLatency).
+					Error("Could not parse forward ejection latency")
+				return
+			}
+		}
+		p.forwardEjector = outlier.New(*ejection)
+	}
+
 	// We got a static forward address, stick it in the destination!
 	if p.ConsulForwardService == "" && conf.ForwardAddress != "" {
 		p.ForwardDestinations.Add(conf.ForwardAddress)
@@ -190,13 +276,13 @@ func NewProxyFromConfig(logger *logrus.Logger, conf ProxyConfig) (p Proxy, err e
 		return
 	}
 
-	if p.usingConsul {
+	if p.discovering() {
 		p.ConsulInterval, err = time.ParseDuration(conf.ConsulRefreshInterval)
 		if err != nil {
 			logger.WithError(err).Error("Error parsing Consul refresh interval")
 			return
 		}
-		logger.WithField("interval", conf.ConsulRefreshInterval).Info("Will use Consul for service discovery")
+		logger.WithField("interval", conf.ConsulRefreshInterval).Info("Will refresh destinations from service discovery")
 	}
 
 	p.MetricsInterval = time.Second * 10
@@ -246,11 +332,57 @@ func NewProxyFromConfig(logger *logrus.Logger, conf ProxyConfig) (p Proxy, err e
 
 	if conf.GrpcAddress != "" {
 		p.grpcListenAddress = conf.GrpcAddress
-		p.grpcServer, err = proxysrv.New(p.ForwardGRPCDestinations,
+		opts := []proxysrv.Option{
+			proxysrv.WithMaxActiveHandlers(conf.GrpcMaxActiveHandlers),
+			proxysrv.WithMaxInFlightBytes(int64(conf.GrpcMaxInFlightBytes)),
+		}
+		if handoffGracePeriod > 0 {
+			opts = append(opts, proxysrv.WithHandoff(handoffGracePeriod,
+				conf.ForwardHashing, conf.ForwardHashingLoadFactor))
+		}
+		if ejection != nil {
+			opts = append(opts, proxysrv.WithEjection(*ejection))
+		}
+
+		serverOpts, err := grpcServerOptions(conf.GrpcTLSCertificate, conf.GrpcTLSKey,
+			conf.GrpcTLSAuthorityCertificate, conf.GrpcBearerTokens, nil)
+		if err != nil {
+			logger.WithError(err).Fatal("Improper gRPC TLS configuration")
+		}
+		dialOpts, err := grpcDialOptions(conf.GrpcTLSCertificate, conf.GrpcTLSKey,
+			conf.GrpcTLSAuthorityCertificate, conf.ForwardGrpcBearerToken, conf.ForwardGrpcCompression)
+		if err != nil {
+			logger.WithError(err).Fatal("Improper gRPC TLS configuration")
+		}
+		// mirrors only share the options about how to forward: they don't
+		// hand off or eject anything, and they have limits of their own
+		forwardOpts := []proxysrv.Option{
 			proxysrv.WithForwardTimeout(p.ForwardTimeout),
 			proxysrv.WithLog(logrus.NewEntry(log)),
 			proxysrv.WithTraceClient(p.TraceClient),
-		)
+			proxysrv.WithDialOptions(dialOpts...),
+			proxysrv.WithForwardBatchSize(conf.ForwardGrpcBatchSize),
+		}
+		opts = append(opts, forwardOpts...)
+		opts = append(opts, proxysrv.WithServerOptions(serverOpts...))
+		for _, m := range p.mirrors {
+			if !m.forwardsGRPC() {
+				continue
+			}
+			mirrorOpts := append([]proxysrv.Option{
+				proxysrv.WithMirrorName(m.name),
+				proxysrv.WithMaxActiveHandlers(m.grpcMaxHandlers),
+				proxysrv.WithMaxInFlightBytes(m.grpcMaxInFlightBytes),
+			}, forwardOpts...)
+			m.grpcServer, err = proxysrv.New(m.forwardGRPCDestinations, mirrorOpts...)
+			if err != nil {
+				logger.WithError(err).WithField("mirror", m.name).
+					Fatal("Failed to initialize the gRPC forwarding of a mirror")
+			}
+			opts = append(opts, proxysrv.WithMirror(m.name, m.grpcServer, m.filter.accepts))
+		}
+
+		p.grpcServer, err = proxysrv.New(p.ForwardGRPCDestinations, opts...)
 		if err != nil {
 			logger.WithError(err).Fatal("Failed to initialize the gRPC server")
 		}
@@ -263,6 +395,11 @@ func NewProxyFromConfig(logger *logrus.Logger, conf ProxyConfig) (p Proxy, err e
 		logger.SetLevel(logrus.DebugLevel)
 	}
 
+	// Don't emit keys into logs now that we're done with them.
+	conf.GrpcTLSKey = REDACTED
+	conf.ForwardGrpcBearerToken = REDACTED
+	conf.GrpcBearerTokens = nil
+
 	logger.WithField("config", conf).Debug("Initialized server")
 
 	return
@@ -278,7 +415,18 @@ func (p *Proxy) Start() {
 	// it for testing.
 	config.HttpClient = p.HTTPClient
 
-	if p.usingKubernetes {
+	if p.discovererFile != "" {
+		disc, err := NewFileDiscoverer(p.discovererFile)
+		if err != nil {
+			log.WithError(err).Error("Error creating file discoverer")
+			return
+		}
+		p.Discoverer = disc
+		log.Info("Set file discoverer")
+	} else if p.usingDNSSRV {
+		p.Discoverer = NewDNSSRVDiscoverer(nil)
+		log.Info("Set DNS SRV discoverer")
+	} else if p.usingKubernetes {
 		disc, err := NewKubernetesDiscoverer()
 		if err != nil {
 			log.WithError(err).Error("Error creating KubernetesDiscoverer")
@@ -318,7 +466,9 @@ func (p *Proxy) Start() {
 		p.grpcServer.SetDestinations(p.ForwardGRPCDestinations)
 	}
 
-	if p.usingConsul || p.usingKubernetes {
+	p.refreshMirrors()
+
+	if p.discovering() {
 		log.Info("Creating service discovery goroutine")
 		go func() {
 			defer func() {
@@ -342,6 +492,7 @@ func (p *Proxy) Start() {
 					p.RefreshDestinations(p.ConsulForwardGRPCService, p.ForwardGRPCDestinations, &p.ForwardGRPCDestinationsMtx)
 					p.grpcServer.SetDestinations(p.ForwardGRPCDestinations)
 				}
+				p.refreshMirrors()
 			}
 		}()
 	}
@@ -471,17 +622,27 @@ func (p *Proxy) gRPCStop() {
 
 	select {
 	case <-done:
-		return
 	case <-time.After(10 * time.Second):
 		log.Info("Force-stopping the gRPC server after waiting for a graceful shutdown")
 		p.grpcServer.Stop()
 	}
+	for _, m := range p.mirrors {
+		if m.grpcServer != nil {
+			m.grpcServer.Stop()
+		}
+	}
+}
+
+// discovering returns whether the proxy discovers its destinations, as
+// opposed to only using static ones.
+func (p *Proxy) discovering() bool {
+	return p.usingConsul || p.usingKubernetes || p.usingDNSSRV || p.discovererFile != ""
 }
 
 // RefreshDestinations updates the server's list of valid destinations
 // for flushing. This should be called periodically to ensure we have
 // the latest data.
-func (p *Proxy) RefreshDestinations(serviceName string, ring *consistent.Consistent, mtx *sync.Mutex) {
+func (p *Proxy) RefreshDestinations(serviceName string, ring hashring.Ring, mtx *sync.Mutex) {
 	samples := &ssf.Samples{}
 	defer metrics.Report(p.TraceClient, samples)
 	srvTags := map[string]string{"service": serviceName}
@@ -508,9 +669,10 @@ func (p *Proxy) RefreshDestinations(serviceName string, ring *consistent.Consist
 	}
 
 	mtx.Lock()
-	ring.Set(destinations)
+	moved := hashring.Set(ring, destinations)
 	mtx.Unlock()
 	samples.Add(ssf.Gauge("discoverer.destination_number", float32(len(destinations)), srvTags))
+	samples.Add(ssf.Gauge("discoverer.moved_keys_ratio", float32(moved), srvTags))
 }
 
 // Handler returns the Handler responsible for routing request processing.
@@ -576,8 +738,10 @@ func (p *Proxy) ProxyTraces(ctx context.Context, traces []DatadogTraceSpan) {
 }
 
 // ProxyMetrics takes a slice of JSONMetrics and breaks them up into
-// multiple HTTP requests by MetricKey using the hash ring.
-func (p *Proxy) ProxyMetrics(ctx context.Context, jsonMetrics []samplers.JSONMetric, origin string) {
+// multiple HTTP requests by MetricKey using the hash ring. If timestamp is
+// not zero, the metrics were replayed from a forward spool, and were
+// aggregated in the interval at that Unix timestamp.
+func (p *Proxy) ProxyMetrics(ctx context.Context, jsonMetrics []samplers.JSONMetric, origin string, timestamp int64) {
 	span, _ := trace.StartSpanFromContext(ctx, "veneur.opentracing.proxy.proxy_metrics")
 	defer span.ClientFinish(p.TraceClient)
 
@@ -599,8 +763,9 @@ func (p *Proxy) ProxyMetrics(ctx context.Context, jsonMetrics []samplers.JSONMet
 		jsonMetricsByDestination[h] = make([]samplers.JSONMetric, 0)
 	}
 
+	router := p.forwardEjector.Router(p.ForwardDestinations)
 	for _, jm := range jsonMetrics {
-		dest, _ := p.ForwardDestinations.Get(jm.MetricKey.String())
+		dest, _ := router.Get(jm.MetricKey.String())
 		jsonMetricsByDestination[dest] = append(jsonMetricsByDestination[dest], jm)
 	}
 
@@ -609,8 +774,9 @@ func (p *Proxy) ProxyMetrics(ctx context.Context, jsonMetrics []samplers.JSONMet
 	wg.Add(len(jsonMetricsByDestination)) // Make our waitgroup the size of our destinations
 
 	for dest, batch := range jsonMetricsByDestination {
-		go p.doPost(ctx, &wg, dest, batch)
+		go p.doPost(ctx, &wg, dest, batch, timestamp)
 	}
+	p.mirrorMetrics(ctx, &wg, jsonMetrics, timestamp)
 	wg.Wait() // Wait for all the above goroutines to complete
 	log.WithField("count", metricCount).Debug("Completed forward")
 
@@ -620,16 +786,66 @@ func (p *Proxy) ProxyMetrics(ctx context.Context, jsonMetrics []samplers.JSONMet
 	)...)
 }
 
-func (p *Proxy) doPost(ctx context.Context, wg *sync.WaitGroup, destination string, batch []samplers.JSONMetric) {
+func (p *Proxy) doPost(ctx context.Context, wg *sync.WaitGroup, destination string, batch []samplers.JSONMetric, timestamp int64) {
 	defer wg.Done()
 
+	if len(batch) < 1 {
+		return
+	}
+	if p.post(ctx, p.forwardEjector, "", destination, batch, timestamp) == nil || p.forwardEjector == nil {
+		return
+	}
+	p.retryPost(destination, batch, timestamp)
+}
+
+// retryPost forwards a batch of metrics that failed to go to destination
+// once more, to the destinations that they would have if destination was
+// ejected.
+func (p *Proxy) retryPost(destination string, batch []samplers.JSONMetric, timestamp int64) {
+	ctx := context.Background()
+	if p.ForwardTimeout > 0 {
+		var cancel func()
+		ctx, cancel = context.WithTimeout(ctx, p.ForwardTimeout)
+		defer cancel()
+	}
+
+	router := p.forwardEjector.Router(p.ForwardDestinations, destination)
+	batches := make(map[string][]samplers.JSONMetric)
+	for _, jm := range batch {
+		dest, err := router.Get(jm.MetricKey.String())
+		if err != nil {
+			log.WithError(err).WithField("destination", destination).
+				Warn("Found no other destination to retry forwarding to")
+			metrics.ReportOne(p.TraceClient, ssf.Count("proxy.retried_metrics_total",
+				float32(len(batch)), map[string]string{"status": "failure", "protocol": "http"}))
+			return
+		}
+		batches[dest] = append(batches[dest], jm)
+	}
+
+	failed := 0
+	for dest, retry := range batches {
+		if p.post(ctx, p.forwardEjector, "", dest, retry, timestamp) != nil {
+			failed += len(retry)
+		}
+	}
+	metrics.ReportBatch(p.TraceClient, []*ssf.SSFSample{
+		ssf.Count("proxy.retried_metrics_total", float32(len(batch)-failed),
+			map[string]string{"status": "success", "protocol": "http"}),
+		ssf.Count("proxy.retried_metrics_total", float32(failed),
+			map[string]string{"status": "failure", "protocol": "http"}),
+	})
+}
+
+// post forwards a batch of metrics to a destination, and records the outcome
+// with ejector, which may be nil. The metrics about posts to the
+// destinations of a mirror are tagged with its name.
+func (p *Proxy) post(ctx context.Context, ejector *outlier.Detector, mirror string, destination string, batch []samplers.JSONMetric, timestamp int64) error {
 	samples := &ssf.Samples{}
 	defer metrics.Report(p.TraceClient, samples)
 
 	batchSize := len(batch)
-	if batchSize < 1 {
-		return
-	}
+	member := destination
 
 	// Make sure the destination always has a valid 'http' prefix.
 	if !strings.HasPrefix(destination, "http") {
@@ -638,30 +854,46 @@ func (p *Proxy) doPost(ctx context.Context, wg *sync.WaitGroup, destination stri
 	}
 
 	endpoint := fmt.Sprintf("%s/import", destination)
+	if timestamp != 0 {
+		endpoint = fmt.Sprintf("%s?timestamp=%d", endpoint, timestamp)
+	}
+	start := time.Now()
 	err := vhttp.PostHelper(ctx, p.HTTPClient, p.TraceClient, http.MethodPost, endpoint, batch, "forward", true, nil, log)
+	if ejector.Observe(member, time.Since(start), err) {
+		log.WithError(err).WithField("destination", member).
+			Warn("Ejected a failing destination")
+		samples.Add(ssf.Count("proxy.ejections_total", 1, map[string]string{"protocol": "http"}))
+	}
 	if err == nil {
 		log.WithField("metrics", batchSize).Debug("Completed forward to Veneur")
 	} else {
-		samples.Add(ssf.Count("forward.error_total", 1, map[string]string{"cause": "post"}))
+		samples.Add(ssf.Count("forward.error_total", 1, mirrorTags(mirror, map[string]string{"cause": "post"})))
 		log.WithError(err).WithFields(logrus.Fields{
 			"endpoint":  endpoint,
 			"batchSize": batchSize,
 		}).Warn("Failed to POST metrics to destination")
 	}
 	samples.Add(ssf.RandomlySample(0.1,
-		ssf.Count("metrics_by_destination", float32(batchSize), map[string]string{"destination": destination, "protocol": "http"}),
+		ssf.Count("metrics_by_destination", float32(batchSize),
+			mirrorTags(mirror, map[string]string{"destination": destination, "protocol": "http"})),
 	)...)
+	return err
 }
 
 func (p *Proxy) ReportRuntimeMetrics() {
 	mem := &runtime.MemStats{}
 	runtime.ReadMemStats(mem)
-	metrics.ReportBatch(p.TraceClient, []*ssf.SSFSample{
+	samples := []*ssf.SSFSample{
 		ssf.Gauge("mem.heap_alloc_bytes", float32(mem.HeapAlloc), nil),
 		ssf.Gauge("gc.number", float32(mem.NumGC), nil),
 		ssf.Gauge("gc.pause_total_ns", float32(mem.PauseTotalNs), nil),
 		ssf.Gauge("gc.alloc_heap_bytes", float32(mem.HeapAlloc), nil),
-	})
+	}
+	if p.forwardEjector != nil {
+		samples = append(samples, ssf.Gauge("proxy.ejected_destinations",
+			float32(len(p.forwardEjector.Ejected())), map[string]string{"protocol": "http"}))
+	}
+	metrics.ReportBatch(p.TraceClient, samples)
 }
 
 // Shutdown signals the server to shut down after closing all
@@ -0,0 +1,186 @@
+package veneur
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/stripe/veneur/hashring"
+	"github.com/stripe/veneur/proxysrv"
+	"github.com/stripe/veneur/samplers"
+	"github.com/stripe/veneur/ssf"
+	"github.com/stripe/veneur/trace/metrics"
+)
+
+// A proxyMirror is an additional set of destinations, like a shadow global
+// cluster or another region, that a proxy sends a copy of the metrics that
+// match its filter to. Mirrors are best-effort: their destinations are
+// never ejected, and the forwards to them that fail are not retried.
+type proxyMirror struct {
+	name   string
+	filter mirrorFilter
+
+	consulForwardService       string
+	forwardDestinations        hashring.Ring
+	forwardDestinationsMtx     sync.Mutex
+	consulForwardGRPCService   string
+	forwardGRPCDestinations    hashring.Ring
+	forwardGRPCDestinationsMtx sync.Mutex
+
+	// grpcServer forwards the metrics mirrored from the proxy's gRPC
+	// server. It doesn't serve anything itself, and drops the metrics that
+	// would put it over its own limits.
+	grpcServer           *proxysrv.Server
+	grpcMaxHandlers      int
+	grpcMaxInFlightBytes int64
+}
+
+// mirrorFilter selects the metrics that are mirrored by their name.
+type mirrorFilter struct {
+	prefixes     []string
+	dropPrefixes []string
+}
+
+// accepts returns whether the metric named name is mirrored: its name must
+// start with one of the prefixes, if there are any, and with none of the
+// drop prefixes.
+func (f mirrorFilter) accepts(name string) bool {
+	for _, prefix := range f.dropPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return false
+		}
+	}
+	if len(f.prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range f.prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// newProxyMirrors creates the mirrors in a proxy's configuration, with
+// their static destinations. Mirrors without limits of their own on the
+// metrics they forward over gRPC get the same limits as the proxy.
+func newProxyMirrors(conf ProxyConfig) ([]*proxyMirror, error) {
+	mirrors := make([]*proxyMirror, 0, len(conf.ForwardMirrors))
+	names := make(map[string]bool, len(conf.ForwardMirrors))
+	for _, mc := range conf.ForwardMirrors {
+		if mc.Name == "" {
+			return nil, errors.New("every forward mirror needs a name")
+		}
+		if names[mc.Name] {
+			return nil, fmt.Errorf("there is more than one forward mirror named %q", mc.Name)
+		}
+		names[mc.Name] = true
+
+		m := &proxyMirror{
+			name: mc.Name,
+			filter: mirrorFilter{
+				prefixes:     mc.MetricNamePrefixes,
+				dropPrefixes: mc.MetricNamePrefixDrops,
+			},
+			consulForwardService:     mc.ConsulForwardServiceName,
+			consulForwardGRPCService: mc.ConsulForwardGrpcServiceName,
+			grpcMaxHandlers:          mc.GrpcMaxActiveHandlers,
+			grpcMaxInFlightBytes:     int64(mc.GrpcMaxInFlightBytes),
+		}
+		if m.grpcMaxHandlers == 0 {
+			m.grpcMaxHandlers = conf.GrpcMaxActiveHandlers
+		}
+		if m.grpcMaxInFlightBytes == 0 {
+			m.grpcMaxInFlightBytes = int64(conf.GrpcMaxInFlightBytes)
+		}
+		for _, ring := range []*hashring.Ring{&m.forwardDestinations, &m.forwardGRPCDestinations} {
+			var err error
+			*ring, err = hashring.New(conf.ForwardHashing, conf.ForwardHashingLoadFactor)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if m.consulForwardService == "" && mc.ForwardAddress != "" {
+			m.forwardDestinations.Add(mc.ForwardAddress)
+		}
+		if m.consulForwardGRPCService == "" && mc.GrpcForwardAddress != "" {
+			m.forwardGRPCDestinations.Add(mc.GrpcForwardAddress)
+		}
+		mirrors = append(mirrors, m)
+	}
+	return mirrors, nil
+}
+
+// discovering returns whether the mirror discovers any of its destinations.
+func (m *proxyMirror) discovering() bool {
+	return m.consulForwardService != "" || m.consulForwardGRPCService != ""
+}
+
+// forwardsGRPC returns whether the mirror has gRPC destinations.
+func (m *proxyMirror) forwardsGRPC() bool {
+	return m.consulForwardGRPCService != "" || len(m.forwardGRPCDestinations.Members()) > 0
+}
+
+// refreshMirrors updates the destinations of the mirrors that discover
+// them.
+func (p *Proxy) refreshMirrors() {
+	for _, m := range p.mirrors {
+		if p.AcceptingForwards && m.consulForwardService != "" {
+			p.RefreshDestinations(m.consulForwardService, m.forwardDestinations, &m.forwardDestinationsMtx)
+		}
+		if m.grpcServer != nil && m.consulForwardGRPCService != "" {
+			p.RefreshDestinations(m.consulForwardGRPCService, m.forwardGRPCDestinations, &m.forwardGRPCDestinationsMtx)
+			if err := m.grpcServer.SetDestinations(m.forwardGRPCDestinations); err != nil {
+				log.WithError(err).WithField("mirror", m.name).
+					Error("Failed to set the gRPC destinations of a mirror")
+			}
+		}
+	}
+}
+
+// mirrorMetrics posts a copy of the metrics that each mirror accepts to the
+// mirror's destinations, adding each post to wg.
+func (p *Proxy) mirrorMetrics(ctx context.Context, wg *sync.WaitGroup, jsonMetrics []samplers.JSONMetric, timestamp int64) {
+	for _, m := range p.mirrors {
+		if len(m.forwardDestinations.Members()) == 0 {
+			continue
+		}
+		batches := make(map[string][]samplers.JSONMetric)
+		mirrored := 0
+		for _, jm := range jsonMetrics {
+			if !m.filter.accepts(jm.Name) {
+				continue
+			}
+			dest, err := m.forwardDestinations.Get(jm.MetricKey.String())
+			if err != nil {
+				continue
+			}
+			batches[dest] = append(batches[dest], jm)
+			mirrored++
+		}
+		if mirrored == 0 {
+			continue
+		}
+
+		metrics.ReportOne(p.TraceClient, ssf.Count("proxy.mirrored_metrics_total", float32(mirrored),
+			map[string]string{"mirror": m.name, "protocol": "http", "status": "success"}))
+		wg.Add(len(batches))
+		for dest, batch := range batches {
+			go func(dest string, batch []samplers.JSONMetric) {
+				defer wg.Done()
+				p.post(ctx, nil, m.name, dest, batch, timestamp)
+			}(dest, batch)
+		}
+	}
+}
+
+// mirrorTags adds the tag of the mirror called name to tags, unless name is
+// empty.
+func mirrorTags(name string, tags map[string]string) map[string]string {
+	if name != "" {
+		tags["mirror"] = name
+	}
+	return tags
+}
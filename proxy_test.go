@@ -3,8 +3,10 @@ package veneur
 import (
 	"compress/zlib"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -15,8 +17,12 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/stripe/veneur/forwardrpc"
 	"github.com/stripe/veneur/samplers"
+	"github.com/stripe/veneur/samplers/metricpb"
 	"github.com/zenazn/goji/graceful"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func generateProxyConfig() ProxyConfig {
@@ -222,7 +228,7 @@ func TestTimeout(t *testing.T) {
 	// timeout:
 	ch := make(chan struct{})
 	go func() {
-		server.ProxyMetrics(context.Background(), metrics, "foo.com")
+		server.ProxyMetrics(context.Background(), metrics, "foo.com", 0)
 		close(ch)
 	}()
 	select {
@@ -233,6 +239,141 @@ func TestTimeout(t *testing.T) {
 	}
 }
 
+func TestProxyEjectsFailingDestinations(t *testing.T) {
+	defer log.SetLevel(log.Level)
+	log.SetLevel(logrus.ErrorLevel)
+
+	failures := 0
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		failures++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer broken.Close()
+
+	var received []samplers.JSONMetric
+	var mtx sync.Mutex
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		z, err := zlib.NewReader(r.Body)
+		require.NoError(t, err)
+		var batch []samplers.JSONMetric
+		require.NoError(t, json.NewDecoder(z).Decode(&batch))
+
+		mtx.Lock()
+		defer mtx.Unlock()
+		received = append(received, batch...)
+	}))
+	defer healthy.Close()
+
+	cfg := generateProxyConfig()
+	cfg.ConsulTraceServiceName = ""
+	cfg.ConsulForwardServiceName = ""
+	cfg.ForwardAddress = healthy.URL
+	cfg.ForwardEjectionConsecutiveFailures = 1
+	cfg.ForwardEjectionCooldown = "1m"
+	server, err := NewProxyFromConfig(logrus.New(), cfg)
+	require.NoError(t, err)
+	server.ForwardDestinations.Set([]string{broken.URL, healthy.URL})
+
+	var metrics []samplers.JSONMetric
+	for i := 0; i < 20; i++ {
+		ctr := samplers.Counter{Name: fmt.Sprintf("foo.%d", i), Tags: []string{}}
+		ctr.Sample(20.0, 1.0)
+		jsonCtr, err := ctr.Export()
+		require.NoError(t, err)
+		metrics = append(metrics, jsonCtr)
+	}
+
+	// the batch for the broken destination is retried on the healthy one
+	server.ProxyMetrics(context.Background(), metrics, "foo.com", 0)
+	assert.Equal(t, 1, failures)
+	assert.Len(t, received, len(metrics))
+	assert.Equal(t, []string{broken.URL}, server.forwardEjector.Ejected())
+
+	// and the broken destination isn't sent anything once it's ejected
+	received = nil
+	server.ProxyMetrics(context.Background(), metrics, "foo.com", 0)
+	assert.Equal(t, 1, failures)
+	assert.Len(t, received, len(metrics))
+}
+
+// jsonMetricsServer records the names of the metrics POSTed to it.
+func jsonMetricsServer(t *testing.T, mtx *sync.Mutex, names *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		z, err := zlib.NewReader(r.Body)
+		require.NoError(t, err)
+		var batch []samplers.JSONMetric
+		require.NoError(t, json.NewDecoder(z).Decode(&batch))
+
+		mtx.Lock()
+		defer mtx.Unlock()
+		for _, jm := range batch {
+			*names = append(*names, jm.Name)
+		}
+	}))
+}
+
+func TestProxyMirrorsMetrics(t *testing.T) {
+	var mtx sync.Mutex
+	var primary, shadow []string
+	primaryServer := jsonMetricsServer(t, &mtx, &primary)
+	defer primaryServer.Close()
+	shadowServer := jsonMetricsServer(t, &mtx, &shadow)
+	defer shadowServer.Close()
+
+	cfg := generateProxyConfig()
+	cfg.ConsulTraceServiceName = ""
+	cfg.ConsulForwardServiceName = ""
+	cfg.ForwardAddress = primaryServer.URL
+	cfg.ForwardMirrors = append(cfg.ForwardMirrors, struct {
+		ConsulForwardGrpcServiceName string   `yaml:"consul_forward_grpc_service_name"`
+		ConsulForwardServiceName     string   `yaml:"consul_forward_service_name"`
+		ForwardAddress               string   `yaml:"forward_address"`
+		GrpcForwardAddress           string   `yaml:"grpc_forward_address"`
+		GrpcMaxActiveHandlers        int      `yaml:"grpc_max_active_handlers"`
+		GrpcMaxInFlightBytes         int      `yaml:"grpc_max_in_flight_bytes"`
+		MetricNamePrefixDrops        []string `yaml:"metric_name_prefix_drops"`
+		MetricNamePrefixes           []string `yaml:"metric_name_prefixes"`
+		Name                         string   `yaml:"name"`
+	}{
+		ForwardAddress:        shadowServer.URL,
+		MetricNamePrefixes:    []string{"api."},
+		MetricNamePrefixDrops: []string{"api.debug."},
+		Name:                  "shadow",
+	})
+	server, err := NewProxyFromConfig(logrus.New(), cfg)
+	require.NoError(t, err)
+
+	var metrics []samplers.JSONMetric
+	for _, name := range []string{"api.requests", "api.debug.requests", "db.queries"} {
+		ctr := samplers.Counter{Name: name, Tags: []string{}}
+		ctr.Sample(1.0, 1.0)
+		jsonCtr, err := ctr.Export()
+		require.NoError(t, err)
+		metrics = append(metrics, jsonCtr)
+	}
+
+	server.ProxyMetrics(context.Background(), metrics, "foo.com", 0)
+	assert.ElementsMatch(t, []string{"api.requests", "api.debug.requests", "db.queries"}, primary)
+	assert.Equal(t, []string{"api.requests"}, shadow)
+}
+
+func TestProxyMirrorsNeedNames(t *testing.T) {
+	cfg := generateProxyConfig()
+	cfg.ForwardMirrors = make([]struct {
+		ConsulForwardGrpcServiceName string   `yaml:"consul_forward_grpc_service_name"`
+		ConsulForwardServiceName     string   `yaml:"consul_forward_service_name"`
+		ForwardAddress               string   `yaml:"forward_address"`
+		GrpcForwardAddress           string   `yaml:"grpc_forward_address"`
+		GrpcMaxActiveHandlers        int      `yaml:"grpc_max_active_handlers"`
+		GrpcMaxInFlightBytes         int      `yaml:"grpc_max_in_flight_bytes"`
+		MetricNamePrefixDrops        []string `yaml:"metric_name_prefix_drops"`
+		MetricNamePrefixes           []string `yaml:"metric_name_prefixes"`
+		Name                         string   `yaml:"name"`
+	}, 1)
+	_, err := NewProxyFromConfig(logrus.New(), cfg)
+	assert.Error(t, err)
+}
+
 // Test that (*Proxy).Serve quits when just the gRPC server is stopped.  The
 // expected behavior is that both listeners (gRPC and HTTP) stop when either
 // of them are stopped.
@@ -285,3 +426,52 @@ func TestProxyServeStopHTTP(t *testing.T) {
 		assert.Fail(t, "Stopping the Proxy over HTTP did not stop both listeners")
 	}
 }
+
+func TestProxyMirrorsHaveTheirOwnLimits(t *testing.T) {
+	// a destination that never responds, so forwards to it stay active
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	cfg := generateProxyConfig()
+	cfg.ConsulTraceServiceName = ""
+	cfg.ConsulForwardServiceName = ""
+	cfg.GrpcForwardAddress = ln.Addr().String()
+	cfg.ForwardTimeout = "5s"
+	cfg.GrpcMaxActiveHandlers = 1
+	cfg.ForwardMirrors = append(cfg.ForwardMirrors, struct {
+		ConsulForwardGrpcServiceName string   `yaml:"consul_forward_grpc_service_name"`
+		ConsulForwardServiceName     string   `yaml:"consul_forward_service_name"`
+		ForwardAddress               string   `yaml:"forward_address"`
+		GrpcForwardAddress           string   `yaml:"grpc_forward_address"`
+		GrpcMaxActiveHandlers        int      `yaml:"grpc_max_active_handlers"`
+		GrpcMaxInFlightBytes         int      `yaml:"grpc_max_in_flight_bytes"`
+		MetricNamePrefixDrops        []string `yaml:"metric_name_prefix_drops"`
+		MetricNamePrefixes           []string `yaml:"metric_name_prefixes"`
+		Name                         string   `yaml:"name"`
+	}{
+		GrpcForwardAddress:    ln.Addr().String(),
+		GrpcMaxActiveHandlers: 2,
+		Name:                  "shadow",
+	})
+	p, err := NewProxyFromConfig(logrus.New(), cfg)
+	require.NoError(t, err)
+	require.Len(t, p.mirrors, 1)
+
+	list := &forwardrpc.MetricList{Metrics: []*metricpb.Metric{{
+		Name:  "api.requests",
+		Type:  metricpb.Type_Counter,
+		Value: &metricpb.Metric_Counter{Counter: &metricpb.CounterValue{Value: 1}},
+	}}}
+	_, err = p.grpcServer.SendMetrics(context.Background(), list)
+	require.NoError(t, err)
+	_, err = p.grpcServer.SendMetrics(context.Background(), list)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err), "the main destinations are limited")
+
+	// the main destinations' forward and the copy for the mirror both
+	// went through, and the mirror has room for one more
+	_, err = p.mirrors[0].grpcServer.SendMetrics(context.Background(), list)
+	assert.NoError(t, err, "the mirror shouldn't share the main destinations' handlers")
+	_, err = p.mirrors[0].grpcServer.SendMetrics(context.Background(), list)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err), "the mirror is limited too")
+}
@@ -0,0 +1,91 @@
+package proxysrv
+
+import (
+	"sync"
+)
+
+// inFlightBytes tracks the size of the metrics that are being forwarded to
+// each destination, to stop accepting metrics for a destination that
+// doesn't keep up.
+type inFlightBytes struct {
+	sync.Mutex
+	max   int64
+	bytes map[string]int64
+}
+
+func newInFlightBytes(max int64) *inFlightBytes {
+	return &inFlightBytes{
+		max:   max,
+		bytes: make(map[string]int64),
+	}
+}
+
+// acquire adds sizes[dest] to the bytes in flight to each destination and
+// returns true, unless that would put any destination over the maximum. It
+// then adds nothing, and returns that destination and false. A destination
+// with nothing in flight accepts any size, so that a batch bigger than the
+// maximum still goes through on its own.
+func (b *inFlightBytes) acquire(sizes map[string]int64) (string, bool) {
+	b.Lock()
+	defer b.Unlock()
+
+	for dest, size := range sizes {
+		if inFlight := b.bytes[dest]; inFlight > 0 && inFlight+size > b.max {
+			return dest, false
+		}
+	}
+	for dest, size := range sizes {
+		b.bytes[dest] += size
+	}
+	return "", true
+}
+
+// add adds sizes[dest] to the bytes in flight to each destination, even if
+// that puts it over the maximum.
+func (b *inFlightBytes) add(sizes map[string]int64) {
+	b.Lock()
+	defer b.Unlock()
+
+	for dest, size := range sizes {
+		b.bytes[dest] += size
+	}
+}
+
+// saturated returns a destination that has the maximum in flight already,
+// and true, or false if there is none.
+func (b *inFlightBytes) saturated() (string, bool) {
+	b.Lock()
+	defer b.Unlock()
+
+	for dest, inFlight := range b.bytes {
+		if inFlight >= b.max {
+			return dest, true
+		}
+	}
+	return "", false
+}
+
+// release removes sizes[dest] from the bytes in flight to each destination.
+func (b *inFlightBytes) release(sizes map[string]int64) {
+	b.Lock()
+	defer b.Unlock()
+
+	for dest, size := range sizes {
+		b.bytes[dest] -= size
+		if b.bytes[dest] <= 0 {
+			delete(b.bytes, dest)
+		}
+	}
+}
+
+// total returns the bytes in flight to every destination.
+func (b *inFlightBytes) total() int64 {
+	b.Lock()
+	defer b.Unlock()
+
+	var total int64
+	for _, size := range b.bytes {
+		total += size
+	}
+	return total
+}
@@ -4,7 +4,9 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/stripe/veneur/outlier"
 	"github.com/stripe/veneur/trace"
+	"google.golang.org/grpc"
 )
 
 // WithForwardTimeout sets the time after which an individual RPC to a
@@ -15,6 +17,34 @@ func WithForwardTimeout(d time.Duration) Option {
 	}
 }
 
+// WithForwardBatchSize streams the metrics forwarded to each destination in
+// messages of at most n metrics, instead of sending them in a single
+// message (see forwardrpc.Send).
+func WithForwardBatchSize(n int) Option {
+	return func(opts *options) {
+		opts.forwardBatchSize = n
+	}
+}
+
+// WithMaxActiveHandlers makes the server reject the metrics it receives
+// while it's forwarding n lists of metrics already, with
+// codes.ResourceExhausted.
+func WithMaxActiveHandlers(n int) Option {
+	return func(opts *options) {
+		opts.maxHandlers = int64(n)
+	}
+}
+
+// WithMaxInFlightBytes makes the server reject the metrics it receives
+// while it's forwarding n bytes of metrics to any destination that some of
+// them would go to, with codes.ResourceExhausted. The size of a metric is
+// the size of its protobuf encoding.
+func WithMaxInFlightBytes(n int64) Option {
+	return func(opts *options) {
+		opts.maxInFlightBytes = n
+	}
+}
+
 // WithLog sets the logger entry used in the object.
 func WithLog(e *logrus.Entry) Option {
 	return func(opts *options) {
@@ -36,3 +66,70 @@ func WithTraceClient(c *trace.Client) Option {
 		opts.traceClient = c
 	}
 }
+
+// WithHandoff makes the server tell the destinations that it stops
+// forwarding some metrics to about each change to its ring, so that they
+// hand the state they aggregated for those metrics off to the new
+// destination for gracePeriod after the change. The strategy and load
+// factor must be the ones that the ring was created with (see
+// hashring.New), so that the destinations compute the same ring.
+func WithHandoff(gracePeriod time.Duration, strategy string, loadFactor float64) Option {
+	return func(opts *options) {
+		opts.handoff = &handoffOptions{
+			gracePeriod: gracePeriod,
+			strategy:    strategy,
+			loadFactor:  loadFactor,
+		}
+	}
+}
+
+// WithEjection makes the server eject destinations that fail or respond
+// slowly, as configured by the options, and send the metrics they own to
+// other destinations until they recover. A batch of metrics that fails to
+// forward is retried once, on the destinations its metrics would have if
+// the failing one was ejected.
+func WithEjection(ejection outlier.Options) Option {
+	return func(opts *options) {
+		opts.ejection = &ejection
+	}
+}
+
+// WithServerOptions sets the options of the underlying grpc.Server, like
+// its transport credentials.
+func WithServerOptions(serverOpts ...grpc.ServerOption) Option {
+	return func(opts *options) {
+		opts.serverOpts = append(opts.serverOpts, serverOpts...)
+	}
+}
+
+// WithDialOptions sets the options used to connect to the destinations,
+// instead of the default grpc.WithInsecure.
+func WithDialOptions(dialOpts ...grpc.DialOption) Option {
+	return func(opts *options) {
+		opts.dialOpts = dialOpts
+	}
+}
+
+// WithMirrorName marks the server as the one that forwards the copies of
+// metrics sent to the mirror called name, which tags the metrics about the
+// server with mirror:name.
+func WithMirrorName(name string) Option {
+	return func(opts *options) {
+		opts.mirrorName = name
+	}
+}
+
+// WithMirror makes the server send a copy of the metrics it receives whose
+// name filter accepts to m, which forwards them to its own destinations, in
+// addition to forwarding them to the server's destinations. A nil filter
+// accepts every metric. The name identifies the mirror in the server's
+// metrics. m only needs to be created with New; it doesn't need to serve.
+func WithMirror(name string, m *Server, filter func(name string) bool) Option {
+	return func(opts *options) {
+		opts.mirrors = append(opts.mirrors, mirror{
+			name:   name,
+			server: m,
+			filter: filter,
+		})
+	}
+}
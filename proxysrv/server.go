@@ -1,13 +1,15 @@
 // Package proxysrv proxies metrics over gRPC to global veneurs using
 // consistent hashing
 //
-// The Server provided accepts a hash ring of destinations, and then listens
+// The Server provided accepts a hash ring of destinations (any of the
+// strategies in the hashring package), and then listens
 // for metrics over gRPC.  It hashes each metric to a specific destination,
 // and forwards each metric to its appropriate destination Veneur.
 package proxysrv
 
 import (
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net"
 	"strings"
@@ -20,9 +22,12 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
-	"stathat.com/c/consistent"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/stripe/veneur/forwardrpc"
+	"github.com/stripe/veneur/hashring"
+	"github.com/stripe/veneur/outlier"
 	"github.com/stripe/veneur/samplers"
 	"github.com/stripe/veneur/samplers/metricpb"
 	"github.com/stripe/veneur/ssf"
@@ -43,42 +48,79 @@ const (
 // on the metric name, type and tags.
 type Server struct {
 	*grpc.Server
-	destinations *consistent.Consistent
-	opts         *options
-	conns        *clientConnMap
-	updateMtx    sync.Mutex
+	destinations hashring.Ring
+	// members are the destinations as of the last call to SetDestinations,
+	// which may have updated the ring in place
+	members   []string
+	opts      *options
+	conns     *clientConnMap
+	updateMtx sync.Mutex
+	// ejector is nil unless the server was created with WithEjection
+	ejector *outlier.Detector
 
 	// A simple counter to track the number of goroutines spawned to handle
 	// proxying metrics
 	activeProxyHandlers *int64
+	// inFlight is nil unless the server was created with
+	// WithMaxInFlightBytes
+	inFlight *inFlightBytes
 }
 
 // Option modifies an internal options type.
 type Option func(*options)
 
 type options struct {
-	log            *logrus.Entry
-	forwardTimeout time.Duration
-	traceClient    *trace.Client
-	statsInterval  time.Duration
+	log              *logrus.Entry
+	forwardTimeout   time.Duration
+	forwardBatchSize int
+	maxHandlers      int64
+	maxInFlightBytes int64
+	traceClient      *trace.Client
+	statsInterval    time.Duration
+	handoff          *handoffOptions
+	ejection         *outlier.Options
+	serverOpts       []grpc.ServerOption
+	dialOpts         []grpc.DialOption
+	mirrors          []mirror
+	mirrorName       string
+}
+
+type mirror struct {
+	name   string
+	server *Server
+	filter func(name string) bool
+}
+
+type handoffOptions struct {
+	gracePeriod time.Duration
+	strategy    string
+	loadFactor  float64
 }
 
 // New creates a new Server with the provided destinations. The server returned
 // is unstarted.
-func New(destinations *consistent.Consistent, opts ...Option) (*Server, error) {
+func New(destinations hashring.Ring, opts ...Option) (*Server, error) {
 	res := &Server{
-		Server: grpc.NewServer(),
 		opts: &options{
 			forwardTimeout: defaultForwardTimeout,
 			statsInterval:  defaultReportStatsInterval,
+			dialOpts:       []grpc.DialOption{grpc.WithInsecure()},
 		},
-		conns:               newClientConnMap(grpc.WithInsecure()),
 		activeProxyHandlers: new(int64),
 	}
 
 	for _, opt := range opts {
 		opt(res.opts)
 	}
+	res.Server = grpc.NewServer(res.opts.serverOpts...)
+	res.conns = newClientConnMap(res.opts.dialOpts...)
+
+	if res.opts.ejection != nil {
+		res.ejector = outlier.New(*res.opts.ejection)
+	}
+	if res.opts.maxInFlightBytes > 0 {
+		res.inFlight = newInFlightBytes(res.opts.maxInFlightBytes)
+	}
 
 	if res.opts.log == nil {
 		log := logrus.New()
@@ -145,14 +187,15 @@ func (s *Server) Stop() {
 // This also prunes the list of open connections.  If a connection exists to
 // a host that wasn't in either the current list or the last one, the
 // connection is closed.
-func (s *Server) SetDestinations(dests *consistent.Consistent) error {
+//
+// If the server was created with WithHandoff and the hosts changed, each of
+// the previous hosts is told about the new ring, so that it hands the metrics
+// that moved away from it off to their new destination.
+func (s *Server) SetDestinations(dests hashring.Ring) error {
 	s.updateMtx.Lock()
 	defer s.updateMtx.Unlock()
 
-	var current []string
-	if s.destinations != nil {
-		current = s.destinations.Members()
-	}
+	current := s.members
 	new := dests.Members()
 
 	// for every connection in the map that isn't in either the current or
@@ -171,39 +214,245 @@ func (s *Server) SetDestinations(dests *consistent.Consistent) error {
 		}
 	}
 
+	if s.opts.handoff != nil && s.destinations != nil && !sameMembers(current, new) {
+		go s.announceRing(current, new)
+	}
+
 	s.destinations = dests
+	s.members = new
 	return nil
 }
 
+// announceRing sends the new members of the ring to each of the previous
+// destinations.
+func (s *Server) announceRing(previous, members []string) {
+	ctx := context.Background()
+	if s.opts.forwardTimeout > 0 {
+		var cancel func()
+		ctx, cancel = context.WithTimeout(ctx, s.opts.forwardTimeout)
+		defer cancel()
+	}
+
+	var failed int64
+	wg := sync.WaitGroup{}
+	wg.Add(len(previous))
+	for _, dest := range previous {
+		go func(dest string) {
+			defer wg.Done()
+			err := s.announceRingTo(ctx, dest, members)
+			if err != nil {
+				atomic.AddInt64(&failed, 1)
+				s.opts.log.WithError(err).WithField("destination", dest).
+					Warn("Failed to announce the new ring to a destination")
+			}
+		}(dest)
+	}
+	wg.Wait()
+
+	_ = metrics.ReportBatch(s.opts.traceClient, []*ssf.SSFSample{
+		ssf.Count("proxy.handoff.announcements_total", float32(int64(len(previous))-failed),
+			s.tags(map[string]string{"status": "success", "protocol": "grpc"})),
+		ssf.Count("proxy.handoff.announcements_total", float32(failed),
+			s.tags(map[string]string{"status": "failure", "protocol": "grpc"})),
+	})
+}
+
+func (s *Server) announceRingTo(ctx context.Context, dest string, members []string) error {
+	conn, ok := s.conns.Get(dest)
+	if !ok {
+		return fmt.Errorf("no connection was found for the host '%s'", dest)
+	}
+
+	c := forwardrpc.NewHandoffClient(conn)
+	_, err := c.SetRing(ctx, &forwardrpc.Ring{
+		Self:          dest,
+		Members:       members,
+		Strategy:      s.opts.handoff.strategy,
+		LoadFactor:    s.opts.handoff.loadFactor,
+		GracePeriodNs: s.opts.handoff.gracePeriod.Nanoseconds(),
+	})
+	return err
+}
+
 // SendMetrics spawns a new goroutine that forwards metrics to the destinations
 // and exist immediately.
+//
+// If the server was created with WithMaxActiveHandlers or
+// WithMaxInFlightBytes and accepting the metrics would go over those limits,
+// it rejects them with codes.ResourceExhausted instead, and forwards none of
+// them. The client should send them again later.
 func (s *Server) SendMetrics(ctx context.Context, mlist *forwardrpc.MetricList) (*empty.Empty, error) {
+	// Track the number of active goroutines in a counter
+	if active := atomic.AddInt64(s.activeProxyHandlers, 1); s.opts.maxHandlers > 0 && active > s.opts.maxHandlers {
+		atomic.AddInt64(s.activeProxyHandlers, -1)
+		return nil, s.reject(len(mlist.Metrics), "handlers",
+			"too many metrics are being forwarded")
+	}
+
+	dests, errs := s.route(mlist.Metrics)
+	var sizes map[string]int64
+	if s.inFlight != nil {
+		sizes = batchSizes(dests)
+		if dest, ok := s.inFlight.acquire(sizes); !ok {
+			atomic.AddInt64(s.activeProxyHandlers, -1)
+			return nil, s.reject(len(mlist.Metrics), "bytes",
+				fmt.Sprintf("too many metrics are being forwarded to '%s'", dest))
+		}
+	}
+
 	go func() {
-		// Track the number of active goroutines in a counter
-		atomic.AddInt64(s.activeProxyHandlers, 1)
-		_ = s.sendMetrics(context.Background(), mlist)
+		_ = s.forwardAll(context.Background(), mlist, dests, errs)
+		if s.inFlight != nil {
+			s.inFlight.release(sizes)
+		}
 		atomic.AddInt64(s.activeProxyHandlers, -1)
 	}()
+	s.mirror(mlist)
 	return &empty.Empty{}, nil
 }
 
-func (s *Server) sendMetrics(ctx context.Context, mlist *forwardrpc.MetricList) error {
-	span, _ := trace.StartSpanFromContext(ctx, "veneur.opentracing.proxysrv.send_metrics")
-	defer span.ClientFinish(s.opts.traceClient)
+// reject returns the error that rejects a list of metrics because the server
+// is overloaded, and counts them.
+func (s *Server) reject(numMetrics int, cause, msg string) error {
+	_ = metrics.ReportOne(s.opts.traceClient, ssf.Count("proxy.rejected_metrics_total",
+		float32(numMetrics), s.tags(map[string]string{"cause": cause, "protocol": "grpc"})))
+	return status.Error(codes.ResourceExhausted, msg)
+}
 
-	if s.opts.forwardTimeout > 0 {
-		var cancel func()
-		ctx, cancel = context.WithTimeout(ctx, s.opts.forwardTimeout)
-		defer cancel()
+// mirror sends a copy of the metrics in mlist that each mirror accepts to
+// that mirror. Copies that would put a mirror over its limits are dropped,
+// rather than queued up behind a mirror that doesn't keep up.
+func (s *Server) mirror(mlist *forwardrpc.MetricList) {
+	for _, m := range s.opts.mirrors {
+		var ms []*metricpb.Metric
+		for _, metric := range mlist.Metrics {
+			if m.filter == nil || m.filter(metric.Name) {
+				ms = append(ms, metric)
+			}
+		}
+		if len(ms) == 0 {
+			continue
+		}
+		result := "success"
+		if _, err := m.server.SendMetrics(context.Background(),
+			&forwardrpc.MetricList{Metrics: ms, Timestamp: mlist.Timestamp}); err != nil {
+			result = "dropped"
+		}
+		_ = metrics.ReportOne(s.opts.traceClient, ssf.Count("proxy.mirrored_metrics_total", float32(len(ms)),
+			map[string]string{"mirror": m.name, "protocol": "grpc", "status": result}))
+	}
+}
+
+// SendMetricsStream forwards the lists of metrics in a stream, the way
+// SendMetrics does, once the stream is complete. A stream that fails before
+// it's complete forwards nothing, so the client can send it again as a
+// whole without any of it being counted twice.
+//
+// The stream is admitted or rejected as a whole when it opens: it takes up
+// one of the handlers that WithMaxActiveHandlers allows until all of its
+// lists are forwarded, and with WithMaxInFlightBytes, it's rejected if any
+// destination already has the maximum in flight. The lists of an admitted
+// stream are counted in the bytes in flight as they arrive, but never
+// rejected.
+func (s *Server) SendMetricsStream(stream forwardrpc.Forward_SendMetricsStreamServer) error {
+	if active := atomic.AddInt64(s.activeProxyHandlers, 1); s.opts.maxHandlers > 0 && active > s.opts.maxHandlers {
+		atomic.AddInt64(s.activeProxyHandlers, -1)
+		return s.rejectStream("handlers", "too many metrics are being forwarded")
+	}
+	if s.inFlight != nil {
+		if dest, full := s.inFlight.saturated(); full {
+			atomic.AddInt64(s.activeProxyHandlers, -1)
+			return s.rejectStream("bytes",
+				fmt.Sprintf("too many metrics are being forwarded to '%s'", dest))
+		}
+	}
+
+	var lists []streamedList
+	for {
+		mlist, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			for _, l := range lists {
+				if s.inFlight != nil {
+					s.inFlight.release(l.sizes)
+				}
+			}
+			atomic.AddInt64(s.activeProxyHandlers, -1)
+			return err
+		}
+
+		l := streamedList{mlist: mlist}
+		l.dests, l.errs = s.route(mlist.Metrics)
+		if s.inFlight != nil {
+			l.sizes = batchSizes(l.dests)
+			s.inFlight.add(l.sizes)
+		}
+		lists = append(lists, l)
 	}
-	metrics := mlist.Metrics
-	span.Add(ssf.Count("proxy.metrics_total", float32(len(metrics)), globalProtocolTags))
 
+	// the stream's handler is busy until all of it is forwarded
+	wg := sync.WaitGroup{}
+	wg.Add(len(lists))
+	for _, l := range lists {
+		go func(l streamedList) {
+			defer wg.Done()
+			_ = s.forwardAll(context.Background(), l.mlist, l.dests, l.errs)
+			if s.inFlight != nil {
+				s.inFlight.release(l.sizes)
+			}
+		}(l)
+		s.mirror(l.mlist)
+	}
+	go func() {
+		wg.Wait()
+		atomic.AddInt64(s.activeProxyHandlers, -1)
+	}()
+	return stream.SendAndClose(&empty.Empty{})
+}
+
+// streamedList is a list of metrics that SendMetricsStream holds on to, with
+// its routes, until the stream is complete.
+type streamedList struct {
+	mlist *forwardrpc.MetricList
+	dests map[string][]*metricpb.Metric
+	errs  forwardErrors
+	sizes map[string]int64
+}
+
+// rejectStream returns the error that rejects a stream of metrics because
+// the server is overloaded, and counts it.
+func (s *Server) rejectStream(cause, msg string) error {
+	_ = metrics.ReportOne(s.opts.traceClient, ssf.Count("proxy.rejected_streams_total",
+		1, s.tags(map[string]string{"cause": cause, "protocol": "grpc"})))
+	return status.Error(codes.ResourceExhausted, msg)
+}
+
+// batchSizes returns the size of the metrics going to each destination.
+func batchSizes(dests map[string][]*metricpb.Metric) map[string]int64 {
+	sizes := make(map[string]int64, len(dests))
+	for dest, batch := range dests {
+		for _, metric := range batch {
+			sizes[dest] += int64(metric.Size())
+		}
+	}
+	return sizes
+}
+
+func (s *Server) sendMetrics(ctx context.Context, mlist *forwardrpc.MetricList) error {
+	dests, errs := s.route(mlist.Metrics)
+	return s.forwardAll(ctx, mlist, dests, errs)
+}
+
+// route groups metrics by their destination.
+func (s *Server) route(metrics []*metricpb.Metric) (map[string][]*metricpb.Metric, forwardErrors) {
 	var errs forwardErrors
 
+	router := s.ejector.Router(s.destinations)
 	dests := make(map[string][]*metricpb.Metric)
 	for _, metric := range metrics {
-		dest, err := s.destForMetric(metric)
+		dest, err := s.destForMetric(router, metric)
 		if err != nil {
 			errs = append(errs, forwardError{err: err, cause: "no-destination",
 				msg: "failed to get a destination for a metric", numMetrics: 1})
@@ -215,6 +464,23 @@ func (s *Server) sendMetrics(ctx context.Context, mlist *forwardrpc.MetricList)
 			dests[dest] = append(dests[dest], metric)
 		}
 	}
+	return dests, errs
+}
+
+// forwardAll forwards the metrics in mlist, grouped by route, to their
+// destinations, and reports the errors that routing them returned along
+// with its own.
+func (s *Server) forwardAll(ctx context.Context, mlist *forwardrpc.MetricList, dests map[string][]*metricpb.Metric, errs forwardErrors) error {
+	span, _ := trace.StartSpanFromContext(ctx, "veneur.opentracing.proxysrv.send_metrics")
+	defer span.ClientFinish(s.opts.traceClient)
+
+	if s.opts.forwardTimeout > 0 {
+		var cancel func()
+		ctx, cancel = context.WithTimeout(ctx, s.opts.forwardTimeout)
+		defer cancel()
+	}
+	metrics := mlist.Metrics
+	span.Add(ssf.Count("proxy.metrics_total", float32(len(metrics)), s.tags(globalProtocolTags)))
 
 	// Wait for all of the forward to finish
 	wg := sync.WaitGroup{}
@@ -224,10 +490,23 @@ func (s *Server) sendMetrics(ctx context.Context, mlist *forwardrpc.MetricList)
 	for dest, batch := range dests {
 		go func(dest string, batch []*metricpb.Metric) {
 			defer wg.Done()
-			if err := s.forward(ctx, dest, batch); err != nil {
+			err := s.forward(ctx, dest, batch, mlist.Timestamp)
+			if err == nil {
+				return
+			}
+			// the metrics that were delivered before the forward failed
+			// mustn't be sent twice
+			unsent := forwardrpc.Unsent(err, batch)
+			if s.ejector == nil {
 				msg := fmt.Sprintf("failed to forward to the host '%s'", dest)
 				errCh <- forwardError{err: err, cause: "forward", msg: msg,
-					numMetrics: len(batch)}
+					numMetrics: len(unsent)}
+				return
+			}
+			if failed, err := s.retry(dest, unsent, mlist.Timestamp); err != nil {
+				msg := fmt.Sprintf("failed to retry forwarding to hosts other than '%s'", dest)
+				errCh <- forwardError{err: err, cause: "retry", msg: msg,
+					numMetrics: failed}
 			}
 		}(dest, batch)
 	}
@@ -244,8 +523,8 @@ func (s *Server) sendMetrics(ctx context.Context, mlist *forwardrpc.MetricList)
 
 	span.Add(ssf.RandomlySample(0.1,
 		ssf.Timing("proxy.duration_ns", time.Since(span.Start), time.Nanosecond,
-			protocolTags),
-		ssf.Count("proxy.proxied_metrics_total", float32(len(metrics)), protocolTags),
+			s.tags(protocolTags)),
+		ssf.Count("proxy.proxied_metrics_total", float32(len(metrics)), s.tags(protocolTags)),
 	)...)
 
 	var res error
@@ -257,7 +536,7 @@ func (s *Server) sendMetrics(ctx context.Context, mlist *forwardrpc.MetricList)
 	if len(errs) > 0 {
 		// if there were errors, report stats and log them
 		for _, err := range errs {
-			err.reportMetrics(span)
+			err.reportMetrics(span, s)
 		}
 		res = errs
 		log.WithError(res).Error("Proxying failed")
@@ -270,9 +549,9 @@ func (s *Server) sendMetrics(ctx context.Context, mlist *forwardrpc.MetricList)
 }
 
 // destForMetric returns a destination for the input metric.
-func (s *Server) destForMetric(m *metricpb.Metric) (string, error) {
+func (s *Server) destForMetric(router *outlier.Router, m *metricpb.Metric) (string, error) {
 	key := samplers.NewMetricKeyFromMetric(m)
-	dest, err := s.destinations.Get(key.String())
+	dest, err := router.Get(key.String())
 	if err != nil {
 		return "", fmt.Errorf("failed to hash the MetricKey '%s' to a "+
 			"destination: %v", key.String(), err)
@@ -282,15 +561,29 @@ func (s *Server) destForMetric(m *metricpb.Metric) (string, error) {
 }
 
 // forward sends a set of metrics to the destination address, and returns
-// an error if necessary.
-func (s *Server) forward(ctx context.Context, dest string, ms []*metricpb.Metric) (err error) {
+// an error if necessary, which is a *forwardrpc.PartialError if some of them
+// were delivered. The timestamp of lists replayed from a forward spool is
+// passed along.
+func (s *Server) forward(ctx context.Context, dest string, ms []*metricpb.Metric, timestamp int64) (err error) {
 	conn, ok := s.conns.Get(dest)
 	if !ok {
 		return fmt.Errorf("no connection was found for the host '%s'", dest)
 	}
 
 	c := forwardrpc.NewForwardClient(conn)
-	_, err = c.SendMetrics(ctx, &forwardrpc.MetricList{Metrics: ms})
+	start := time.Now()
+	err = forwardrpc.Send(ctx, c, ms, timestamp, s.opts.forwardBatchSize)
+	if s.ejector.Observe(dest, time.Since(start), err) {
+		s.opts.log.WithError(err).WithField("destination", dest).
+			Warn("Ejected a failing destination")
+		_ = metrics.ReportOne(s.opts.traceClient,
+			ssf.Count("proxy.ejections_total", 1, s.tags(protocolTags)))
+	}
+	if perr, ok := err.(*forwardrpc.PartialError); ok {
+		perr.Err = fmt.Errorf("failed to send %d of %d metrics over gRPC: %v",
+			len(perr.Unsent), len(ms), perr.Err)
+		return perr
+	}
 	if err != nil {
 		return fmt.Errorf("failed to send %d metrics over gRPC: %v",
 			len(ms), err)
@@ -298,16 +591,79 @@ func (s *Server) forward(ctx context.Context, dest string, ms []*metricpb.Metric
 
 	_ = metrics.ReportBatch(s.opts.traceClient, ssf.RandomlySample(0.1,
 		ssf.Count("metrics_by_destination", float32(len(ms)),
-			map[string]string{"destination": dest, "protocol": "grpc"}),
+			s.tags(map[string]string{"destination": dest, "protocol": "grpc"})),
 	))
 
 	return nil
 }
 
+// retry forwards a batch of metrics that failed to go to dest once more, to
+// the destinations that they would have if dest was ejected. It returns the
+// number of metrics that failed again.
+func (s *Server) retry(dest string, ms []*metricpb.Metric, timestamp int64) (int, error) {
+	ctx := context.Background()
+	if s.opts.forwardTimeout > 0 {
+		var cancel func()
+		ctx, cancel = context.WithTimeout(ctx, s.opts.forwardTimeout)
+		defer cancel()
+	}
+
+	router := s.ejector.Router(s.destinations, dest)
+	dests := make(map[string][]*metricpb.Metric)
+	for _, metric := range ms {
+		retryDest, err := s.destForMetric(router, metric)
+		if err != nil {
+			return len(ms), err
+		}
+		dests[retryDest] = append(dests[retryDest], metric)
+	}
+
+	var (
+		failed  int
+		lastErr error
+	)
+	for retryDest, batch := range dests {
+		if err := s.forward(ctx, retryDest, batch, timestamp); err != nil {
+			failed += len(forwardrpc.Unsent(err, batch))
+			lastErr = err
+		}
+	}
+	_ = metrics.ReportBatch(s.opts.traceClient, []*ssf.SSFSample{
+		ssf.Count("proxy.retried_metrics_total", float32(len(ms)-failed),
+			s.tags(map[string]string{"status": "success", "protocol": "grpc"})),
+		ssf.Count("proxy.retried_metrics_total", float32(failed),
+			s.tags(map[string]string{"status": "failure", "protocol": "grpc"})),
+	})
+	return failed, lastErr
+}
+
 // reportStats reports statistics about the server to the internal trace client
 func (s *Server) reportStats() {
-	_ = metrics.ReportOne(s.opts.traceClient,
-		ssf.Gauge("proxy.active_goroutines", float32(atomic.LoadInt64(s.activeProxyHandlers)), globalProtocolTags))
+	samples := []*ssf.SSFSample{
+		ssf.Gauge("proxy.active_goroutines", float32(atomic.LoadInt64(s.activeProxyHandlers)), s.tags(globalProtocolTags)),
+	}
+	if s.ejector != nil {
+		samples = append(samples, ssf.Gauge("proxy.ejected_destinations",
+			float32(len(s.ejector.Ejected())), s.tags(globalProtocolTags)))
+	}
+	if s.inFlight != nil {
+		samples = append(samples, ssf.Gauge("proxy.in_flight_bytes",
+			float32(s.inFlight.total()), s.tags(globalProtocolTags)))
+	}
+	_ = metrics.ReportBatch(s.opts.traceClient, samples)
+}
+
+// sameMembers returns whether a and b have the same members, in any order.
+func sameMembers(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, member := range a {
+		if !strInSlice(member, b) {
+			return false
+		}
+	}
+	return true
 }
 
 func strInSlice(s string, slice []string) bool {
@@ -335,12 +691,12 @@ func (e forwardError) Error() string {
 		e.numMetrics, e.err)
 }
 
-// reportMetrics adds various metrics to an input span.
-func (e forwardError) reportMetrics(span *trace.Span) {
-	tags := map[string]string{
+// reportMetrics adds various metrics about the server s to an input span.
+func (e forwardError) reportMetrics(span *trace.Span, s *Server) {
+	tags := s.tags(map[string]string{
 		"cause":    e.cause,
 		"protocol": "grpc",
-	}
+	})
 	span.Add(
 		ssf.Count("proxy.proxied_metrics_failed", float32(e.numMetrics), tags),
 		ssf.Count("proxy.forward_errors", 1, tags),
@@ -373,6 +729,20 @@ func (errs forwardErrors) Error() string {
 	return str
 }
 
+// tags returns the tags of a metric about the server: tags, along with the
+// mirror tag if the server forwards to a mirror.
+func (s *Server) tags(tags map[string]string) map[string]string {
+	if s.opts.mirrorName == "" {
+		return tags
+	}
+	res := make(map[string]string, len(tags)+1)
+	for k, v := range tags {
+		res[k] = v
+	}
+	res["mirror"] = s.opts.mirrorName
+	return res
+}
+
 // protocolTags and globalProtocolTags can be used as arguments to various
 // ssf metric types.  These are declared at the package-level  here to avoid
 // repeated operation allocations per request.
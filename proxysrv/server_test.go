@@ -4,16 +4,24 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"net"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stripe/veneur/forwardrpc"
+	"github.com/stripe/veneur/hashring"
 	"github.com/stripe/veneur/internal/forwardtest"
+	"github.com/stripe/veneur/outlier"
 	"github.com/stripe/veneur/samplers/metricpb"
 	metrictest "github.com/stripe/veneur/samplers/metricpb/testutils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"stathat.com/c/consistent"
 )
 
@@ -55,7 +63,7 @@ func TestManyDestinations(t *testing.T) {
 		expected := metrictest.RandomForwardMetrics(100)
 
 		server := newServer(t, ring)
-		err := server.sendMetrics(context.Background(), &forwardrpc.MetricList{expected})
+		err := server.sendMetrics(context.Background(), &forwardrpc.MetricList{Metrics: expected})
 		assert.NoError(t, err, "sendMetrics shouldn't have failed")
 
 		assert.ElementsMatch(t, expected, actual)
@@ -65,7 +73,7 @@ func TestManyDestinations(t *testing.T) {
 func TestNoDestinations(t *testing.T) {
 	server := newServer(t, consistent.New())
 	err := server.sendMetrics(context.Background(),
-		&forwardrpc.MetricList{metrictest.RandomForwardMetrics(10)})
+		&forwardrpc.MetricList{Metrics: metrictest.RandomForwardMetrics(10)})
 	assert.Error(t, err, "sendMetrics should have returned an error when there "+
 		"are no valid destinations")
 }
@@ -77,7 +85,7 @@ func TestUnreachableDestinations(t *testing.T) {
 
 	server := newServer(t, ring, WithForwardTimeout(500*time.Millisecond))
 	err := server.sendMetrics(context.Background(),
-		&forwardrpc.MetricList{metrictest.RandomForwardMetrics(10)})
+		&forwardrpc.MetricList{Metrics: metrictest.RandomForwardMetrics(10)})
 	assert.Error(t, err, "sendMetrics should have returned an error when all "+
 		"of the destinations are unreachable")
 }
@@ -91,7 +99,7 @@ func TestTimeout(t *testing.T) {
 
 	server := newServer(t, ring, WithForwardTimeout(1*time.Nanosecond))
 	err := server.sendMetrics(context.Background(),
-		&forwardrpc.MetricList{metrictest.RandomForwardMetrics(10)})
+		&forwardrpc.MetricList{Metrics: metrictest.RandomForwardMetrics(10)})
 	assert.Error(t, err, "sendMetrics should have returned an error when the "+
 		"timeout was set to effectively zero")
 }
@@ -125,7 +133,7 @@ func TestSetDestinations(t *testing.T) {
 	server := newServer(t, ring)
 	defer server.Stop()
 	err := server.sendMetrics(context.Background(),
-		&forwardrpc.MetricList{metrictest.RandomForwardMetrics(10)})
+		&forwardrpc.MetricList{Metrics: metrictest.RandomForwardMetrics(10)})
 	assert.NoError(t, err, "sendMetrics should not have returned an error")
 	assert.True(t, receivedByOriginal, "the original set of servers should have gotten some requests, but didn't")
 	assert.False(t, receivedByNew, "the new servers shouldn't have gotten RPCs")
@@ -135,7 +143,7 @@ func TestSetDestinations(t *testing.T) {
 	ring.Set(addrsFromServers(new))
 	assert.NoError(t, server.SetDestinations(ring), "setting the destinations failed")
 	err = server.sendMetrics(context.Background(),
-		&forwardrpc.MetricList{metrictest.RandomForwardMetrics(10)})
+		&forwardrpc.MetricList{Metrics: metrictest.RandomForwardMetrics(10)})
 	assert.NoError(t, err, "sendMetrics should not have returned an error")
 	assert.True(t, receivedByNew, "the new servers should have had RPCs")
 	assert.False(t, receivedByOriginal, "the old servers should not have gotten RPCs")
@@ -147,12 +155,143 @@ func TestSetDestinations(t *testing.T) {
 	ring.Set(addrsFromServers(both))
 	assert.NoError(t, server.SetDestinations(ring), "setting the destinations failed")
 	err = server.sendMetrics(context.Background(),
-		&forwardrpc.MetricList{metrictest.RandomForwardMetrics(100)})
+		&forwardrpc.MetricList{Metrics: metrictest.RandomForwardMetrics(100)})
 	assert.NoError(t, err, "sendMetrics should not have returned an error")
 	assert.True(t, receivedByNew, "the new servers should have had RPCs")
 	assert.True(t, receivedByOriginal, "the old servers should have gotten RPCs")
 }
 
+func TestSetDestinationsAnnouncesRing(t *testing.T) {
+	rings := make(chan *forwardrpc.Ring, 10)
+	dests := make([]*forwardtest.Server, 3)
+	for i := range dests {
+		dests[i] = forwardtest.NewHandoffServer(nil, func(ring *forwardrpc.Ring) {
+			rings <- ring
+		})
+		dests[i].Start(t)
+	}
+	defer stopTestForwardServers(dests)
+
+	ring := hashring.NewRendezvous()
+	ring.Set(addrsFromServers(dests))
+	server := newServer(t, ring, WithHandoff(time.Minute, hashring.RendezvousHashing, 0))
+	defer server.Stop()
+
+	// the ring is updated in place, the way veneur-proxy does it
+	members := addrsFromServers(dests[:2])
+	ring.Set(members)
+	assert.NoError(t, server.SetDestinations(ring), "setting the destinations failed")
+
+	// every previous destination hears about the change, including the
+	// one that was removed
+	var selves []string
+	for range dests {
+		select {
+		case r := <-rings:
+			selves = append(selves, r.Self)
+			assert.ElementsMatch(t, members, r.Members)
+			assert.Equal(t, hashring.RendezvousHashing, r.Strategy)
+			assert.Equal(t, time.Minute.Nanoseconds(), r.GracePeriodNs)
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for the ring to be announced")
+		}
+	}
+	assert.ElementsMatch(t, addrsFromServers(dests), selves)
+
+	// setting the same members again isn't a change
+	assert.NoError(t, server.SetDestinations(ring), "setting the destinations failed")
+	select {
+	case r := <-rings:
+		t.Fatalf("An unchanged ring was announced to %s", r.Self)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestEjection(t *testing.T) {
+	var actual []*metricpb.Metric
+	var mtx sync.Mutex
+	dests := createTestForwardServers(t, 4, func(ms []*metricpb.Metric) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		actual = append(actual, ms...)
+	})
+	defer stopTestForwardServers(dests)
+
+	ring := hashring.NewRendezvous()
+	ring.Set(addrsFromServers(dests))
+	server := newServer(t, ring, WithEjection(outlier.Options{ConsecutiveFailures: 1}))
+	defer server.Stop()
+
+	dead := dests[0].Addr().String()
+	dests[0].Stop()
+
+	// the batch for the dead destination is retried elsewhere
+	expected := metrictest.RandomForwardMetrics(100)
+	err := server.sendMetrics(context.Background(), &forwardrpc.MetricList{Metrics: expected})
+	assert.NoError(t, err, "the failed batch should have been retried")
+	assert.ElementsMatch(t, expected, actual)
+	assert.Equal(t, []string{dead}, server.ejector.Ejected())
+
+	// and it isn't sent anything after it was ejected
+	actual = nil
+	expected = metrictest.RandomForwardMetrics(100)
+	err = server.sendMetrics(context.Background(), &forwardrpc.MetricList{Metrics: expected})
+	assert.NoError(t, err, "the ejected destination should have been skipped")
+	assert.ElementsMatch(t, expected, actual)
+	server.reportStats()
+}
+
+func TestMirror(t *testing.T) {
+	var mtx sync.Mutex
+	var primary, mirrored []*metricpb.Metric
+	record := func(ms *[]*metricpb.Metric) forwardtest.SendMetricHandler {
+		return func(received []*metricpb.Metric) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			*ms = append(*ms, received...)
+		}
+	}
+	primaryDests := createTestForwardServers(t, 2, record(&primary))
+	defer stopTestForwardServers(primaryDests)
+	mirrorDests := createTestForwardServers(t, 2, record(&mirrored))
+	defer stopTestForwardServers(mirrorDests)
+
+	mirrorRing := consistent.New()
+	mirrorRing.Set(addrsFromServers(mirrorDests))
+	mirror := newServer(t, mirrorRing)
+	defer mirror.Stop()
+
+	ring := consistent.New()
+	ring.Set(addrsFromServers(primaryDests))
+	server := newServer(t, ring, WithMirror("shadow", mirror, func(name string) bool {
+		return strings.HasPrefix(name, "api.")
+	}))
+
+	input := []*metricpb.Metric{
+		{Name: "api.requests", Type: metricpb.Type_Counter},
+		{Name: "db.queries", Type: metricpb.Type_Counter},
+	}
+	_, err := server.SendMetrics(context.Background(), &forwardrpc.MetricList{Metrics: input})
+	require.NoError(t, err)
+
+	// both forwards happen asynchronously
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mtx.Lock()
+		done := len(primary) == 2 && len(mirrored) == 1
+		mtx.Unlock()
+		if done || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	mtx.Lock()
+	defer mtx.Unlock()
+	assert.Len(t, primary, 2)
+	require.Len(t, mirrored, 1)
+	assert.Equal(t, "api.requests", mirrored[0].Name)
+}
+
 func TestCountActiveHandlers(t *testing.T) {
 	t.Parallel()
 
@@ -172,7 +311,7 @@ func TestCountActiveHandlers(t *testing.T) {
 			ring := consistent.New()
 			ring.Set(addrsFromServers(blocking))
 
-			metrics := &forwardrpc.MetricList{metrictest.RandomForwardMetrics(100)}
+			metrics := &forwardrpc.MetricList{Metrics: metrictest.RandomForwardMetrics(100)}
 			s := newServer(t, ring, WithStatsInterval(10*time.Nanosecond))
 
 			// Make the specified number of calls, all of these should spawn
@@ -222,6 +361,125 @@ func TestCountActiveHandlers(t *testing.T) {
 	}
 }
 
+func TestBackpressure(t *testing.T) {
+	metrics := &forwardrpc.MetricList{Metrics: metrictest.RandomForwardMetrics(10)}
+	var size int64
+	for _, m := range metrics.Metrics {
+		size += int64(m.Size())
+	}
+
+	for name, opt := range map[string]Option{
+		"handlers": WithMaxActiveHandlers(2),
+		"bytes":    WithMaxInFlightBytes(2 * size),
+	} {
+		t.Run(name, func(t *testing.T) {
+			done := make(chan struct{})
+			blocking := createTestForwardServers(t, 1, func(_ []*metricpb.Metric) {
+				<-done
+			})
+			defer stopTestForwardServers(blocking)
+			ring := consistent.New()
+			ring.Set(addrsFromServers(blocking))
+			s := newServer(t, ring, opt)
+
+			for i := 0; i < 2; i++ {
+				_, err := s.SendMetrics(context.Background(), metrics)
+				require.NoError(t, err, "the server isn't at its limit yet")
+			}
+			_, err := s.SendMetrics(context.Background(), metrics)
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+			assert.Equal(t, int64(2), atomic.LoadInt64(s.activeProxyHandlers),
+				"the rejected metrics shouldn't be forwarded")
+
+			// once the forwards complete, the server accepts metrics again
+			close(done)
+			for i := 0; i < 300 && atomic.LoadInt64(s.activeProxyHandlers) != 0; i++ {
+				time.Sleep(10 * time.Millisecond)
+			}
+			_, err = s.SendMetrics(context.Background(), metrics)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// serve serves s on a local port, and returns a client connected to it.
+func serve(t *testing.T, s *Server) (forwardrpc.ForwardClient, func()) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go s.Server.Serve(ln)
+	conn, err := grpc.Dial(ln.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	return forwardrpc.NewForwardClient(conn), func() {
+		conn.Close()
+		s.Stop()
+	}
+}
+
+func TestStreamForwardsWhenComplete(t *testing.T) {
+	var received int64
+	dests := createTestForwardServers(t, 1, func(ms []*metricpb.Metric) {
+		atomic.AddInt64(&received, int64(len(ms)))
+	})
+	defer stopTestForwardServers(dests)
+	ring := consistent.New()
+	ring.Set(addrsFromServers(dests))
+	s := newServer(t, ring, WithMaxInFlightBytes(1<<20))
+	client, stop := serve(t, s)
+	defer stop()
+
+	// a stream that breaks partway forwards none of its lists
+	ms := metrictest.RandomForwardMetrics(10)
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.SendMetricsStream(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&forwardrpc.MetricList{Metrics: ms[:5]}))
+	for i := 0; i < 300 && s.inFlight.total() == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.NotZero(t, s.inFlight.total(), "the first list should have arrived")
+	cancel()
+	for i := 0; i < 300 && atomic.LoadInt64(s.activeProxyHandlers) != 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Zero(t, atomic.LoadInt64(&received))
+	assert.Zero(t, s.inFlight.total())
+
+	// so sending all of it again forwards each metric once
+	require.NoError(t, forwardrpc.Send(context.Background(), client, ms, 0, 5))
+	for i := 0; i < 300 && atomic.LoadInt64(s.activeProxyHandlers) != 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, int64(10), atomic.LoadInt64(&received))
+}
+
+func TestStreamBackpressure(t *testing.T) {
+	dests := createTestForwardServers(t, 1, func(_ []*metricpb.Metric) {})
+	defer stopTestForwardServers(dests)
+	ring := consistent.New()
+	ring.Set(addrsFromServers(dests))
+	s := newServer(t, ring, WithMaxActiveHandlers(1))
+	client, stop := serve(t, s)
+	defer stop()
+
+	// an open stream takes up the only handler
+	open, err := client.SendMetricsStream(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, open.Send(&forwardrpc.MetricList{Metrics: metrictest.RandomForwardMetrics(1)}))
+	for i := 0; i < 300 && atomic.LoadInt64(s.activeProxyHandlers) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	err = forwardrpc.Send(context.Background(), client, metrictest.RandomForwardMetrics(10), 0, 2)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err), "a stream should be rejected when it opens")
+
+	_, err = open.CloseAndRecv()
+	require.NoError(t, err)
+	for i := 0; i < 300 && atomic.LoadInt64(s.activeProxyHandlers) != 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.NoError(t, forwardrpc.Send(context.Background(), client, metrictest.RandomForwardMetrics(10), 0, 2))
+}
+
 func BenchmarkProxyServerSendMetrics(b *testing.B) {
 	// Use a consistent seed for predictably comparable results
 	rand.Seed(1522191080)
@@ -256,7 +514,43 @@ func addrsFromServers(a []*forwardtest.Server) []string {
 	return res
 }
 
-func newServer(t testing.TB, ring *consistent.Consistent, opts ...Option) *Server {
+func TestMirrorDropsOverItsLimits(t *testing.T) {
+	// a mirror destination that never responds, so forwards to it stay
+	// active
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	mirrorRing := consistent.New()
+	mirrorRing.Set([]string{ln.Addr().String()})
+	mirror := newServer(t, mirrorRing, WithMirrorName("shadow"), WithMaxActiveHandlers(1),
+		WithForwardTimeout(5*time.Second))
+	defer mirror.Stop()
+
+	dests := createTestForwardServers(t, 1, func(_ []*metricpb.Metric) {})
+	defer stopTestForwardServers(dests)
+	ring := consistent.New()
+	ring.Set(addrsFromServers(dests))
+	s := newServer(t, ring, WithMirror("shadow", mirror, nil))
+
+	for i := 0; i < 5; i++ {
+		_, err := s.SendMetrics(context.Background(),
+			&forwardrpc.MetricList{Metrics: metrictest.RandomForwardMetrics(1)})
+		assert.NoError(t, err, "a slow mirror shouldn't hold up the main destinations")
+	}
+	assert.Equal(t, int64(1), atomic.LoadInt64(mirror.activeProxyHandlers),
+		"the copies over the mirror's limit should be dropped")
+}
+
+func TestMirrorNameTagsMetrics(t *testing.T) {
+	s := newServer(t, consistent.New())
+	assert.Equal(t, protocolTags, s.tags(protocolTags))
+
+	mirror := newServer(t, consistent.New(), WithMirrorName("shadow"))
+	assert.Equal(t, map[string]string{"protocol": "grpc", "mirror": "shadow"}, mirror.tags(protocolTags))
+	assert.Equal(t, map[string]string{"protocol": "grpc"}, protocolTags, "the shared tags shouldn't change")
+}
+
+func newServer(t testing.TB, ring hashring.Ring, opts ...Option) *Server {
 	s, err := New(ring, opts...)
 	assert.NoError(t, err, "creating a server shouldn't have returned an error")
 	return s
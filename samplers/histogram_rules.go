@@ -0,0 +1,113 @@
+package samplers
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+)
+
+// A HistogramRule overrides the percentiles and aggregates flushed for the
+// histograms, timers and distributions whose name and tags it matches.
+type HistogramRule struct {
+	glob  string
+	regex *regexp.Regexp
+	tags  []string
+
+	// Percentiles replaces the default percentiles, unless it is nil.
+	Percentiles []float64
+	// Aggregates replaces the default aggregates, unless it is nil.
+	Aggregates *HistogramAggregates
+}
+
+// NewHistogramRule creates a rule for the metrics whose name matches either
+// the glob (as in path.Match) or the regular expression, and that carry
+// every one of tags. If both glob and regex are empty, the rule matches
+// metrics of any name. A nil percentiles or aggregates list keeps the
+// default for that setting.
+func NewHistogramRule(glob, regex string, tags []string, percentiles []float64, aggregates []string) (HistogramRule, error) {
+	rule := HistogramRule{glob: glob, tags: tags, Percentiles: percentiles}
+	switch {
+	case glob != "" && regex != "":
+		return rule, fmt.Errorf("histogram rule can't have both a glob %q and a regex %q", glob, regex)
+	case glob != "":
+		if _, err := path.Match(glob, ""); err != nil {
+			return rule, fmt.Errorf("invalid histogram rule glob %q: %v", glob, err)
+		}
+	case regex != "":
+		var err error
+		rule.regex, err = regexp.Compile(regex)
+		if err != nil {
+			return rule, fmt.Errorf("invalid histogram rule regex %q: %v", regex, err)
+		}
+	}
+
+	for _, p := range percentiles {
+		if p < 0 || p > 1 {
+			return rule, fmt.Errorf("histogram rule percentile %v is out of bounds", p)
+		}
+	}
+	if aggregates != nil {
+		rule.Aggregates = &HistogramAggregates{}
+		for _, name := range aggregates {
+			agg, ok := AggregatesLookup[name]
+			if !ok {
+				return rule, fmt.Errorf("unknown histogram rule aggregate %q", name)
+			}
+			rule.Aggregates.Value |= agg
+			rule.Aggregates.Count++
+		}
+	}
+	return rule, nil
+}
+
+// Matches returns true if the rule applies to a metric with the given name
+// and tags.
+func (hr HistogramRule) Matches(name string, tags []string) bool {
+	switch {
+	case hr.glob != "":
+		if ok, _ := path.Match(hr.glob, name); !ok {
+			return false
+		}
+	case hr.regex != nil:
+		if !hr.regex.MatchString(name) {
+			return false
+		}
+	}
+
+	for _, want := range hr.tags {
+		found := false
+		for _, tag := range tags {
+			if tag == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// HistogramRules are checked in order, and the first one that matches a
+// metric decides its percentiles and aggregates.
+type HistogramRules []HistogramRule
+
+// For returns the percentiles and aggregates that a histogram with the
+// given name and tags should flush, falling back to the given defaults for
+// anything the matching rule doesn't set.
+func (rules HistogramRules) For(name string, tags []string, percentiles []float64, aggregates HistogramAggregates) ([]float64, HistogramAggregates) {
+	for _, rule := range rules {
+		if !rule.Matches(name, tags) {
+			continue
+		}
+		if rule.Percentiles != nil {
+			percentiles = rule.Percentiles
+		}
+		if rule.Aggregates != nil {
+			aggregates = *rule.Aggregates
+		}
+		break
+	}
+	return percentiles, aggregates
+}
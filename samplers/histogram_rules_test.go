@@ -0,0 +1,65 @@
+package samplers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistogramRules(t *testing.T) {
+	defaultPercentiles := []float64{0.5, 0.99}
+	defaultAggregates := HistogramAggregates{Value: AggregateCount, Count: 1}
+
+	tail, err := NewHistogramRule("api.*.latency", "", []string{"service:checkout"}, []float64{0.99, 0.999}, nil)
+	require.NoError(t, err)
+	quiet, err := NewHistogramRule("", `^db\.(reads|writes)$`, nil, []float64{}, []string{"min", "max"})
+	require.NoError(t, err)
+	rules := HistogramRules{tail, quiet}
+
+	ps, aggs := rules.For("api.charge.latency", []string{"env:prod", "service:checkout"}, defaultPercentiles, defaultAggregates)
+	assert.Equal(t, []float64{0.99, 0.999}, ps)
+	assert.Equal(t, defaultAggregates, aggs, "unset aggregates should keep the default")
+
+	ps, aggs = rules.For("api.charge.latency", []string{"service:billing"}, defaultPercentiles, defaultAggregates)
+	assert.Equal(t, defaultPercentiles, ps, "every tag of a rule must be present")
+	assert.Equal(t, defaultAggregates, aggs)
+
+	ps, aggs = rules.For("db.reads", nil, defaultPercentiles, defaultAggregates)
+	assert.Empty(t, ps, "an empty list should disable percentiles")
+	assert.Equal(t, HistogramAggregates{Value: AggregateMin | AggregateMax, Count: 2}, aggs)
+
+	ps, _ = rules.For("db.reads.slow", nil, defaultPercentiles, defaultAggregates)
+	assert.Equal(t, defaultPercentiles, ps)
+
+	// only the first matching rule applies:
+	everything, err := NewHistogramRule("", "", nil, []float64{0.75}, []string{"sum"})
+	require.NoError(t, err)
+	ps, aggs = HistogramRules{everything, tail}.For("api.charge.latency", []string{"service:checkout"}, defaultPercentiles, defaultAggregates)
+	assert.Equal(t, []float64{0.75}, ps)
+	assert.Equal(t, HistogramAggregates{Value: AggregateSum, Count: 1}, aggs)
+}
+
+func TestNewHistogramRuleErrors(t *testing.T) {
+	_, err := NewHistogramRule("a.*", "^a", nil, nil, nil)
+	assert.Error(t, err, "a rule can't have both a glob and a regex")
+	_, err = NewHistogramRule("a.[", "", nil, nil, nil)
+	assert.Error(t, err)
+	_, err = NewHistogramRule("", "a(", nil, nil, nil)
+	assert.Error(t, err)
+	_, err = NewHistogramRule("", "", nil, []float64{99}, nil)
+	assert.Error(t, err)
+	_, err = NewHistogramRule("", "", nil, nil, []string{"p99"})
+	assert.Error(t, err)
+}
+
+func TestHistoFlushFractionalPercentiles(t *testing.T) {
+	h := NewHist("a.b.c", nil)
+	h.Sample(1, 1.0)
+
+	var names []string
+	for _, m := range h.Flush(0, []float64{0.5, 0.999, 0.9999}, HistogramAggregates{}, true) {
+		names = append(names, m.Name)
+	}
+	assert.Equal(t, []string{"a.b.c.50percentile", "a.b.c.99_9percentile", "a.b.c.99_99percentile"}, names)
+}
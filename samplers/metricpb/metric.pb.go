@@ -7,6 +7,7 @@ import (
 	encoding_binary "encoding/binary"
 	fmt "fmt"
 	proto "github.com/gogo/protobuf/proto"
+	ddsketch "github.com/stripe/veneur/ddsketch"
 	tdigest "github.com/stripe/veneur/tdigest"
 	io "io"
 	math "math"
@@ -61,6 +62,9 @@ const (
 	Type_Histogram Type = 2
 	Type_Set       Type = 3
 	Type_Timer     Type = 4
+	// Distribution is a histogram that is always aggregated globally, like
+	// DogStatsD's distribution type.
+	Type_Distribution Type = 5
 )
 
 var Type_name = map[int32]string{
@@ -69,14 +73,16 @@ var Type_name = map[int32]string{
 	2: "Histogram",
 	3: "Set",
 	4: "Timer",
+	5: "Distribution",
 }
 
 var Type_value = map[string]int32{
-	"Counter":   0,
-	"Gauge":     1,
-	"Histogram": 2,
-	"Set":       3,
-	"Timer":     4,
+	"Counter":      0,
+	"Gauge":        1,
+	"Histogram":    2,
+	"Set":          3,
+	"Timer":        4,
+	"Distribution": 5,
 }
 
 func (x Type) String() string {
@@ -425,10 +431,12 @@ func (m *GaugeValue) GetValue() float64 {
 	return 0
 }
 
-// HistogramValue for now just includes the t-digest.  This can be expanded
-// to include the other values such as the sum, average, etc.
+// HistogramValue holds the quantile sketch of a histogram: either a t-digest
+// or a DDSketch.  This can be expanded to include the other values such as
+// the sum, average, etc.
 type HistogramValue struct {
-	TDigest *tdigest.MergingDigestData `protobuf:"bytes,1,opt,name=t_digest,json=tDigest,proto3" json:"t_digest,omitempty"`
+	TDigest  *tdigest.MergingDigestData `protobuf:"bytes,1,opt,name=t_digest,json=tDigest,proto3" json:"t_digest,omitempty"`
+	DdSketch *ddsketch.DDSketchData     `protobuf:"bytes,2,opt,name=dd_sketch,json=ddSketch,proto3" json:"dd_sketch,omitempty"`
 }
 
 func (m *HistogramValue) Reset()         { *m = HistogramValue{} }
@@ -471,6 +479,13 @@ func (m *HistogramValue) GetTDigest() *tdigest.MergingDigestData {
 	return nil
 }
 
+func (m *HistogramValue) GetDdSketch() *ddsketch.DDSketchData {
+	if m != nil {
+		return m.DdSketch
+	}
+	return nil
+}
+
 // SetValue contains a binary-encoded HyperLogLog
 type SetValue struct {
 	HyperLogLog []byte `protobuf:"bytes,1,opt,name=hyper_log_log,json=hyperLogLog,proto3" json:"hyper_log_log,omitempty"`
@@ -529,35 +544,38 @@ func init() {
 func init() { proto.RegisterFile("samplers/metricpb/metric.proto", fileDescriptor_95975e4c0ef795ab) }
 
 var fileDescriptor_95975e4c0ef795ab = []byte{
-	// 446 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x92, 0x41, 0x6b, 0xdb, 0x40,
-	0x10, 0x85, 0xb5, 0x96, 0x65, 0x59, 0xe3, 0xc4, 0x15, 0x43, 0x5a, 0x96, 0x1c, 0x84, 0x11, 0x6d,
-	0x71, 0x43, 0x51, 0xc0, 0xa5, 0xd0, 0x6b, 0xd3, 0x80, 0x73, 0xb0, 0x2f, 0x4a, 0xe8, 0x35, 0xac,
-	0x9d, 0x65, 0x23, 0x90, 0xbc, 0x42, 0x5a, 0x97, 0xfa, 0x5f, 0xf4, 0x67, 0xe5, 0x98, 0x63, 0x8f,
-	0xc5, 0xfe, 0x23, 0x65, 0x57, 0xda, 0x28, 0x3e, 0x08, 0xcd, 0xbe, 0xf7, 0x3d, 0x89, 0xa7, 0x11,
-	0x44, 0x35, 0x2b, 0xca, 0x9c, 0x57, 0xf5, 0x65, 0xc1, 0x55, 0x95, 0xad, 0xcb, 0x55, 0x3b, 0x24,
-	0x65, 0x25, 0x95, 0xc4, 0xa1, 0x95, 0xcf, 0xdf, 0xaa, 0x87, 0x4c, 0xf0, 0x5a, 0x5d, 0xb6, 0xf7,
-	0x06, 0x88, 0x9f, 0x7a, 0x30, 0x58, 0x1a, 0x06, 0x11, 0xfa, 0x1b, 0x56, 0x70, 0x4a, 0x26, 0x64,
-	0x1a, 0xa4, 0x66, 0xd6, 0x9a, 0x62, 0xa2, 0xa6, 0xbd, 0x89, 0xab, 0x35, 0x3d, 0x63, 0x0c, 0x7d,
-	0xb5, 0x2b, 0x39, 0x75, 0x27, 0x64, 0x3a, 0x9e, 0x8d, 0x13, 0xfb, 0x8a, 0xe4, 0x6e, 0x57, 0xf2,
-	0xd4, 0x78, 0x38, 0x03, 0x7f, 0x2d, 0xb7, 0x1b, 0xc5, 0x2b, 0xea, 0x4d, 0xc8, 0x74, 0x34, 0x7b,
-	0xd7, 0x61, 0x3f, 0x1a, 0xe3, 0x27, 0xcb, 0xb7, 0xfc, 0xc6, 0x49, 0x2d, 0x88, 0x9f, 0xc1, 0x13,
-	0x6c, 0x2b, 0x38, 0x1d, 0x98, 0xc4, 0x59, 0x97, 0x98, 0x6b, 0xd9, 0xf2, 0x0d, 0x84, 0xdf, 0x20,
-	0x78, 0xcc, 0x6a, 0x25, 0x45, 0xc5, 0x0a, 0xea, 0x9b, 0x04, 0xed, 0x12, 0x37, 0xd6, 0xb2, 0xa9,
-	0x0e, 0xc6, 0x8f, 0xe0, 0xd6, 0x5c, 0xd1, 0xa1, 0xc9, 0x60, 0x97, 0xb9, 0xe5, 0xca, 0xd2, 0x1a,
-	0xc0, 0x0f, 0xe0, 0xd5, 0x6b, 0x59, 0x72, 0x1a, 0x98, 0xa2, 0x6f, 0x5e, 0x91, 0x5a, 0x4e, 0x1b,
-	0xf7, 0xca, 0x07, 0xef, 0x97, 0x8e, 0xc5, 0xef, 0xe1, 0xe4, 0x75, 0x35, 0x3c, 0x6b, 0x0d, 0xf3,
-	0x41, 0xdd, 0xb4, 0xa5, 0x62, 0x80, 0xae, 0xce, 0x31, 0x43, 0x2c, 0x33, 0x87, 0xf1, 0x71, 0x01,
-	0xfc, 0x0a, 0x43, 0x75, 0xdf, 0x2c, 0xce, 0xa0, 0xa3, 0xd9, 0x79, 0x62, 0x17, 0xb9, 0xe4, 0x95,
-	0xc8, 0x36, 0xe2, 0xda, 0x9c, 0xae, 0x99, 0x62, 0xa9, 0xaf, 0x9a, 0x43, 0x9c, 0xc0, 0xd0, 0xb6,
-	0xc2, 0x18, 0x4e, 0x1f, 0x77, 0x25, 0xaf, 0xee, 0x73, 0x29, 0xf4, 0x65, 0x9e, 0x73, 0x92, 0x8e,
-	0x8c, 0xb8, 0x90, 0x62, 0x21, 0xc5, 0xc5, 0x27, 0xf0, 0x4c, 0x37, 0x0c, 0xc0, 0x5b, 0x66, 0xbf,
-	0xf9, 0x43, 0xe8, 0xe8, 0x71, 0x21, 0xd7, 0x2c, 0x0f, 0x09, 0x02, 0x0c, 0xe6, 0xb9, 0x5c, 0xb1,
-	0x3c, 0xec, 0x5d, 0x7c, 0x87, 0xbe, 0xde, 0x37, 0x8e, 0xc0, 0x6f, 0x5b, 0x37, 0xac, 0x29, 0x17,
-	0x12, 0x3c, 0x85, 0xe0, 0xa5, 0x43, 0xd8, 0x43, 0x1f, 0xdc, 0x5b, 0xae, 0x42, 0x57, 0x23, 0x77,
-	0x59, 0xc1, 0xab, 0xb0, 0x7f, 0x45, 0x9f, 0xf6, 0x11, 0x79, 0xde, 0x47, 0xe4, 0xdf, 0x3e, 0x22,
-	0x7f, 0x0e, 0x91, 0xf3, 0x7c, 0x88, 0x9c, 0xbf, 0x87, 0xc8, 0x59, 0x0d, 0xcc, 0xcf, 0xf9, 0xe5,
-	0x7f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x50, 0xd7, 0x77, 0x2f, 0xdf, 0x02, 0x00, 0x00,
+	// 494 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x92, 0x41, 0x8b, 0xda, 0x40,
+	0x14, 0xc7, 0x8d, 0x31, 0xc6, 0x3c, 0x5d, 0x1b, 0x1e, 0xdb, 0x76, 0xf0, 0x10, 0x24, 0xb4, 0xc5,
+	0x2e, 0x45, 0xc1, 0xa5, 0xd0, 0xf3, 0x56, 0xd8, 0x3d, 0xe8, 0x25, 0x2e, 0xbd, 0x4a, 0x34, 0xc3,
+	0x18, 0x1a, 0x9d, 0x90, 0x8c, 0xa5, 0x42, 0x3f, 0x44, 0x3f, 0xd6, 0x1e, 0xf7, 0xd8, 0x63, 0xd1,
+	0x2f, 0xb2, 0xcc, 0x4c, 0x66, 0xb3, 0x7b, 0x08, 0x79, 0xf3, 0xff, 0xff, 0xfe, 0x0c, 0xef, 0xbd,
+	0x81, 0xa0, 0x8c, 0x77, 0x79, 0x46, 0x8b, 0x72, 0xb2, 0xa3, 0xa2, 0x48, 0x37, 0xf9, 0xba, 0x2a,
+	0xc6, 0x79, 0xc1, 0x05, 0xc7, 0x8e, 0x91, 0x07, 0x6f, 0x45, 0x92, 0x32, 0x5a, 0x8a, 0x49, 0xf5,
+	0xd7, 0xc0, 0xe0, 0x7d, 0x92, 0x94, 0x3f, 0xa9, 0xd8, 0x6c, 0x27, 0xa6, 0xd0, 0x46, 0xf8, 0xd0,
+	0x84, 0xf6, 0x42, 0x85, 0x11, 0xa1, 0xb5, 0x8f, 0x77, 0x94, 0x58, 0x43, 0x6b, 0xe4, 0x45, 0xaa,
+	0x96, 0x9a, 0x88, 0x59, 0x49, 0x9a, 0x43, 0x5b, 0x6a, 0xb2, 0xc6, 0x10, 0x5a, 0xe2, 0x98, 0x53,
+	0x62, 0x0f, 0xad, 0x51, 0x7f, 0xda, 0x1f, 0x9b, 0xbb, 0xc7, 0xf7, 0xc7, 0x9c, 0x46, 0xca, 0xc3,
+	0x29, 0xb8, 0x1b, 0x7e, 0xd8, 0x0b, 0x5a, 0x10, 0x67, 0x68, 0x8d, 0xba, 0xd3, 0x77, 0x35, 0xf6,
+	0x5d, 0x1b, 0x3f, 0xe2, 0xec, 0x40, 0xef, 0x1a, 0x91, 0x01, 0xf1, 0x0b, 0x38, 0x2c, 0x3e, 0x30,
+	0x4a, 0xda, 0x2a, 0x71, 0x59, 0x27, 0x6e, 0xa5, 0x6c, 0x78, 0x0d, 0xe1, 0x37, 0xf0, 0xb6, 0x69,
+	0x29, 0x38, 0x2b, 0xe2, 0x1d, 0x71, 0x55, 0x82, 0xd4, 0x89, 0x3b, 0x63, 0x99, 0x54, 0x0d, 0xe3,
+	0x27, 0xb0, 0x4b, 0x2a, 0x48, 0x47, 0x65, 0xb0, 0xce, 0x2c, 0xa9, 0x30, 0xb4, 0x04, 0xf0, 0x23,
+	0x38, 0xe5, 0x86, 0xe7, 0x94, 0x78, 0xaa, 0xd1, 0x37, 0x2f, 0x48, 0x29, 0x47, 0xda, 0xbd, 0x71,
+	0xc1, 0xf9, 0x25, 0x63, 0xe1, 0x07, 0xe8, 0xbd, 0x6c, 0x0d, 0x2f, 0x2b, 0x43, 0x0d, 0xd4, 0x8e,
+	0x2a, 0x2a, 0x04, 0xa8, 0xdb, 0x79, 0xcd, 0x58, 0x86, 0xf9, 0x03, 0xfd, 0xd7, 0x0d, 0xe0, 0x57,
+	0xe8, 0x88, 0x95, 0xde, 0xa8, 0x42, 0xbb, 0xd3, 0xc1, 0xd8, 0x6c, 0x78, 0x41, 0x0b, 0x96, 0xee,
+	0xd9, 0x4c, 0x9d, 0x66, 0xb1, 0x88, 0x23, 0x57, 0xe8, 0x03, 0x5e, 0x83, 0x97, 0x24, 0x2b, 0xbd,
+	0x70, 0xd2, 0xac, 0x16, 0xf1, 0xfc, 0x02, 0x66, 0xb3, 0xa5, 0x2a, 0x54, 0xa6, 0x93, 0x24, 0xfa,
+	0x14, 0x8e, 0xa1, 0x63, 0x46, 0x81, 0x21, 0x5c, 0x6c, 0x8f, 0x39, 0x2d, 0x56, 0x19, 0x67, 0xf2,
+	0x53, 0x97, 0xf7, 0xa2, 0xae, 0x12, 0xe7, 0x9c, 0xcd, 0x39, 0xbb, 0xfa, 0x0c, 0x8e, 0x1a, 0x08,
+	0x7a, 0xe0, 0x2c, 0xd2, 0xdf, 0x34, 0xf1, 0x1b, 0xb2, 0x9c, 0xf3, 0x4d, 0x9c, 0xf9, 0x16, 0x02,
+	0xb4, 0x6f, 0x33, 0xbe, 0x8e, 0x33, 0xbf, 0x79, 0xb5, 0x84, 0x96, 0x7c, 0x24, 0xd8, 0x05, 0xb7,
+	0x1a, 0x95, 0x66, 0xd5, 0x44, 0x7c, 0x0b, 0x2f, 0xc0, 0x7b, 0x6e, 0xdc, 0x6f, 0xa2, 0x0b, 0xf6,
+	0x92, 0x0a, 0xdf, 0x96, 0xc8, 0x7d, 0xba, 0xa3, 0x85, 0xdf, 0x42, 0x1f, 0x7a, 0xb3, 0xb4, 0x14,
+	0x45, 0xba, 0x3e, 0x88, 0x94, 0xef, 0x7d, 0xe7, 0x86, 0x3c, 0x9c, 0x02, 0xeb, 0xf1, 0x14, 0x58,
+	0xff, 0x4f, 0x81, 0xf5, 0xf7, 0x1c, 0x34, 0x1e, 0xcf, 0x41, 0xe3, 0xdf, 0x39, 0x68, 0xac, 0xdb,
+	0xea, 0x8d, 0x5f, 0x3f, 0x0d, 0x00, 0x07, 0xfc, 0x15, 0x55, 0x3f, 0x03, 0x00, 0x00,
 }
 
 func (m *Metric) Marshal() (dAtA []byte, err error) {
@@ -744,6 +762,16 @@ func (m *HistogramValue) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n6
 	}
+	if m.DdSketch != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMetric(dAtA, i, uint64(m.DdSketch.Size()))
+		n7, err := m.DdSketch.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
+	}
 	return i, nil
 }
 
@@ -890,6 +918,10 @@ func (m *HistogramValue) Size() (n int) {
 		l = m.TDigest.Size()
 		n += 1 + l + sovMetric(uint64(l))
 	}
+	if m.DdSketch != nil {
+		l = m.DdSketch.Size()
+		n += 1 + l + sovMetric(uint64(l))
+	}
 	return n
 }
 
@@ -1415,6 +1447,42 @@ func (m *HistogramValue) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DdSketch", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetric
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMetric
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetric
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DdSketch == nil {
+				m.DdSketch = &ddsketch.DDSketchData{}
+			}
+			if err := m.DdSketch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMetric(dAtA[iNdEx:])
@@ -333,7 +333,9 @@ func ParseMetric(packet []byte) (*UDPMetric, error) {
 		ret.Type = "counter"
 	case 'g':
 		ret.Type = "gauge"
-	case 'd', 'h': // consider DogStatsD's "distribution" to be a histogram
+	case 'd':
+		ret.Type = "distribution"
+	case 'h':
 		ret.Type = "histogram"
 	case 'm': // We can ignore the s in "ms"
 		ret.Type = "timer"
@@ -417,6 +419,12 @@ func ParseMetric(packet []byte) (*UDPMetric, error) {
 		}
 	}
 
+	if ret.Type == "distribution" {
+		// distributions are only meaningful when aggregated across every
+		// host, regardless of any scope tag
+		ret.Scope = GlobalOnly
+	}
+
 	ret.Digest = h
 
 	return ret, nil
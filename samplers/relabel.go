@@ -0,0 +1,256 @@
+package samplers
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/segmentio/fasthash/fnv1a"
+)
+
+// The actions that a RelabelRule can take on the metrics it matches.
+const (
+	// RelabelDrop drops the metric.
+	RelabelDrop = "drop"
+	// RelabelRename replaces the parts of the metric's name matching
+	// MatchName with Replacement, which can refer to submatches as in
+	// regexp.Regexp.Expand.
+	RelabelRename = "rename"
+	// RelabelAddTag adds Tag to the metric, replacing any tag with the
+	// same key.
+	RelabelAddTag = "add_tag"
+	// RelabelDropTag removes the tag with the key TagKey.
+	RelabelDropTag = "drop_tag"
+	// RelabelRenameTag changes the key of the tag with the key TagKey to
+	// Replacement.
+	RelabelRenameTag = "rename_tag"
+	// RelabelMapTagValue replaces the parts of the value of the tag with
+	// the key TagKey that match ValueRegex with Replacement.
+	RelabelMapTagValue = "map_tag_value"
+	// RelabelSetType changes the type of a counter, gauge, histogram,
+	// timer or distribution to Type, which must be one of those.
+	RelabelSetType = "set_type"
+	// RelabelSetScope changes the scope of the metric to Scope, which is
+	// one of "default", "local" or "global".
+	RelabelSetScope = "set_scope"
+)
+
+// RelabelConfig is the configuration of a RelabelRule.
+type RelabelConfig struct {
+	Action string
+	// MatchName is a regular expression that the metric's name must
+	// match. If empty, metrics of any name match.
+	MatchName string
+	// MatchTags must all be present on the metric.
+	MatchTags []string
+
+	Replacement string
+	Scope       string
+	Tag         string
+	TagKey      string
+	Type        string
+	ValueRegex  string
+}
+
+// A RelabelRule takes an action on the metrics that match it.
+type RelabelRule struct {
+	action      string
+	name        *regexp.Regexp
+	tags        []string
+	replacement string
+	scope       MetricScope
+	tag         string
+	tagKey      string
+	typ         string
+	valueRegex  *regexp.Regexp
+}
+
+// numericTypes are the metric types whose values are float64s, which can be
+// changed into each other.
+var numericTypes = map[string]bool{
+	"counter":      true,
+	"gauge":        true,
+	"histogram":    true,
+	"timer":        true,
+	"distribution": true,
+}
+
+// NewRelabelRule checks a RelabelConfig and creates its RelabelRule.
+func NewRelabelRule(c RelabelConfig) (RelabelRule, error) {
+	rule := RelabelRule{
+		action:      c.Action,
+		tags:        c.MatchTags,
+		replacement: c.Replacement,
+		tag:         c.Tag,
+		tagKey:      c.TagKey,
+		typ:         c.Type,
+	}
+	var err error
+	if c.MatchName != "" {
+		if rule.name, err = regexp.Compile(c.MatchName); err != nil {
+			return rule, fmt.Errorf("invalid relabel rule name regex %q: %v", c.MatchName, err)
+		}
+	}
+
+	switch c.Action {
+	case RelabelDrop:
+	case RelabelRename:
+		if rule.name == nil {
+			return rule, fmt.Errorf("relabel rule %q needs a name regex", c.Action)
+		}
+	case RelabelAddTag:
+		if c.Tag == "" {
+			return rule, fmt.Errorf("relabel rule %q needs a tag", c.Action)
+		}
+	case RelabelDropTag:
+		if c.TagKey == "" {
+			return rule, fmt.Errorf("relabel rule %q needs a tag key", c.Action)
+		}
+	case RelabelRenameTag:
+		if c.TagKey == "" || c.Replacement == "" {
+			return rule, fmt.Errorf("relabel rule %q needs a tag key and a replacement", c.Action)
+		}
+	case RelabelMapTagValue:
+		if c.TagKey == "" || c.ValueRegex == "" {
+			return rule, fmt.Errorf("relabel rule %q needs a tag key and a value regex", c.Action)
+		}
+		if rule.valueRegex, err = regexp.Compile(c.ValueRegex); err != nil {
+			return rule, fmt.Errorf("invalid relabel rule value regex %q: %v", c.ValueRegex, err)
+		}
+	case RelabelSetType:
+		if !numericTypes[c.Type] {
+			return rule, fmt.Errorf("relabel rule can't change metrics to type %q", c.Type)
+		}
+	case RelabelSetScope:
+		switch c.Scope {
+		case "default":
+			rule.scope = MixedScope
+		case "local":
+			rule.scope = LocalOnly
+		case "global":
+			rule.scope = GlobalOnly
+		default:
+			return rule, fmt.Errorf("unknown relabel rule scope %q", c.Scope)
+		}
+	default:
+		return rule, fmt.Errorf("unknown relabel rule action %q", c.Action)
+	}
+	return rule, nil
+}
+
+func (r RelabelRule) matches(m *UDPMetric) bool {
+	if r.name != nil && !r.name.MatchString(m.Name) {
+		return false
+	}
+	for _, want := range r.tags {
+		found := false
+		for _, tag := range m.Tags {
+			if tag == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Relabeler applies RelabelRules, in order, to metrics before they are
+// aggregated.
+type Relabeler []RelabelRule
+
+// Relabel applies every rule that matches the metric, in order. Each rule
+// sees the metric as changed by the rules before it. It returns false if
+// the metric should be dropped.
+func (rules Relabeler) Relabel(m *UDPMetric) bool {
+	changed := false
+	for _, r := range rules {
+		if !r.matches(m) {
+			continue
+		}
+		switch r.action {
+		case RelabelDrop:
+			return false
+		case RelabelRename:
+			m.Name = r.name.ReplaceAllString(m.Name, r.replacement)
+		case RelabelAddTag:
+			key, _ := splitTag(r.tag)
+			m.Tags = append(withoutTag(m.Tags, key), r.tag)
+		case RelabelDropTag:
+			m.Tags = withoutTag(m.Tags, r.tagKey)
+		case RelabelRenameTag:
+			m.Tags = mapTag(m.Tags, r.tagKey, func(value string) string {
+				if value == "" {
+					return r.replacement
+				}
+				return r.replacement + ":" + value
+			})
+		case RelabelMapTagValue:
+			m.Tags = mapTag(m.Tags, r.tagKey, func(value string) string {
+				return r.tagKey + ":" + r.valueRegex.ReplaceAllString(value, r.replacement)
+			})
+		case RelabelSetType:
+			if !numericTypes[m.Type] {
+				continue
+			}
+			m.Type = r.typ
+		case RelabelSetScope:
+			m.Scope = r.scope
+		}
+		changed = true
+	}
+	if !changed {
+		return true
+	}
+
+	if m.Type == "distribution" {
+		// distributions are always global, as in ParseMetric
+		m.Scope = GlobalOnly
+	}
+	// hash the metric the same way that the parsers do, so that each
+	// timeseries is still aggregated by a single worker
+	sort.Strings(m.Tags)
+	m.JoinedTags = strings.Join(m.Tags, ",")
+	h := fnv1a.Init32
+	h = fnv1a.AddString32(h, m.Name)
+	h = fnv1a.AddString32(h, m.Type)
+	h = fnv1a.AddString32(h, m.JoinedTags)
+	m.Digest = h
+	return true
+}
+
+// splitTag splits a tag into its key and its value, which is empty if the
+// tag has no colon.
+func splitTag(tag string) (string, string) {
+	if i := strings.IndexByte(tag, ':'); i >= 0 {
+		return tag[:i], tag[i+1:]
+	}
+	return tag, ""
+}
+
+// withoutTag returns a copy of tags without the tags with the given key.
+func withoutTag(tags []string, key string) []string {
+	ret := make([]string, 0, len(tags)+1)
+	for _, tag := range tags {
+		if k, _ := splitTag(tag); k != key {
+			ret = append(ret, tag)
+		}
+	}
+	return ret
+}
+
+// mapTag returns a copy of tags, with the tags with the given key replaced
+// by f of their value.
+func mapTag(tags []string, key string, f func(value string) string) []string {
+	ret := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if k, v := splitTag(tag); k == key {
+			tag = f(v)
+		}
+		ret = append(ret, tag)
+	}
+	return ret
+}
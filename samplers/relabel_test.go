@@ -0,0 +1,93 @@
+package samplers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func relabeler(t *testing.T, configs ...RelabelConfig) Relabeler {
+	rules := Relabeler{}
+	for _, c := range configs {
+		rule, err := NewRelabelRule(c)
+		require.NoError(t, err)
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func TestRelabel(t *testing.T) {
+	rules := relabeler(t,
+		RelabelConfig{Action: RelabelRename, MatchName: `^legacy\.(.*)$`, Replacement: "app.$1"},
+		RelabelConfig{Action: RelabelMapTagValue, MatchName: `^app\.http\.`, TagKey: "status", ValueRegex: "^([1-5])..$", Replacement: "${1}xx"},
+		RelabelConfig{Action: RelabelRenameTag, TagKey: "host", Replacement: "hostname"},
+		RelabelConfig{Action: RelabelDropTag, TagKey: "request_id"},
+		RelabelConfig{Action: RelabelAddTag, MatchTags: []string{"env:staging"}, Tag: "env:dev"},
+		RelabelConfig{Action: RelabelSetType, MatchName: `latency$`, Type: "distribution"},
+	)
+
+	m, err := ParseMetric([]byte("legacy.http.latency:5|h|#status:404,request_id:abc,host:a,env:staging"))
+	require.NoError(t, err)
+	require.True(t, rules.Relabel(m))
+
+	expected, err := ParseMetric([]byte("app.http.latency:5|d|#env:dev,hostname:a,status:4xx"))
+	require.NoError(t, err)
+	assert.Equal(t, expected, m, "a relabeled metric should be the same as if it had been sent that way")
+	assert.Equal(t, GlobalOnly, m.Scope)
+
+	// metrics that no rule changes are untouched:
+	m, err = ParseMetric([]byte("db.reads:1|c|#shard:1"))
+	require.NoError(t, err)
+	expected = &UDPMetric{}
+	*expected = *m
+	require.True(t, rules.Relabel(m))
+	assert.Equal(t, expected, m)
+}
+
+func TestRelabelDrop(t *testing.T) {
+	rules := relabeler(t,
+		RelabelConfig{Action: RelabelSetScope, MatchName: `^app\.`, Scope: "local"},
+		RelabelConfig{Action: RelabelDrop, MatchName: `^app\.debug\.`, MatchTags: []string{"env:production"}},
+	)
+
+	m, err := ParseMetric([]byte("app.debug.hits:1|c|#env:production"))
+	require.NoError(t, err)
+	assert.False(t, rules.Relabel(m))
+
+	m, err = ParseMetric([]byte("app.debug.hits:1|c|#env:staging"))
+	require.NoError(t, err)
+	assert.True(t, rules.Relabel(m), "every tag must match for a rule to apply")
+	assert.Equal(t, LocalOnly, m.Scope)
+}
+
+func TestRelabelSetTypeOnlyNumeric(t *testing.T) {
+	rules := relabeler(t, RelabelConfig{Action: RelabelSetType, Type: "gauge"})
+
+	m, err := ParseMetric([]byte("a.b.c:foo|s"))
+	require.NoError(t, err)
+	require.True(t, rules.Relabel(m))
+	assert.Equal(t, "set", m.Type, "sets can't be changed into other types")
+
+	m, err = ParseMetric([]byte("a.b.c:1|c"))
+	require.NoError(t, err)
+	require.True(t, rules.Relabel(m))
+	assert.Equal(t, "gauge", m.Type)
+}
+
+func TestNewRelabelRuleErrors(t *testing.T) {
+	for name, c := range map[string]RelabelConfig{
+		"unknown action":    {Action: "explode"},
+		"bad name regex":    {Action: RelabelDrop, MatchName: "a("},
+		"rename no regex":   {Action: RelabelRename, Replacement: "a"},
+		"add no tag":        {Action: RelabelAddTag},
+		"drop tag no key":   {Action: RelabelDropTag},
+		"rename tag no key": {Action: RelabelRenameTag, Replacement: "b"},
+		"map no regex":      {Action: RelabelMapTagValue, TagKey: "a"},
+		"set type to set":   {Action: RelabelSetType, Type: "set"},
+		"unknown scope":     {Action: RelabelSetScope, Scope: "regional"},
+	} {
+		_, err := NewRelabelRule(c)
+		assert.Error(t, err, name)
+	}
+}
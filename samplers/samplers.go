@@ -5,10 +5,12 @@ import (
 	"encoding/binary"
 	"fmt"
 	"math"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/axiomhq/hyperloglog"
+	"github.com/stripe/veneur/ddsketch"
 	"github.com/stripe/veneur/samplers/metricpb"
 	"github.com/stripe/veneur/tdigest"
 )
@@ -24,6 +26,10 @@ const (
 	GaugeMetric
 	// StatusMetric is a status (synonymous with a service check)
 	StatusMetric
+	// SketchMetric is a histogram, timer or distribution flushed as its
+	// quantile sketch, instead of as percentiles. Only sinks that accept
+	// sketches receive these.
+	SketchMetric
 )
 
 // RouteInformation is a key-only map indicating sink names that are
@@ -54,6 +60,10 @@ type InterMetric struct {
 	Message   string
 	HostName  string
 
+	// Sketch is the quantile sketch of a SketchMetric, and nil for every
+	// other type.
+	Sketch *metricpb.HistogramValue `json:",omitempty"`
+
 	// Sinks, if non-nil, indicates which metric sinks a metric
 	// should be inserted into. If nil, that means the metric is
 	// meant to go to every sink.
@@ -105,6 +115,9 @@ type JSONMetric struct {
 	// the Value is an internal representation of the metric's contents, eg a
 	// gob-encoded histogram or hyperloglog.
 	Value []byte `json:"value"`
+	// Sketch is the type of quantile sketch encoded in the Value of a
+	// histogram. Empty means a t-digest.
+	Sketch SketchType `json:"sketch,omitempty"`
 }
 
 const sinkPrefix string = "veneursinkonly:"
@@ -467,7 +480,7 @@ func (s *Set) Merge(v *metricpb.SetValue) error {
 type Histo struct {
 	Name  string
 	Tags  []string
-	Value *tdigest.MergingDigest
+	Value QuantileSketch
 	// these values are computed from only the samples that came through this
 	// veneur instance, ignoring any histograms merged from elsewhere
 	// we separate them because they're easy to aggregate on the backend without
@@ -493,13 +506,18 @@ func (h *Histo) Sample(sample float64, sampleRate float32) {
 	h.LocalReciprocalSum += (1 / sample) * weight
 }
 
-// NewHist generates a new Histo and returns it.
+// NewHist generates a new Histo, backed by a t-digest, and returns it.
 func NewHist(Name string, Tags []string) *Histo {
+	return NewHistWithSketch(Name, Tags, SketchTDigest)
+}
+
+// NewHistWithSketch generates a new Histo backed by the given type of
+// quantile sketch, and returns it.
+func NewHistWithSketch(Name string, Tags []string, sketch SketchType) *Histo {
 	return &Histo{
-		Name: Name,
-		Tags: Tags,
-		// we're going to allocate a lot of these, so we don't want them to be huge
-		Value:    tdigest.NewMerging(100, false),
+		Name:     Name,
+		Tags:     Tags,
+		Value:    newQuantileSketch(sketch),
 		LocalMin: math.Inf(+1),
 		LocalMax: math.Inf(-1),
 		LocalSum: 0,
@@ -659,9 +677,8 @@ func (h *Histo) Flush(interval time.Duration, percentiles []float64, aggregates
 		copy(tags, h.Tags)
 		metrics = append(
 			metrics,
-			// TODO Fix to allow for p999, etc
 			InterMetric{
-				Name:      fmt.Sprintf("%s.%dpercentile", h.Name, int(p*100)),
+				Name:      fmt.Sprintf("%s.%spercentile", h.Name, PercentileName(p)),
 				Timestamp: now,
 				Value:     float64(h.Value.Quantile(p)),
 				Tags:      tags,
@@ -674,9 +691,41 @@ func (h *Histo) Flush(interval time.Duration, percentiles []float64, aggregates
 	return metrics
 }
 
+// PercentileName formats a quantile for the name of the percentile metric
+// that Histo.Flush emits for it, e.g. "99" for 0.99 and "99_9" for 0.999.
+// The decimal point is replaced so that it can't be mistaken for a
+// separator in the metric's name.
+func PercentileName(p float64) string {
+	// round away floating-point noise, e.g. 0.999*100 = 99.89999999999999
+	// and 0.29*100 = 28.999999999999996
+	pct := math.Floor(p*100*1e6+0.5) / 1e6
+	if pct == math.Trunc(pct) {
+		return strconv.Itoa(int(pct))
+	}
+	return strings.Replace(strconv.FormatFloat(pct, 'f', -1, 64), ".", "_", 1)
+}
+
+// FlushSketch generates a single SketchMetric for the current state of the
+// Histo, carrying its entire sketch. Sinks that compute quantiles themselves
+// can use it in place of the percentiles generated by Flush. Its Value is
+// the total weight of the sketch.
+func (h *Histo) FlushSketch() InterMetric {
+	tags := make([]string, len(h.Tags))
+	copy(tags, h.Tags)
+	return InterMetric{
+		Name:      h.Name,
+		Timestamp: time.Now().Unix(),
+		Value:     h.Value.Count(),
+		Tags:      tags,
+		Type:      SketchMetric,
+		Sketch:    histogramValue(h.Value),
+		Sinks:     routeInfo(h.Tags),
+	}
+}
+
 // Export converts a Histogram into a JSONMetric
 func (h *Histo) Export() (JSONMetric, error) {
-	val, err := h.Value.GobEncode()
+	sketch, val, err := encodeQuantileSketch(h.Value)
 	if err != nil {
 		return JSONMetric{}, err
 	}
@@ -686,19 +735,37 @@ func (h *Histo) Export() (JSONMetric, error) {
 			Type:       "histogram",
 			JoinedTags: strings.Join(h.Tags, ","),
 		},
-		Tags:  h.Tags,
-		Value: val,
+		Tags:   h.Tags,
+		Value:  val,
+		Sketch: sketch,
 	}, nil
 }
 
 // Combine merges the values of a histogram with another histogram
-// (marshalled as a byte slice)
+// (marshalled as a byte slice, backed by a t-digest)
 func (h *Histo) Combine(other []byte) error {
-	otherHistogram := tdigest.NewMerging(100, false)
-	if err := otherHistogram.GobDecode(other); err != nil {
-		return err
+	return h.CombineSketch(SketchTDigest, other)
+}
+
+// CombineSketch merges the values of a histogram with another histogram,
+// marshalled as a byte slice and backed by the given type of sketch.
+func (h *Histo) CombineSketch(sketch SketchType, other []byte) error {
+	switch sketch {
+	case SketchTDigest, "":
+		otherHistogram := tdigest.NewMerging(100, false)
+		if err := otherHistogram.GobDecode(other); err != nil {
+			return err
+		}
+		h.mergeTDigest(otherHistogram)
+	case SketchDDSketch:
+		data := &ddsketch.DDSketchData{}
+		if err := data.Unmarshal(other); err != nil {
+			return err
+		}
+		h.mergeDDSketch(ddsketch.NewFromData(data))
+	default:
+		return fmt.Errorf("unknown sketch type %q", sketch)
 	}
-	h.Value.Merge(otherHistogram)
 	return nil
 }
 
@@ -712,19 +779,41 @@ func (h *Histo) GetName() string {
 // a Histo for forwarding.
 func (h *Histo) Metric() (*metricpb.Metric, error) {
 	return &metricpb.Metric{
-		Name: h.Name,
-		Tags: h.Tags,
-		Type: metricpb.Type_Histogram,
-		Value: &metricpb.Metric_Histogram{&metricpb.HistogramValue{
-			TDigest: h.Value.Data(),
-		}},
+		Name:  h.Name,
+		Tags:  h.Tags,
+		Type:  metricpb.Type_Histogram,
+		Value: &metricpb.Metric_Histogram{histogramValue(h.Value)},
 	}, nil
 }
 
-// Merge merges the t-digests of the two histograms and mutates the state
-// of this one.
+// Merge merges the sketches of the two histograms and mutates the state
+// of this one. If the sketches are of different types, the other one is
+// converted to the type of this one.
 func (h *Histo) Merge(v *metricpb.HistogramValue) {
 	if v.TDigest != nil {
-		h.Value.Merge(tdigest.NewMergingFromData(v.TDigest))
+		h.mergeTDigest(tdigest.NewMergingFromData(v.TDigest))
+	}
+	if v.DdSketch != nil {
+		h.mergeDDSketch(ddsketch.NewFromData(v.DdSketch))
+	}
+}
+
+func (h *Histo) mergeTDigest(td *tdigest.MergingDigest) {
+	switch v := h.Value.(type) {
+	case *tdigest.MergingDigest:
+		v.Merge(td)
+	case *ddsketch.DDSketch:
+		v.MergeTDigest(td)
+	}
+}
+
+func (h *Histo) mergeDDSketch(dd *ddsketch.DDSketch) {
+	switch v := h.Value.(type) {
+	case *ddsketch.DDSketch:
+		v.Merge(dd)
+	case *tdigest.MergingDigest:
+		// t-digests can't hold the DDSketch's exact extremes and sums, so
+		// this is only as accurate as the DDSketch's buckets.
+		dd.ForEach(v.Add)
 	}
 }
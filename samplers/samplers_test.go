@@ -7,9 +7,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stripe/veneur/ddsketch"
 	"github.com/stripe/veneur/tdigest"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stripe/veneur/ssf"
 )
 
@@ -401,6 +403,19 @@ func TestHisto(t *testing.T) {
 	assert.Equal(t, float64(23.75), m7.Value, "Value")
 }
 
+func TestPercentileName(t *testing.T) {
+	for p, name := range map[float64]string{
+		0.5:    "50",
+		0.29:   "29",
+		0.99:   "99",
+		0.999:  "99_9",
+		0.9999: "99_99",
+		1:      "100",
+	} {
+		assert.Equal(t, name, PercentileName(p), "quantile %v", p)
+	}
+}
+
 func TestHistoAvgOnly(t *testing.T) {
 	h := NewHist("a.b.c", []string{"a:b"})
 
@@ -524,6 +539,25 @@ func TestHistoMerge(t *testing.T) {
 	assert.InDelta(t, 1.0, h2.LocalMax, 0.02, "merged histogram should have max of 1 after adding a value")
 }
 
+func TestHistoFlushSketch(t *testing.T) {
+	h := NewHist("a.b.c", []string{"a:b", "veneursinkonly:kafka"})
+	h.Sample(5, 1.0)
+	h.Sample(10, 0.5)
+
+	m := h.FlushSketch()
+	assert.Equal(t, "a.b.c", m.Name)
+	assert.Equal(t, SketchMetric, m.Type)
+	assert.Equal(t, float64(3), m.Value, "the value should be the total weight")
+	assert.Equal(t, []string{"a:b", "veneursinkonly:kafka"}, m.Tags)
+	assert.True(t, m.Sinks.RouteTo("kafka"))
+	assert.False(t, m.Sinks.RouteTo("datadog"))
+
+	require.NotNil(t, m.Sketch)
+	td := tdigest.NewMergingFromData(m.Sketch.TDigest)
+	assert.Equal(t, float64(5), td.Min())
+	assert.Equal(t, float64(10), td.Max())
+}
+
 // Test the Metric and Merge function on Set
 func TestHistoMergeMetric(t *testing.T) {
 	rand.Seed(time.Now().Unix())
@@ -549,6 +583,105 @@ func TestHistoMergeMetric(t *testing.T) {
 	assert.InDelta(t, 1.0, h2.LocalMax, 0.02, "merged histogram should have max of 1 after adding a value")
 }
 
+func TestDDSketchHistoMerge(t *testing.T) {
+	rand.Seed(time.Now().Unix())
+
+	h := NewHistWithSketch("a.b.c", []string{"a:b"}, SketchDDSketch)
+	for i := 0; i < 100; i++ {
+		h.Sample(rand.ExpFloat64(), 1.0)
+	}
+
+	jm, err := h.Export()
+	require.NoError(t, err, "should have exported successfully")
+	assert.Equal(t, SketchDDSketch, jm.Sketch)
+
+	h2 := NewHistWithSketch("a.b.c", []string{"a:b"}, SketchDDSketch)
+	require.NoError(t, h2.CombineSketch(jm.Sketch, jm.Value), "should have combined successfully")
+	assert.Equal(t, h.Value.Quantile(0.99), h2.Value.Quantile(0.99), "99th percentiles did not match after combining")
+	assert.Error(t, h2.CombineSketch(SketchTDigest, jm.Value), "a DDSketch shouldn't decode as a t-digest")
+	assert.Error(t, h2.CombineSketch("hdr", jm.Value), "unknown sketches should be rejected")
+
+	m, err := h.Metric()
+	require.NoError(t, err)
+	require.NotNil(t, m.GetHistogram().DdSketch)
+	assert.Nil(t, m.GetHistogram().TDigest)
+
+	h3 := NewHistWithSketch("a.b.c", []string{"a:b"}, SketchDDSketch)
+	h3.Merge(m.GetHistogram())
+	assert.Equal(t, h.Value.Quantile(0.99), h3.Value.Quantile(0.99), "99th percentiles did not match after merging")
+	assert.InDelta(t, 0, h3.LocalWeight, 0.02, "merged histogram should have count of zero")
+}
+
+// Mixed fleets forward both kinds of sketches while migrating, so each must
+// merge into the other.
+func TestHistoMergeMixedSketches(t *testing.T) {
+	rand.Seed(time.Now().Unix())
+
+	td := NewHist("a.b.c", []string{"a:b"})
+	dd := NewHistWithSketch("a.b.c", []string{"a:b"}, SketchDDSketch)
+	for i := 0; i < 1000; i++ {
+		v := rand.Float64() * 100
+		td.Sample(v, 1.0)
+		dd.Sample(v, 1.0)
+	}
+
+	tdm, err := td.Metric()
+	require.NoError(t, err)
+	ddm, err := dd.Metric()
+	require.NoError(t, err)
+
+	toDD := NewHistWithSketch("a.b.c", []string{"a:b"}, SketchDDSketch)
+	toDD.Merge(tdm.GetHistogram())
+	toDD.Merge(ddm.GetHistogram())
+	toTD := NewHist("a.b.c", []string{"a:b"})
+	toTD.Merge(tdm.GetHistogram())
+	toTD.Merge(ddm.GetHistogram())
+
+	for _, h := range []*Histo{toDD, toTD} {
+		assert.Equal(t, float64(2000), h.Value.Count())
+		assert.InEpsilon(t, td.Value.Quantile(0.5), h.Value.Quantile(0.5), 0.05)
+	}
+	// DDSketches carry over the exact extremes and sums of a t-digest...
+	assert.Equal(t, td.Value.Min(), toDD.Value.Min())
+	assert.Equal(t, td.Value.Max(), toDD.Value.Max())
+	assert.InEpsilon(t, td.Value.Sum()*2, toDD.Value.Sum(), 1e-9)
+	// ...but t-digests only get the DDSketch's buckets
+	assert.InEpsilon(t, td.Value.Min(), toTD.Value.Min(), 0.0101)
+	assert.InEpsilon(t, td.Value.Max(), toTD.Value.Max(), 0.0101)
+	assert.InEpsilon(t, td.Value.Sum()*2, toTD.Value.Sum(), 0.0101)
+	_, ok := toDD.Value.(*ddsketch.DDSketch)
+	assert.True(t, ok, "merging should keep the receiving histogram's sketch")
+	_, ok = toTD.Value.(*tdigest.MergingDigest)
+	assert.True(t, ok, "merging should keep the receiving histogram's sketch")
+
+	jm, err := dd.Export()
+	require.NoError(t, err)
+	require.NoError(t, td.CombineSketch(jm.Sketch, jm.Value))
+	assert.Equal(t, float64(2000), td.Value.Count())
+}
+
+func TestSketchSelector(t *testing.T) {
+	var zero SketchSelector
+	assert.Equal(t, SketchTDigest, zero.For("a.b.c"))
+
+	ss := SketchSelector{
+		Default: SketchDDSketch,
+		Prefixes: map[string]SketchType{
+			"api.":        SketchTDigest,
+			"api.latency": SketchDDSketch,
+		},
+	}
+	assert.Equal(t, SketchDDSketch, ss.For("db.latency"))
+	assert.Equal(t, SketchTDigest, ss.For("api.requests"))
+	assert.Equal(t, SketchDDSketch, ss.For("api.latency.p99"), "the longest prefix should win")
+
+	sketch, err := ParseSketchType("")
+	assert.NoError(t, err)
+	assert.Equal(t, SketchTDigest, sketch)
+	_, err = ParseSketchType("hdr")
+	assert.Error(t, err)
+}
+
 func TestMetricKeyEquality(t *testing.T) {
 	c1 := NewCounter("a.b.c", []string{"a:b", "c:d"})
 	ce1, _ := c1.Export()
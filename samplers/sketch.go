@@ -0,0 +1,106 @@
+package samplers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stripe/veneur/ddsketch"
+	"github.com/stripe/veneur/samplers/metricpb"
+	"github.com/stripe/veneur/tdigest"
+)
+
+// QuantileSketch is a mergeable approximate histogram that backs a Histo.
+// It is implemented by *tdigest.MergingDigest and *ddsketch.DDSketch.
+type QuantileSketch interface {
+	Add(value float64, weight float64)
+	Quantile(quantile float64) float64
+	Min() float64
+	Max() float64
+	Count() float64
+	Sum() float64
+	ReciprocalSum() float64
+}
+
+var _ QuantileSketch = &tdigest.MergingDigest{}
+var _ QuantileSketch = &ddsketch.DDSketch{}
+
+// SketchType names an implementation of QuantileSketch.
+type SketchType string
+
+const (
+	// SketchTDigest is a t-digest, which is most accurate around the
+	// median.
+	SketchTDigest SketchType = "tdigest"
+	// SketchDDSketch is a DDSketch, whose error is relative to the value
+	// at every quantile, keeping extreme tails accurate.
+	SketchDDSketch SketchType = "ddsketch"
+)
+
+// ParseSketchType returns the SketchType with the given name. The empty
+// string is a t-digest.
+func ParseSketchType(name string) (SketchType, error) {
+	switch SketchType(name) {
+	case "", SketchTDigest:
+		return SketchTDigest, nil
+	case SketchDDSketch:
+		return SketchDDSketch, nil
+	}
+	return "", fmt.Errorf("unknown histogram sketch %q", name)
+}
+
+// SketchSelector chooses the type of sketch backing each histogram, timer
+// and distribution by the metric's name. The zero value always chooses a
+// t-digest.
+type SketchSelector struct {
+	Default SketchType
+	// Prefixes overrides Default for the metrics whose name starts with
+	// one of its keys. The longest matching prefix wins.
+	Prefixes map[string]SketchType
+}
+
+// For returns the type of sketch that should back the named metric.
+func (ss SketchSelector) For(name string) SketchType {
+	sketch, longest := ss.Default, -1
+	for prefix, s := range ss.Prefixes {
+		if len(prefix) > longest && strings.HasPrefix(name, prefix) {
+			sketch, longest = s, len(prefix)
+		}
+	}
+	if sketch == "" {
+		return SketchTDigest
+	}
+	return sketch
+}
+
+func newQuantileSketch(sketch SketchType) QuantileSketch {
+	if sketch == SketchDDSketch {
+		return ddsketch.New(ddsketch.DefaultRelativeAccuracy, ddsketch.DefaultMaxBins)
+	}
+	// we're going to allocate a lot of these, so we don't want them to be huge
+	return tdigest.NewMerging(100, false)
+}
+
+// histogramValue returns the protobuf representation of a sketch.
+func histogramValue(qs QuantileSketch) *metricpb.HistogramValue {
+	switch v := qs.(type) {
+	case *ddsketch.DDSketch:
+		return &metricpb.HistogramValue{DdSketch: v.Data()}
+	case *tdigest.MergingDigest:
+		return &metricpb.HistogramValue{TDigest: v.Data()}
+	}
+	return &metricpb.HistogramValue{}
+}
+
+// encodeQuantileSketch marshals a sketch for a JSONMetric. T-digests are
+// gob-encoded, for compatibility with older veneurs.
+func encodeQuantileSketch(qs QuantileSketch) (SketchType, []byte, error) {
+	switch v := qs.(type) {
+	case *ddsketch.DDSketch:
+		val, err := v.Data().Marshal()
+		return SketchDDSketch, val, err
+	case *tdigest.MergingDigest:
+		val, err := v.GobEncode()
+		return "", val, err
+	}
+	return "", nil, fmt.Errorf("unknown sketch %T", qs)
+}
@@ -32,11 +32,13 @@ import (
 	"github.com/zenazn/goji/bind"
 	"github.com/zenazn/goji/graceful"
 	"google.golang.org/grpc"
+	grpccredentials "google.golang.org/grpc/credentials"
 
 	"github.com/pkg/profile"
 
 	vhttp "github.com/stripe/veneur/http"
 	"github.com/stripe/veneur/importsrv"
+	"github.com/stripe/veneur/jaeger"
 	"github.com/stripe/veneur/plugins"
 	localfilep "github.com/stripe/veneur/plugins/localfile"
 	s3p "github.com/stripe/veneur/plugins/s3"
@@ -47,15 +49,22 @@ import (
 	"github.com/stripe/veneur/sinks/datadog"
 	"github.com/stripe/veneur/sinks/debug"
 	"github.com/stripe/veneur/sinks/falconer"
+	"github.com/stripe/veneur/sinks/grpsink"
 	"github.com/stripe/veneur/sinks/kafka"
 	"github.com/stripe/veneur/sinks/lightstep"
+	"github.com/stripe/veneur/sinks/otlp"
+	"github.com/stripe/veneur/sinks/prometheus"
 	"github.com/stripe/veneur/sinks/signalfx"
 	"github.com/stripe/veneur/sinks/splunk"
 	"github.com/stripe/veneur/sinks/ssfmetrics"
 	"github.com/stripe/veneur/sinks/xray"
+	zipkinsink "github.com/stripe/veneur/sinks/zipkin"
+	"github.com/stripe/veneur/spool"
 	"github.com/stripe/veneur/ssf"
+	"github.com/stripe/veneur/tailsample"
 	"github.com/stripe/veneur/trace"
 	"github.com/stripe/veneur/trace/metrics"
+	"github.com/stripe/veneur/zipkin"
 )
 
 // VERSION stores the current veneur version.
@@ -102,11 +111,28 @@ type Server struct {
 
 	ForwardAddr    string
 	forwardUseGRPC bool
+	// persists forwards that failed, so that they can be replayed
+	forwardSpool *spool.Spool
+	// the metrics replayed for each past interval, merged until they're
+	// flushed
+	backfills   map[int64]*Worker
+	backfillMtx sync.Mutex
+
+	// the ring change announced by a proxy, if any
+	handoff    *ringHandoff
+	handoffMtx sync.Mutex
 
 	StatsdListenAddrs []net.Addr
 	SSFListenAddrs    []net.Addr
 	RcvbufBytes       int
 
+	// the addresses that Zipkin and Jaeger clients send spans to over
+	// HTTP, if any
+	ZipkinListenAddr string
+	JaegerListenAddr string
+	// the largest request that the Zipkin and Jaeger listeners accept
+	spanHTTPMaxBodyBytes int64
+
 	interval            time.Duration
 	synchronizeInterval bool
 	numReaders          int
@@ -129,9 +155,25 @@ type Server struct {
 
 	HistogramAggregates samplers.HistogramAggregates
 
+	// HistogramRules override HistogramPercentiles and
+	// HistogramAggregates for the metrics they match.
+	HistogramRules samplers.HistogramRules
+
+	// the sketches that back histograms, timers and distributions
+	sketches samplers.SketchSelector
+
+	// relabels metrics after parsing, before they're hashed to a worker
+	relabeler samplers.Relabeler
+
 	spanSinks   []sinks.SpanSink
 	metricSinks []sinks.MetricSink
 
+	// serves the last flush on /metrics, if enabled
+	prometheusScrapeSink *prometheus.ScrapeSink
+
+	// receives the spans its peers send it, if tail sampling has peers
+	tailSampler *tailsample.Sampler
+
 	TraceClient *trace.Client
 
 	ssfInternalMetrics sync.Map
@@ -142,6 +184,9 @@ type Server struct {
 
 	// gRPC forward clients
 	grpcForwardConn *grpc.ClientConn
+	grpcDialOptions []grpc.DialOption
+	// grpcBatchSize is the most metrics per message forwarded over gRPC
+	grpcBatchSize int
 
 	stuckIntervals int
 	lastFlushUnix  int64
@@ -277,6 +322,26 @@ func NewFromConfig(logger *logrus.Logger, conf Config) (*Server, error) {
 	ret.HistogramAggregates.Count = len(conf.Aggregates)
 
 	var err error
+	ret.sketches, err = conf.ParseSketchSelector()
+	if err != nil {
+		return ret, err
+	}
+
+	ret.HistogramRules, err = conf.ParseHistogramRules()
+	if err != nil {
+		return ret, err
+	}
+
+	limits, err := conf.ParseCardinalityLimits()
+	if err != nil {
+		return ret, err
+	}
+
+	ret.relabeler, err = conf.ParseRelabelRules()
+	if err != nil {
+		return ret, err
+	}
+
 	ret.interval, err = conf.ParseInterval()
 	if err != nil {
 		return ret, err
@@ -389,6 +454,8 @@ func NewFromConfig(logger *logrus.Logger, conf Config) (*Server, error) {
 	// Use the pre-allocated Workers slice to know how many to start.
 	for i := range ret.Workers {
 		ret.Workers[i] = NewWorker(i+1, ret.IsLocal(), ret.CountUniqueTimeseries, ret.TraceClient, log, ret.Statsd)
+		ret.Workers[i].SetSketchSelector(ret.sketches)
+		ret.Workers[i].SetCardinalityLimits(limits)
 		// do not close over loop index
 		go func(w *Worker) {
 			defer func() {
@@ -406,7 +473,20 @@ func NewFromConfig(logger *logrus.Logger, conf Config) (*Server, error) {
 	for i, w := range ret.Workers {
 		processors[i] = w
 	}
-	metricSink, err := ssfmetrics.NewMetricExtractionSink(processors, conf.IndicatorSpanTimerName, conf.ObjectiveSpanTimerName, ret.TraceClient, log)
+	if len(ret.relabeler) > 0 {
+		processors = []ssfmetrics.Processor{relabelingIngester{ret}}
+	}
+	spanMetrics := ssfmetrics.SpanMetricsOptions{
+		RED:                  conf.SpanRedMetrics,
+		ServiceGraphMaxSpans: conf.SpanServiceGraphMaxSpans,
+	}
+	if conf.SpanServiceGraphWindow != "" {
+		spanMetrics.ServiceGraphWindow, err = time.ParseDuration(conf.SpanServiceGraphWindow)
+		if err != nil {
+			return ret, err
+		}
+	}
+	metricSink, err := ssfmetrics.NewMetricExtractionSink(processors, conf.IndicatorSpanTimerName, conf.ObjectiveSpanTimerName, spanMetrics, ret.TraceClient, log)
 	if err != nil {
 		return ret, err
 	}
@@ -426,6 +506,9 @@ func NewFromConfig(logger *logrus.Logger, conf Config) (*Server, error) {
 		}
 		ret.SSFListenAddrs = append(ret.SSFListenAddrs, addr)
 	}
+	ret.ZipkinListenAddr = conf.ZipkinListenAddress
+	ret.JaegerListenAddr = conf.JaegerListenAddress
+	ret.spanHTTPMaxBodyBytes = int64(conf.SpanHTTPMaxBodyBytes)
 
 	ret.metricMaxLength = conf.MetricMaxLength
 	ret.traceMaxLengthBytes = conf.TraceMaxLengthBytes
@@ -469,6 +552,19 @@ func NewFromConfig(logger *logrus.Logger, conf Config) (*Server, error) {
 		}
 	}
 
+	grpcServerOpts, err := grpcServerOptions(conf.GrpcTLSCertificate, conf.GrpcTLSKey,
+		conf.GrpcTLSAuthorityCertificate, conf.GrpcBearerTokens, conf.GrpcUnauthenticatedServices)
+	if err != nil {
+		logger.WithError(err).Error("Improper gRPC TLS configuration")
+		return ret, err
+	}
+	ret.grpcDialOptions, err = grpcDialOptions(conf.GrpcTLSCertificate, conf.GrpcTLSKey,
+		conf.GrpcTLSAuthorityCertificate, conf.ForwardGrpcBearerToken, conf.ForwardGrpcCompression)
+	if err != nil {
+		logger.WithError(err).Error("Improper gRPC TLS configuration")
+		return ret, err
+	}
+
 	if conf.SignalfxAPIKey != "" {
 		tracedHTTP := *ret.HTTPClient
 		tracedHTTP.Transport = vhttp.NewTraceRoundTripper(tracedHTTP.Transport, ret.TraceClient, "signalfx")
@@ -511,6 +607,23 @@ func NewFromConfig(logger *logrus.Logger, conf Config) (*Server, error) {
 		}
 		ret.metricSinks = append(ret.metricSinks, ddSink)
 	}
+	if conf.PrometheusRemoteWriteAddress != "" {
+		promSink, err := prometheus.NewRemoteWriteSink(
+			conf.PrometheusRemoteWriteAddress, conf.PrometheusRemoteWriteBearerToken,
+			conf.PrometheusRemoteWriteFlushMaxPerBody, conf.PrometheusCounterExpiryFlushes, conf.Hostname, ret.Tags,
+			ret.HTTPClient, log,
+		)
+		if err != nil {
+			return ret, err
+		}
+		ret.metricSinks = append(ret.metricSinks, promSink)
+		logger.Info("Configured Prometheus remote-write metric sink")
+	}
+	if conf.PrometheusScrapeEnabled {
+		ret.prometheusScrapeSink = prometheus.NewScrapeSink(ret.Tags, conf.PrometheusCounterExpiryFlushes, log)
+		ret.metricSinks = append(ret.metricSinks, ret.prometheusScrapeSink)
+		logger.Info("Configured Prometheus scrape metric sink")
+	}
 
 	// Configure tracing sinks
 	if len(conf.SsfListenAddresses) > 0 {
@@ -610,6 +723,38 @@ func NewFromConfig(logger *logrus.Logger, conf Config) (*Server, error) {
 			ret.spanSinks = append(ret.spanSinks, sss)
 		}
 
+		if conf.OtlpTraceAddress != "" {
+			transport := grpc.WithInsecure()
+			if conf.OtlpTraceUseTLS {
+				transport = grpc.WithTransportCredentials(grpccredentials.NewTLS(&tls.Config{}))
+			}
+			otlpSink, err := otlp.NewSpanSink(
+				context.Background(), conf.OtlpTraceAddress,
+				conf.OtlpSpanBufferSize, conf.OtlpSpanBatchSize,
+				ret.TagsAsMap, log, transport,
+			)
+			if err != nil {
+				return ret, err
+			}
+
+			ret.spanSinks = append(ret.spanSinks, otlpSink)
+			logger.Info("Configured OTLP span sink")
+		}
+
+		if conf.ZipkinTraceAddress != "" {
+			zipkinSink, err := zipkinsink.NewSpanSink(
+				conf.ZipkinTraceAddress,
+				conf.ZipkinSpanBufferSize, conf.ZipkinSpanBatchSize,
+				ret.TagsAsMap, ret.HTTPClient, log,
+			)
+			if err != nil {
+				return ret, err
+			}
+
+			ret.spanSinks = append(ret.spanSinks, zipkinSink)
+			logger.Info("Configured Zipkin span sink")
+		}
+
 		if conf.FalconerAddress != "" {
 			falsink, err := falconer.NewSpanSink(context.Background(), conf.FalconerAddress, log, grpc.WithInsecure())
 			if err != nil {
@@ -634,7 +779,7 @@ func NewFromConfig(logger *logrus.Logger, conf Config) (*Server, error) {
 				conf.KafkaMetricTopic, conf.KafkaMetricRequireAcks,
 				conf.KafkaPartitioner, conf.KafkaRetryMax,
 				conf.KafkaMetricBufferBytes, conf.KafkaMetricBufferMessages,
-				conf.KafkaMetricBufferFrequency,
+				conf.KafkaMetricBufferFrequency, conf.KafkaMetricSketches,
 			)
 			if err != nil {
 				return ret, err
@@ -680,6 +825,61 @@ func NewFromConfig(logger *logrus.Logger, conf Config) (*Server, error) {
 	// After all sinks are initialized, set the list of tags to exclude
 	setSinkExcludedTags(conf.TagsExclude, ret.metricSinks, ret.spanSinks)
 
+	// Then put every span sink but the metric extraction one, which needs
+	// every span, behind tail sampling
+	if conf.TailSamplingDecisionWait != "" {
+		opts := tailsample.Options{
+			MaxTraces:      conf.TailSamplingMaxTraces,
+			KeepErrors:     conf.TailSamplingKeepErrors,
+			KeepIndicators: conf.TailSamplingKeepIndicators,
+			Tags:           conf.TailSamplingTags,
+			Percent:        conf.TailSamplingRatePercent,
+		}
+		opts.DecisionWait, err = time.ParseDuration(conf.TailSamplingDecisionWait)
+		if err != nil {
+			return ret, err
+		}
+		if conf.TailSamplingMinRootDuration != "" {
+			opts.MinRootDuration, err = time.ParseDuration(conf.TailSamplingMinRootDuration)
+			if err != nil {
+				return ret, err
+			}
+		}
+
+		var sampled []sinks.SpanSink
+		for _, sink := range ret.spanSinks {
+			if sink != sinks.SpanSink(metricSink) {
+				sampled = append(sampled, sink)
+			}
+		}
+		if len(conf.TailSamplingPeers) > 0 {
+			if conf.GrpcAddress == "" || conf.TailSamplingSelf == "" {
+				return ret, errors.New("tail_sampling_peers is set; must set grpc_address and tail_sampling_self")
+			}
+			opts.Self = conf.TailSamplingSelf
+			opts.Peers = make(map[string]sinks.SpanSink)
+			for _, peer := range conf.TailSamplingPeers {
+				if peer == opts.Self {
+					continue
+				}
+				opts.Peers[peer], err = grpsink.NewGRPCSpanSink(
+					context.Background(), peer, "tail_sampling", log, ret.grpcDialOptions...)
+				if err != nil {
+					return ret, err
+				}
+			}
+		}
+		sampler := tailsample.New(sampled, opts, log)
+		if len(opts.Peers) > 0 {
+			ret.tailSampler = sampler
+		}
+		ret.spanSinks = []sinks.SpanSink{metricSink, sampler}
+		logger.WithFields(logrus.Fields{
+			"decision_wait": opts.DecisionWait,
+			"peers":         len(opts.Peers),
+		}).Info("Tail sampling spans")
+	}
+
 	var svc s3iface.S3API
 	awsID := conf.AwsAccessKeyID
 	awsSecret := conf.AwsSecretAccessKey
@@ -736,25 +936,62 @@ func NewFromConfig(logger *logrus.Logger, conf Config) (*Server, error) {
 	// Don't emit keys into logs now that we're done with them.
 	conf.SentryDsn = REDACTED
 	conf.TLSKey = REDACTED
+	conf.GrpcTLSKey = REDACTED
+	conf.ForwardGrpcBearerToken = REDACTED
+	conf.GrpcBearerTokens = nil
 	conf.DatadogAPIKey = REDACTED
 	conf.SignalfxAPIKey = REDACTED
 	conf.LightstepAccessToken = REDACTED
+	conf.PrometheusRemoteWriteBearerToken = REDACTED
 	conf.AwsAccessKeyID = REDACTED
 	conf.AwsSecretAccessKey = REDACTED
 
 	ret.forwardUseGRPC = conf.ForwardUseGrpc
+	ret.grpcBatchSize = conf.ForwardGrpcBatchSize
+
+	if conf.ForwardSpoolDirectory != "" && ret.IsLocal() {
+		var maxAge time.Duration
+		if conf.ForwardSpoolMaxAge != "" {
+			maxAge, err = time.ParseDuration(conf.ForwardSpoolMaxAge)
+			if err != nil {
+				return ret, err
+			}
+		}
+		ret.forwardSpool, err = spool.New(conf.ForwardSpoolDirectory, int64(conf.ForwardSpoolMaxBytes), maxAge, ret.Statsd)
+		if err != nil {
+			return ret, err
+		}
+	}
 
 	// Setup the grpc server if it was configured
 	ret.grpcListenAddress = conf.GrpcAddress
 	if ret.grpcListenAddress != "" {
 		// convert all the workers to the proper interface
 		ingesters := make([]importsrv.MetricIngester, len(ret.Workers))
+		udpIngesters := make([]importsrv.UDPMetricIngester, len(ret.Workers))
 		for i, worker := range ret.Workers {
 			ingesters[i] = worker
+			udpIngesters[i] = worker
+		}
+		if len(ret.relabeler) > 0 {
+			udpIngesters = []importsrv.UDPMetricIngester{relabelingIngester{ret}}
 		}
 
-		ret.grpcServer = importsrv.New(ingesters,
-			importsrv.WithTraceClient(ret.TraceClient))
+		opts := []importsrv.Option{
+			importsrv.WithTraceClient(ret.TraceClient),
+			importsrv.WithOTLPSpans(spanIngester{ret, "otlp"}),
+			importsrv.WithJaegerSpans(spanIngester{ret, "jaeger"}),
+			importsrv.WithOTLPMetrics(udpIngesters),
+			importsrv.WithBackfill(backfillIngester{ret}),
+		}
+		if !ret.IsLocal() {
+			opts = append(opts, importsrv.WithHandoff(handoffReceiver{ret}))
+		}
+		if ret.tailSampler != nil {
+			opts = append(opts, importsrv.WithPeerSpans(peerSpanIngester{ret.tailSampler}))
+		}
+		opts = append(opts, importsrv.WithServerOptions(grpcServerOpts...))
+		ret.grpcServer = importsrv.New(ingesters, opts...)
 	}
 
 	logger.WithField("config", conf).Debug("Initialized server")
@@ -834,11 +1071,19 @@ func (s *Server) Start() {
 	} else {
 		logrus.Info("Tracing sockets are not configured - not reading trace socket")
 	}
+	if s.ZipkinListenAddr != "" {
+		s.ZipkinListenAddr = startSpanHTTP(s, "zipkin", s.ZipkinListenAddr,
+			zipkin.NewHandler(spanIngester{s, "zipkin"}, s.spanHTTPMaxBodyBytes, s.TraceClient, log)).String()
+	}
+	if s.JaegerListenAddr != "" {
+		s.JaegerListenAddr = startSpanHTTP(s, "jaeger", s.JaegerListenAddr,
+			jaeger.NewHandler(spanIngester{s, "jaeger"}, s.spanHTTPMaxBodyBytes, s.TraceClient, log)).String()
+	}
 
 	// Initialize a gRPC connection for forwarding
 	if s.forwardUseGRPC {
 		var err error
-		s.grpcForwardConn, err = grpc.Dial(s.ForwardAddr, grpc.WithInsecure())
+		s.grpcForwardConn, err = grpc.Dial(s.ForwardAddr, s.grpcDialOptions...)
 		if err != nil {
 			log.WithError(err).WithFields(logrus.Fields{
 				"forwardAddr": s.ForwardAddr,
@@ -846,6 +1091,11 @@ func (s *Server) Start() {
 		}
 	}
 
+	// Replay the forwards that failed, including in a previous run
+	if s.forwardSpool != nil {
+		go s.replayForwardSpool()
+	}
+
 	// Flush every Interval forever!
 	go func() {
 		defer func() {
@@ -977,6 +1227,9 @@ func (s *Server) HandleMetricPacket(packet []byte) error {
 			samples.Add(ssf.Count("packet.error_total", 1, map[string]string{"packet_type": "metric", "reason": "parse"}))
 			return err
 		}
+		if !s.relabeler.Relabel(metric) {
+			return nil
+		}
 		s.Workers[metric.Digest%uint32(len(s.Workers))].PacketChan <- *metric
 	}
 	return nil
@@ -1015,6 +1268,45 @@ func (s *Server) HandleTracePacket(packet []byte) {
 	s.handleSSF(span, "packet")
 }
 
+// spanIngester feeds spans that were translated to SSF from another
+// tracing protocol, like OTLP, Zipkin or Jaeger, into the span pipeline.
+type spanIngester struct {
+	s *Server
+	// the ssf_format that the spans are counted under
+	format string
+}
+
+func (si spanIngester) IngestSpan(span *ssf.SSFSpan) {
+	si.s.handleSSF(span, si.format)
+}
+
+// peerSpanIngester passes the spans that tail sampling peers send straight
+// to the Sampler, because the peer that received them already extracted
+// metrics from them.
+type peerSpanIngester struct {
+	sampler *tailsample.Sampler
+}
+
+func (pi peerSpanIngester) IngestSpan(span *ssf.SSFSpan) {
+	if err := pi.sampler.IngestFromPeer(span); err != nil {
+		log.WithError(err).Debug("Couldn't sample a span from a tail sampling peer")
+	}
+}
+
+// relabelingIngester relabels the metrics it ingests before hashing them to
+// a worker, so that each relabeled timeseries is still aggregated by a
+// single worker.
+type relabelingIngester struct {
+	s *Server
+}
+
+func (ri relabelingIngester) IngestUDP(metric samplers.UDPMetric) {
+	if !ri.s.relabeler.Relabel(&metric) {
+		return
+	}
+	ri.s.Workers[metric.Digest%uint32(len(ri.s.Workers))].IngestUDP(metric)
+}
+
 func (s *Server) handleSSF(span *ssf.SSFSpan, ssfFormat string) {
 	// 1/internalMetricSampleRate packets will be chosen
 	const internalMetricSampleRate = 1000
@@ -1427,6 +1719,9 @@ func (s *Server) Shutdown() {
 // IsLocal indicates whether veneur is running as a local instance
 // (forwarding non-local data to a global veneur instance) or is running as a global
 // instance (sending all data directly to the final destination).
+//
+// A regional instance, which other local instances forward to, is local
+// too: it merges what it imports, and forwards it on to the global instance.
 func (s *Server) IsLocal() bool {
 	return s.ForwardAddr != ""
 }
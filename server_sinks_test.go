@@ -14,6 +14,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stripe/veneur/sinks/datadog"
 	"github.com/stripe/veneur/sinks/lightstep"
 )
@@ -162,3 +163,30 @@ func TestNewDatadogMetricSinkConfig(t *testing.T) {
 	// Verify that the values got set	assert.Equal(t, "apikey", sink.APIKey)
 	assert.Equal(t, "http://api", sink.DDHostname)
 }
+
+func TestTailSamplingWrapsSpanSinks(t *testing.T) {
+	config := Config{
+		DatadogAPIKey:            "apikey",
+		DatadogAPIHostname:       "http://api",
+		DatadogTraceAPIAddress:   "http://trace",
+		DatadogSpanBufferSize:    32,
+		SsfListenAddresses:       []string{"udp://127.0.0.1:99"},
+		TailSamplingDecisionWait: "10s",
+		TailSamplingKeepErrors:   true,
+
+		// required or NewFromConfig fails
+		Interval:     "10s",
+		StatsAddress: "localhost:62251",
+	}
+	server, err := NewFromConfig(logrus.New(), config)
+	require.NoError(t, err)
+
+	require.Len(t, server.spanSinks, 2)
+	assert.Equal(t, "metric_extraction", server.spanSinks[0].Name(),
+		"the metric extraction sink should get every span")
+	assert.Equal(t, "tail_sampling", server.spanSinks[1].Name())
+
+	config.TailSamplingDecisionWait = "soon"
+	_, err = NewFromConfig(logrus.New(), config)
+	assert.Error(t, err)
+}
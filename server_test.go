@@ -264,6 +264,39 @@ func TestLocalServerUnaggregatedMetrics(t *testing.T) {
 	assert.Equal(t, 6, len(interMetrics), "incorrect number of elements in the flushed series on the remote server")
 }
 
+func TestServerRelabelsBeforeWorkers(t *testing.T) {
+	config := localConfig()
+	metricsChan := make(chan []samplers.InterMetric, 10)
+	cms, _ := NewChannelMetricSink(metricsChan)
+	defer close(metricsChan)
+
+	f := newFixture(t, config, cms, nil)
+	defer f.Close()
+
+	rename, err := samplers.NewRelabelRule(samplers.RelabelConfig{Action: samplers.RelabelRename, MatchName: `^legacy\.`, Replacement: "app."})
+	require.NoError(t, err)
+	drop, err := samplers.NewRelabelRule(samplers.RelabelConfig{Action: samplers.RelabelDrop, MatchName: `\.debug$`})
+	require.NoError(t, err)
+	f.server.relabeler = samplers.Relabeler{rename, drop}
+
+	require.NoError(t, f.server.HandleMetricPacket([]byte("legacy.hits:1|c|#env:prod")))
+	require.NoError(t, f.server.HandleMetricPacket([]byte("app.debug:1|c|#env:prod")))
+	// metrics extracted from SSF spans and received over OTLP are
+	// relabeled by a relabelingIngester:
+	ssfMetric, err := samplers.ParseMetricSSF(ssf.Count("app.hits", 2, map[string]string{"env": "prod"}))
+	require.NoError(t, err)
+	relabelingIngester{f.server}.IngestUDP(ssfMetric)
+
+	// wait for the workers to process the metrics
+	time.Sleep(100 * time.Millisecond)
+	f.server.Flush(context.TODO())
+
+	interMetrics := <-metricsChan
+	require.Len(t, interMetrics, 1, "relabeled metrics should be aggregated together, and dropped metrics should be gone")
+	assert.Equal(t, "app.hits", interMetrics[0].Name)
+	assert.Equal(t, float64(3), interMetrics[0].Value)
+}
+
 func TestGlobalServerFlush(t *testing.T) {
 	metricValues, expectedMetrics := generateMetrics()
 	config := globalConfig()
@@ -33,6 +33,7 @@ const IngestTimeout = 5 * time.Second
 var IngestTimeoutError = errors.New("Timed out writing to Kafka producer")
 
 var _ sinks.MetricSink = &KafkaMetricSink{}
+var _ sinks.SketchSink = &KafkaMetricSink{}
 var _ sinks.SpanSink = &KafkaSpanSink{}
 
 type KafkaMetricSink struct {
@@ -44,6 +45,9 @@ type KafkaMetricSink struct {
 	brokers     string
 	config      *sarama.Config
 	traceClient *trace.Client
+	// sketches makes the sink receive histograms, timers and
+	// distributions as their quantile sketch instead of percentiles.
+	sketches bool
 }
 
 type KafkaSpanSink struct {
@@ -59,8 +63,10 @@ type KafkaSpanSink struct {
 	traceClient     *trace.Client
 }
 
-// NewKafkaMetricSink creates a new Kafka Plugin.
-func NewKafkaMetricSink(logger *logrus.Logger, cl *trace.Client, brokers string, checkTopic string, eventTopic string, metricTopic string, ackRequirement string, partitioner string, retries int, bufferBytes int, bufferMessages int, bufferDuration string) (*KafkaMetricSink, error) {
+// NewKafkaMetricSink creates a new Kafka Plugin. If sketches is set,
+// histograms, timers and distributions are published with their quantile
+// sketch instead of as percentiles.
+func NewKafkaMetricSink(logger *logrus.Logger, cl *trace.Client, brokers string, checkTopic string, eventTopic string, metricTopic string, ackRequirement string, partitioner string, retries int, bufferBytes int, bufferMessages int, bufferDuration string, sketches bool) (*KafkaMetricSink, error) {
 	if logger == nil {
 		logger = &logrus.Logger{Out: ioutil.Discard}
 	}
@@ -93,6 +99,7 @@ func NewKafkaMetricSink(logger *logrus.Logger, cl *trace.Client, brokers string,
 		"buffer_bytes":    bufferBytes,
 		"buffer_messages": bufferMessages,
 		"buffer_duration": bufferDuration,
+		"sketches":        sketches,
 	}).Info("Created Kafka metric sink")
 
 	return &KafkaMetricSink{
@@ -103,6 +110,7 @@ func NewKafkaMetricSink(logger *logrus.Logger, cl *trace.Client, brokers string,
 		brokers:     brokers,
 		config:      config,
 		traceClient: cl,
+		sketches:    sketches,
 	}, nil
 }
 
@@ -219,6 +227,12 @@ func (k *KafkaMetricSink) Flush(ctx context.Context, interMetrics []samplers.Int
 	return nil
 }
 
+// AcceptsSketches returns true if the sink was configured to publish
+// quantile sketches.
+func (k *KafkaMetricSink) AcceptsSketches() bool {
+	return k.sketches
+}
+
 // FlushOtherSamples flushes non-metric, non-span samples
 func (k *KafkaMetricSink) FlushOtherSamples(ctx context.Context, samples []ssf.SSFSample) {
 	// TODO
@@ -28,7 +28,7 @@ func TestMetricFlush(t *testing.T) {
 	// https://github.com/stripe/veneur/issues/277
 	logger := logrus.StandardLogger()
 
-	sink, err := NewKafkaMetricSink(logger, nil, "testing", "testCheckTopic", "testEventTopic", "testMetricTopic", "all", "hash", 0, 0, 0, "")
+	sink, err := NewKafkaMetricSink(logger, nil, "testing", "testCheckTopic", "testEventTopic", "testMetricTopic", "all", "hash", 0, 0, 0, "", false)
 	assert.NoError(t, err)
 	sink.Start(trace.DefaultClient)
 
@@ -122,7 +122,7 @@ func TestMetricFlushRouting(t *testing.T) {
 			// https://github.com/stripe/veneur/issues/277
 			logger := logrus.StandardLogger()
 
-			sink, err := NewKafkaMetricSink(logger, nil, "testing", "testCheckTopic", "testEventTopic", "testMetricTopic", "all", "hash", 0, 0, 0, "")
+			sink, err := NewKafkaMetricSink(logger, nil, "testing", "testCheckTopic", "testEventTopic", "testMetricTopic", "all", "hash", 0, 0, 0, "", false)
 			assert.NoError(t, err)
 			sink.Start(trace.DefaultClient)
 
@@ -150,7 +150,7 @@ func TestMetricFlushRouting(t *testing.T) {
 func TestMetricConstructor(t *testing.T) {
 	logger := logrus.StandardLogger()
 
-	sink, err := NewKafkaMetricSink(logger, nil, "testing", "veneur_checks", "veneur_events", "veneur_metrics", "all", "hash", 1, 2, 3, "10s")
+	sink, err := NewKafkaMetricSink(logger, nil, "testing", "veneur_checks", "veneur_events", "veneur_metrics", "all", "hash", 1, 2, 3, "10s", false)
 	assert.NoError(t, err)
 
 	assert.Equal(t, "kafka", sink.Name())
@@ -170,11 +170,11 @@ func TestMetricInstantiateError(t *testing.T) {
 	logger := logrus.StandardLogger()
 
 	// Busted duration
-	_, err1 := NewKafkaMetricSink(logger, nil, "testing", "veneur_checks", "veneur_events", "veneur_metrics", "all", "hash", 1, 2, 3, "farts")
+	_, err1 := NewKafkaMetricSink(logger, nil, "testing", "veneur_checks", "veneur_events", "veneur_metrics", "all", "hash", 1, 2, 3, "farts", false)
 	assert.Error(t, err1)
 
 	// No topics
-	_, err := NewKafkaMetricSink(logger, nil, "testing", "", "", "", "all", "hash", 1, 2, 3, "10s")
+	_, err := NewKafkaMetricSink(logger, nil, "testing", "", "", "", "all", "hash", 1, 2, 3, "10s", false)
 	assert.Error(t, err)
 }
 
@@ -0,0 +1,291 @@
+// Package otlp provides a span sink that exports spans to any
+// OpenTelemetry (OTLP) compatible backend over gRPC.
+package otlp
+
+import (
+	"container/ring"
+	"context"
+	"encoding/binary"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+
+	"github.com/stripe/veneur/otlp/collectortracepb"
+	"github.com/stripe/veneur/otlp/commonpb"
+	"github.com/stripe/veneur/otlp/resourcepb"
+	"github.com/stripe/veneur/otlp/tracepb"
+	"github.com/stripe/veneur/protocol"
+	"github.com/stripe/veneur/sinks"
+	"github.com/stripe/veneur/ssf"
+	"github.com/stripe/veneur/trace"
+	"github.com/stripe/veneur/trace/metrics"
+)
+
+const (
+	// DefaultSpanBufferSize is the number of spans retained between
+	// flushes if no buffer size is configured.
+	DefaultSpanBufferSize = 1 << 14
+
+	// DefaultBatchSize is the maximum number of spans sent in a single
+	// export request if no batch size is configured.
+	DefaultBatchSize = 512
+
+	// exportTimeout bounds each export request, so that a slow backend
+	// can't hold up the next flush.
+	exportTimeout = 10 * time.Second
+
+	serviceNameAttribute = "service.name"
+	errorMessageTag      = "error.msg"
+	spanKindTag          = "span.kind"
+	scopeName            = "veneur"
+)
+
+// SpanSink buffers spans and exports them to an OTLP/gRPC endpoint on
+// every flush.
+type SpanSink struct {
+	target     string
+	grpcConn   *grpc.ClientConn
+	client     collectortracepb.TraceServiceClient
+	buffer     *ring.Ring
+	bufferSize int
+	batchSize  int
+	// commonTags are added to every span's attributes, unless the span
+	// has a tag of the same name.
+	commonTags  map[string]string
+	mutex       *sync.Mutex
+	traceClient *trace.Client
+	log         *logrus.Logger
+}
+
+var _ sinks.SpanSink = &SpanSink{}
+
+// NewSpanSink creates a span sink that exports to the OTLP/gRPC endpoint
+// at target. At most bufferSize spans are retained between flushes, and
+// they are exported in requests of at most batchSize spans. Any
+// grpc.DialOptions are used while establishing the connection.
+func NewSpanSink(ctx context.Context, target string, bufferSize, batchSize int, commonTags map[string]string, log *logrus.Logger, opts ...grpc.DialOption) (*SpanSink, error) {
+	if bufferSize == 0 {
+		bufferSize = DefaultSpanBufferSize
+	}
+	if batchSize == 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	conn, err := grpc.DialContext(ctx, target, opts...)
+	if err != nil {
+		log.WithError(err).WithField("target", target).
+			Error("Error establishing connection to OTLP endpoint")
+		return nil, err
+	}
+
+	return &SpanSink{
+		target:     target,
+		grpcConn:   conn,
+		client:     collectortracepb.NewTraceServiceClient(conn),
+		buffer:     ring.New(bufferSize),
+		bufferSize: bufferSize,
+		batchSize:  batchSize,
+		commonTags: commonTags,
+		mutex:      &sync.Mutex{},
+		log:        log,
+	}, nil
+}
+
+// Name returns the name of this sink.
+func (ot *SpanSink) Name() string {
+	return "otlp"
+}
+
+// Start performs final adjustments on the sink.
+func (ot *SpanSink) Start(cl *trace.Client) error {
+	ot.traceClient = cl
+	return nil
+}
+
+// Ingest takes the span and adds it to the ringbuffer. If the buffer is
+// full, the oldest span is overwritten.
+func (ot *SpanSink) Ingest(span *ssf.SSFSpan) error {
+	if err := protocol.ValidateTrace(span); err != nil {
+		return err
+	}
+	ot.mutex.Lock()
+	defer ot.mutex.Unlock()
+
+	ot.buffer.Value = span
+	ot.buffer = ot.buffer.Next()
+	return nil
+}
+
+// Flush exports the spans buffered since the last flush, in batches of
+// at most batchSize spans.
+func (ot *SpanSink) Flush() {
+	samples := &ssf.Samples{}
+	defer metrics.Report(ot.traceClient, samples)
+
+	flushStart := time.Now()
+	ot.mutex.Lock()
+	spans := make([]*ssf.SSFSpan, 0, ot.buffer.Len())
+	ot.buffer.Do(func(t interface{}) {
+		if span, ok := t.(*ssf.SSFSpan); ok {
+			spans = append(spans, span)
+		}
+	})
+	// Reset the ring.
+	ot.buffer = ring.New(ot.bufferSize)
+	ot.mutex.Unlock()
+
+	if len(spans) == 0 {
+		ot.log.Debug("No spans to flush to OTLP, skipping.")
+		return
+	}
+
+	tags := map[string]string{"sink": ot.Name()}
+	serviceCount := map[string]int64{}
+	var dropped int
+	for start := 0; start < len(spans); start += ot.batchSize {
+		end := start + ot.batchSize
+		if end > len(spans) {
+			end = len(spans)
+		}
+		batch := spans[start:end]
+
+		ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+		_, err := ot.client.Export(ctx, &collectortracepb.ExportTraceServiceRequest{
+			ResourceSpans: ot.resourceSpans(batch),
+		})
+		cancel()
+		if err != nil {
+			dropped += len(batch)
+			ot.log.WithError(err).WithFields(logrus.Fields{
+				"target": ot.target,
+				"spans":  len(batch),
+			}).Warn("Error exporting spans to OTLP endpoint")
+			continue
+		}
+		for _, span := range batch {
+			serviceCount[span.Service]++
+		}
+	}
+
+	for service, count := range serviceCount {
+		samples.Add(ssf.Count(sinks.MetricKeyTotalSpansFlushed, float32(count),
+			map[string]string{"sink": ot.Name(), "service": service}))
+	}
+	samples.Add(
+		ssf.Count(sinks.MetricKeyTotalSpansDropped, float32(dropped), tags),
+		ssf.Timing(sinks.MetricKeySpanFlushDuration, time.Since(flushStart), time.Nanosecond, tags),
+	)
+	ot.log.WithFields(logrus.Fields{
+		"spans":   len(spans) - dropped,
+		"dropped": dropped,
+	}).Info("Completed flushing spans to OTLP")
+}
+
+// resourceSpans groups spans by service, since each service is a
+// separate resource in OTLP.
+func (ot *SpanSink) resourceSpans(spans []*ssf.SSFSpan) []*tracepb.ResourceSpans {
+	byService := map[string][]*tracepb.Span{}
+	for _, span := range spans {
+		byService[span.Service] = append(byService[span.Service], ot.otlpSpan(span))
+	}
+
+	services := make([]string, 0, len(byService))
+	for service := range byService {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	res := make([]*tracepb.ResourceSpans, 0, len(services))
+	for _, service := range services {
+		res = append(res, &tracepb.ResourceSpans{
+			Resource: &resourcepb.Resource{
+				Attributes: []*commonpb.KeyValue{stringAttribute(serviceNameAttribute, service)},
+			},
+			ScopeSpans: []*tracepb.ScopeSpans{{
+				Scope: &commonpb.InstrumentationScope{Name: scopeName},
+				Spans: byService[service],
+			}},
+		})
+	}
+	return res
+}
+
+// otlpSpan converts an SSF span to OTLP. The "span.kind" and "error.msg"
+// tags become the span's kind and status message, and all other tags
+// become string attributes.
+func (ot *SpanSink) otlpSpan(span *ssf.SSFSpan) *tracepb.Span {
+	res := &tracepb.Span{
+		TraceId:           traceID(span.FullTraceID()),
+		SpanId:            spanID(span.Id),
+		Name:              span.Name,
+		StartTimeUnixNano: uint64(span.StartTimestamp),
+		EndTimeUnixNano:   uint64(span.EndTimestamp),
+		Status:            &tracepb.Status{},
+	}
+	if span.ParentId > 0 {
+		res.ParentSpanId = spanID(span.ParentId)
+	}
+	if span.Error {
+		res.Status.Code = tracepb.Status_STATUS_CODE_ERROR
+		res.Status.Message = span.Tags[errorMessageTag]
+	}
+
+	keys := make([]string, 0, len(span.Tags)+len(ot.commonTags))
+	for k := range ot.commonTags {
+		if _, ok := span.Tags[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	for k := range span.Tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	res.Attributes = make([]*commonpb.KeyValue, 0, len(keys))
+	for _, k := range keys {
+		v, ok := span.Tags[k]
+		if !ok {
+			v = ot.commonTags[k]
+		}
+		switch k {
+		case spanKindTag:
+			if kind, ok := tracepb.Span_SpanKind_value["SPAN_KIND_"+strings.ToUpper(v)]; ok {
+				res.Kind = tracepb.Span_SpanKind(kind)
+				continue
+			}
+		case errorMessageTag:
+			if span.Error {
+				continue
+			}
+		}
+		res.Attributes = append(res.Attributes, stringAttribute(k, v))
+	}
+	return res
+}
+
+func stringAttribute(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}},
+	}
+}
+
+// traceID encodes the high and low 64 bits of an SSF trace ID as a
+// 16-byte OTLP trace ID.
+func traceID(high, low uint64) []byte {
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint64(b, high)
+	binary.BigEndian.PutUint64(b[8:], low)
+	return b
+}
+
+// spanID encodes an SSF span ID as an 8-byte OTLP span ID.
+func spanID(id int64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(id))
+	return b
+}
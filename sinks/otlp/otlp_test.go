@@ -0,0 +1,123 @@
+package otlp
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/stripe/veneur/otlp/collectortracepb"
+	"github.com/stripe/veneur/otlp/tracepb"
+	"github.com/stripe/veneur/ssf"
+)
+
+type testTraceServer struct {
+	mtx      sync.Mutex
+	requests []*collectortracepb.ExportTraceServiceRequest
+	fail     bool
+}
+
+func (ts *testTraceServer) Export(ctx context.Context, req *collectortracepb.ExportTraceServiceRequest) (*collectortracepb.ExportTraceServiceResponse, error) {
+	ts.mtx.Lock()
+	defer ts.mtx.Unlock()
+	if ts.fail {
+		return nil, status.Error(codes.Unavailable, "try again later")
+	}
+	ts.requests = append(ts.requests, req)
+	return &collectortracepb.ExportTraceServiceResponse{}, nil
+}
+
+func newTestSink(t *testing.T, batchSize int) (*SpanSink, *testTraceServer, *grpc.Server) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	ts := &testTraceServer{}
+	collectortracepb.RegisterTraceServiceServer(srv, ts)
+	go srv.Serve(ln)
+
+	sink, err := NewSpanSink(context.Background(), ln.Addr().String(), 0, batchSize,
+		map[string]string{"region": "us", "env": "prod"}, logrus.New(), grpc.WithInsecure())
+	require.NoError(t, err)
+	require.NoError(t, sink.Start(nil))
+	return sink, ts, srv
+}
+
+func testSpan(id int64, service string) *ssf.SSFSpan {
+	return &ssf.SSFSpan{
+		TraceId:        1,
+		Id:             id,
+		ParentId:       1,
+		StartTimestamp: 1000,
+		EndTimestamp:   2000,
+		Service:        service,
+		Name:           "charge",
+		Tags:           map[string]string{"env": "canary"},
+	}
+}
+
+func TestOTLPSpanSinkFlush(t *testing.T) {
+	sink, ts, srv := newTestSink(t, 2)
+	defer srv.Stop()
+
+	errSpan := testSpan(4, "checkout")
+	errSpan.SetFullTraceID(0x0102030405060708, 0x8000000000000001)
+	errSpan.Error = true
+	errSpan.Tags = map[string]string{"error.msg": "declined", "span.kind": "server"}
+	for _, span := range []*ssf.SSFSpan{testSpan(2, "checkout"), testSpan(3, "billing"), errSpan} {
+		require.NoError(t, sink.Ingest(span))
+	}
+	assert.Error(t, sink.Ingest(&ssf.SSFSpan{}), "invalid spans should be rejected")
+	sink.Flush()
+
+	require.Len(t, ts.requests, 2, "spans should be exported in batches of 2")
+	first := ts.requests[0].ResourceSpans
+	require.Len(t, first, 2, "each service should be a separate resource")
+	assert.Equal(t, "billing", first[0].Resource.Attributes[0].Value.GetStringValue())
+
+	span := first[1].ScopeSpans[0].Spans[0]
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}, span.TraceId)
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 2}, span.SpanId)
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 1}, span.ParentSpanId)
+	assert.Equal(t, uint64(1000), span.StartTimeUnixNano)
+	assert.Equal(t, tracepb.Status_STATUS_CODE_UNSET, span.Status.Code)
+	require.Len(t, span.Attributes, 2)
+	assert.Equal(t, "env", span.Attributes[0].Key)
+	assert.Equal(t, "canary", span.Attributes[0].Value.GetStringValue(), "span tags should win over common tags")
+	assert.Equal(t, "region", span.Attributes[1].Key)
+
+	span = ts.requests[1].ResourceSpans[0].ScopeSpans[0].Spans[0]
+	assert.Equal(t, []byte{1, 2, 3, 4, 5, 6, 7, 8, 0x80, 0, 0, 0, 0, 0, 0, 1}, span.TraceId,
+		"128-bit trace IDs should be sent in full")
+	assert.Equal(t, tracepb.Status_STATUS_CODE_ERROR, span.Status.Code)
+	assert.Equal(t, "declined", span.Status.Message)
+	assert.Equal(t, tracepb.Span_SPAN_KIND_SERVER, span.Kind)
+	for _, attr := range span.Attributes {
+		assert.NotContains(t, []string{"error.msg", "span.kind"}, attr.Key)
+	}
+
+	// the buffer is empty after a flush:
+	sink.Flush()
+	assert.Len(t, ts.requests, 2)
+}
+
+func TestOTLPSpanSinkFlushError(t *testing.T) {
+	sink, ts, srv := newTestSink(t, 0)
+	defer srv.Stop()
+	ts.fail = true
+
+	require.NoError(t, sink.Ingest(testSpan(2, "checkout")))
+	sink.Flush()
+	assert.Empty(t, ts.requests)
+
+	// failed spans are dropped, not retried:
+	ts.fail = false
+	sink.Flush()
+	assert.Empty(t, ts.requests)
+}
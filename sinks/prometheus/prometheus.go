@@ -0,0 +1,395 @@
+package prometheus
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/sirupsen/logrus"
+	"github.com/stripe/veneur/samplers"
+	"github.com/stripe/veneur/sinks"
+	"github.com/stripe/veneur/sinks/prometheus/prompb"
+	"github.com/stripe/veneur/ssf"
+	"github.com/stripe/veneur/trace"
+)
+
+// remoteWriteVersion is the version of the remote-write protocol that
+// this sink speaks.
+const remoteWriteVersion = "0.1.0"
+
+// DefaultCounterExpiry is the number of flushes after which the running
+// total of a counter series that hasn't been flushed again is dropped, if
+// no expiry is configured.
+const DefaultCounterExpiry = 10
+
+// metricNameLabel is the label that carries a series' metric name in
+// the remote-write protocol.
+const metricNameLabel = "__name__"
+
+// RemoteWriteSink is a MetricSink that pushes metrics to a Prometheus
+// remote-write endpoint.
+type RemoteWriteSink struct {
+	HTTPClient      *http.Client
+	endpoint        string
+	bearerToken     string
+	flushMaxPerBody int
+	hostname        string
+	tags            []string
+	excludedTags    []string
+	counters        *counterTotals
+	traceClient     *trace.Client
+	log             *logrus.Logger
+}
+
+// NewRemoteWriteSink creates a new sink that sends metrics to the
+// remote-write endpoint at writeURL. If bearerToken is non-empty, it
+// is sent along with every request. The running totals of counters are
+// dropped once they haven't been flushed for counterExpiry flushes.
+func NewRemoteWriteSink(writeURL string, bearerToken string, flushMaxPerBody int, counterExpiry int, hostname string, tags []string, httpClient *http.Client, log *logrus.Logger) (*RemoteWriteSink, error) {
+	if _, err := url.ParseRequestURI(writeURL); err != nil {
+		return nil, err
+	}
+	return &RemoteWriteSink{
+		HTTPClient:      httpClient,
+		endpoint:        writeURL,
+		bearerToken:     bearerToken,
+		flushMaxPerBody: flushMaxPerBody,
+		hostname:        hostname,
+		tags:            tags,
+		counters:        newCounterTotals(counterExpiry),
+		log:             log,
+	}, nil
+}
+
+// Name returns the name of this sink.
+func (prw *RemoteWriteSink) Name() string {
+	return "prometheus_remote_write"
+}
+
+// Start sets the sink up.
+func (prw *RemoteWriteSink) Start(cl *trace.Client) error {
+	prw.traceClient = cl
+	return nil
+}
+
+// SetExcludedTags sets the excluded tag names. Any tags with the
+// provided key (name) will be excluded.
+func (prw *RemoteWriteSink) SetExcludedTags(excludes []string) {
+	prw.excludedTags = excludes
+}
+
+// Flush sends metrics to the remote-write endpoint, in batches of at
+// most flushMaxPerBody series.
+func (prw *RemoteWriteSink) Flush(ctx context.Context, interMetrics []samplers.InterMetric) error {
+	span, _ := trace.StartSpanFromContext(ctx, "")
+	defer span.ClientFinish(prw.traceClient)
+
+	flushStart := time.Now()
+	series, skipped := prw.finalizeMetrics(interMetrics)
+	prw.counters.expire()
+	tags := map[string]string{"sink": prw.Name()}
+	span.Add(ssf.Count(sinks.MetricKeyTotalMetricsSkipped, float32(skipped), tags))
+	if len(series) == 0 {
+		return nil
+	}
+
+	// break the series into chunks of approximately equal size, such that
+	// each chunk is less than the limit
+	// we compute the chunks using rounding-up integer division
+	workers := 1
+	if prw.flushMaxPerBody > 0 {
+		workers = ((len(series) - 1) / prw.flushMaxPerBody) + 1
+	}
+	chunkSize := ((len(series) - 1) / workers) + 1
+	var wg sync.WaitGroup
+	errs := make([]error, workers)
+	for i := 0; i < workers; i++ {
+		chunk := series[i*chunkSize:]
+		if i < workers-1 {
+			// trim to chunk size unless this is the last one
+			chunk = chunk[:chunkSize]
+		}
+		wg.Add(1)
+		go func(i int, chunk []prompb.TimeSeries) {
+			defer wg.Done()
+			errs[i] = prw.flushPart(span.Attach(ctx), chunk)
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	span.Add(
+		ssf.Timing(sinks.MetricKeyMetricFlushDuration, time.Since(flushStart), time.Nanosecond, tags),
+		ssf.Count(sinks.MetricKeyTotalMetricsFlushed, float32(len(series)), tags),
+	)
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	prw.log.WithField("metrics", len(series)).Info("Completed flush to Prometheus remote-write")
+	return nil
+}
+
+// FlushOtherSamples is a no-op; Prometheus has no notion of events or
+// service checks.
+func (prw *RemoteWriteSink) FlushOtherSamples(ctx context.Context, samples []ssf.SSFSample) {}
+
+// finalizeMetrics converts the metrics that this sink accepts into
+// remote-write time series, and returns the number of metrics it
+// skipped.
+func (prw *RemoteWriteSink) finalizeMetrics(metrics []samplers.InterMetric) ([]prompb.TimeSeries, int) {
+	series := make([]prompb.TimeSeries, 0, len(metrics))
+	skipped := 0
+	for _, m := range metrics {
+		if !sinks.IsAcceptableMetric(m, prw) {
+			skipped++
+			continue
+		}
+
+		value := m.Value
+		switch m.Type {
+		case samplers.CounterMetric:
+			// Prometheus expects counters to be monotonic, so
+			// report the running total rather than this
+			// interval's delta.
+			value = prw.counters.add(seriesKey(m.Name, m.Tags), m.Value)
+		case samplers.GaugeMetric:
+		default:
+			skipped++
+			continue
+		}
+
+		series = append(series, prompb.TimeSeries{
+			Labels: labelsFor(m, prw.hostname, prw.tags, prw.excludedTags),
+			Samples: []prompb.Sample{{
+				Value:     value,
+				Timestamp: m.Timestamp * 1000,
+			}},
+		})
+	}
+	return series, skipped
+}
+
+func (prw *RemoteWriteSink) flushPart(ctx context.Context, series []prompb.TimeSeries) error {
+	span, _ := trace.StartSpanFromContext(ctx, "")
+	span.SetTag("action", "flush")
+	span.SetTag("sink", prw.Name())
+	defer span.ClientFinish(prw.traceClient)
+
+	extraTags := map[string]string{"sink": prw.Name()}
+	errorCount := func(cause string) *ssf.SSFSample {
+		return ssf.Count("flush.error_total", 1, map[string]string{"sink": prw.Name(), "cause": cause})
+	}
+
+	raw, err := (&prompb.WriteRequest{Timeseries: series}).Marshal()
+	if err != nil {
+		span.Error(err)
+		span.Add(errorCount("marshal"))
+		prw.log.WithError(err).Error("Could not marshal remote-write request")
+		return err
+	}
+	body := snappy.Encode(nil, raw)
+	span.Add(ssf.Count("flush.content_length_bytes", float32(len(body)), extraTags))
+
+	req, err := http.NewRequest(http.MethodPost, prw.endpoint, bytes.NewReader(body))
+	if err != nil {
+		span.Error(err)
+		span.Add(errorCount("construct"))
+		prw.log.WithError(err).Error("Could not construct request")
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", remoteWriteVersion)
+	if prw.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+prw.bearerToken)
+	}
+
+	resp, err := prw.HTTPClient.Do(req)
+	if err != nil {
+		if urlErr, ok := err.(*url.Error); ok {
+			// ditch the url, which may contain credentials
+			err = urlErr.Err
+		}
+		span.Error(err)
+		span.Add(errorCount("io"))
+		prw.log.WithError(err).Warn("Could not execute remote-write request")
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		respBody, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		err := fmt.Errorf("remote-write endpoint returned %s: %s", resp.Status, respBody)
+		span.Error(err)
+		span.Add(errorCount(strconv.Itoa(resp.StatusCode)))
+		prw.log.WithError(err).Warn("Could not POST to remote-write endpoint")
+		return err
+	}
+	// make sure the error metric isn't sparse
+	span.Add(ssf.Count("flush.error_total", 0, extraTags))
+	return nil
+}
+
+// labelsFor returns the sorted label set for a metric: its sanitized
+// name, the sink-wide tags and the metric's own tags (with the latter
+// taking precedence), and a "host" label unless the metric already
+// carries one.
+func labelsFor(m samplers.InterMetric, hostname string, commonTags []string, excludedTags []string) []prompb.Label {
+	byName := make(map[string]string, len(m.Tags)+len(commonTags)+2)
+	addTags := func(tags []string) {
+	TAGLOOP:
+		for _, tag := range tags {
+			for _, excl := range excludedTags {
+				if strings.HasPrefix(tag, excl) {
+					continue TAGLOOP
+				}
+			}
+			name, value := splitTag(tag)
+			byName[SanitizeLabelName(name)] = value
+		}
+	}
+	addTags(commonTags)
+	addTags(m.Tags)
+	if _, ok := byName["host"]; !ok && hostname != "" {
+		byName["host"] = hostname
+	}
+	byName[metricNameLabel] = SanitizeMetricName(m.Name)
+
+	labels := make([]prompb.Label, 0, len(byName))
+	for name, value := range byName {
+		labels = append(labels, prompb.Label{Name: name, Value: value})
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].Name < labels[j].Name
+	})
+	return labels
+}
+
+// splitTag splits a "key:value" tag into its parts. Tags without a
+// value are treated as a boolean flag, since Prometheus drops labels
+// with empty values.
+func splitTag(tag string) (string, string) {
+	parts := strings.SplitN(tag, ":", 2)
+	if len(parts) == 1 {
+		return parts[0], "true"
+	}
+	return parts[0], parts[1]
+}
+
+// SanitizeMetricName replaces all characters that aren't valid in a
+// Prometheus metric name with underscores. For example, "api.requests"
+// becomes "api_requests".
+func SanitizeMetricName(name string) string {
+	return sanitize(name, true)
+}
+
+// SanitizeLabelName replaces all characters that aren't valid in a
+// Prometheus label name with underscores.
+func SanitizeLabelName(name string) string {
+	return sanitize(name, false)
+}
+
+func sanitize(name string, allowColon bool) string {
+	if name == "" {
+		return "_"
+	}
+	b := []byte(name)
+	for i, c := range b {
+		valid := c == '_' ||
+			(c >= 'a' && c <= 'z') ||
+			(c >= 'A' && c <= 'Z') ||
+			(c >= '0' && c <= '9' && i > 0) ||
+			(c == ':' && allowColon)
+		if !valid {
+			b[i] = '_'
+		}
+	}
+	if name[0] >= '0' && name[0] <= '9' {
+		return "_" + name[:1] + string(b[1:])
+	}
+	return string(b)
+}
+
+// seriesKey returns a string that uniquely identifies a metric's series.
+func seriesKey(name string, tags []string) string {
+	sorted := make([]string, len(tags))
+	copy(sorted, tags)
+	sort.Strings(sorted)
+	return name + "|" + strings.Join(sorted, ",")
+}
+
+// counterTotals keeps the running total for each counter series a sink
+// has seen, so that per-interval deltas can be reported as monotonic
+// counters. Series that haven't been seen for expiry flushes are
+// dropped, so that short-lived series don't accumulate forever; if one
+// comes back, its total starts over, which Prometheus treats as a
+// counter reset.
+type counterTotals struct {
+	mtx     sync.Mutex
+	totals  map[string]*counterTotal
+	flushes int64
+	expiry  int64
+}
+
+type counterTotal struct {
+	value float64
+	// lastSeen is the number of the last flush the series was in.
+	lastSeen int64
+}
+
+func newCounterTotals(expiry int) *counterTotals {
+	if expiry <= 0 {
+		expiry = DefaultCounterExpiry
+	}
+	return &counterTotals{
+		totals: map[string]*counterTotal{},
+		expiry: int64(expiry),
+	}
+}
+
+// add adds delta to the series' running total and returns the new
+// total.
+func (ct *counterTotals) add(key string, delta float64) float64 {
+	ct.mtx.Lock()
+	defer ct.mtx.Unlock()
+	total, ok := ct.totals[key]
+	if !ok {
+		total = &counterTotal{}
+		ct.totals[key] = total
+	}
+	total.value += delta
+	total.lastSeen = ct.flushes
+	return total.value
+}
+
+// expire should be called at the end of every flush. It drops the
+// totals of the series that weren't seen in the last expiry flushes.
+func (ct *counterTotals) expire() {
+	ct.mtx.Lock()
+	defer ct.mtx.Unlock()
+	ct.flushes++
+	for key, total := range ct.totals {
+		if ct.flushes-total.lastSeen > ct.expiry {
+			delete(ct.totals, key)
+		}
+	}
+}
+
+// len returns the number of series with a running total.
+func (ct *counterTotals) len() int {
+	ct.mtx.Lock()
+	defer ct.mtx.Unlock()
+	return len(ct.totals)
+}
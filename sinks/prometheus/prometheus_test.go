@@ -0,0 +1,128 @@
+package prometheus
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/veneur/samplers"
+	"github.com/stripe/veneur/sinks/prometheus/prompb"
+)
+
+func TestSanitizeNames(t *testing.T) {
+	assert.Equal(t, "api_request_duration", SanitizeMetricName("api.request-duration"))
+	assert.Equal(t, "ns:metric_total", SanitizeMetricName("ns:metric_total"))
+	assert.Equal(t, "_1up", SanitizeMetricName("1up"))
+	assert.Equal(t, "ns_key", SanitizeLabelName("ns:key"))
+	assert.Equal(t, "_", SanitizeLabelName(""))
+}
+
+func TestLabelsFor(t *testing.T) {
+	m := samplers.InterMetric{
+		Name: "a.b.c",
+		Tags: []string{"foo:bar", "flag", "drop:me", "z.key:v"},
+	}
+	labels := labelsFor(m, "myhost", []string{"foo:common", "region:us"}, []string{"drop"})
+	assert.Equal(t, []prompb.Label{
+		{Name: "__name__", Value: "a_b_c"},
+		{Name: "flag", Value: "true"},
+		{Name: "foo", Value: "bar"},
+		{Name: "host", Value: "myhost"},
+		{Name: "region", Value: "us"},
+		{Name: "z_key", Value: "v"},
+	}, labels)
+
+	m.Tags = []string{"host:other"}
+	labels = labelsFor(m, "myhost", nil, nil)
+	assert.Contains(t, labels, prompb.Label{Name: "host", Value: "other"})
+}
+
+func TestRemoteWriteFlush(t *testing.T) {
+	var mtx sync.Mutex
+	var received []prompb.TimeSeries
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "snappy", r.Header.Get("Content-Encoding"))
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		assert.Equal(t, "Bearer s3cret", r.Header.Get("Authorization"))
+
+		compressed, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		raw, err := snappy.Decode(nil, compressed)
+		require.NoError(t, err)
+		req := &prompb.WriteRequest{}
+		require.NoError(t, req.Unmarshal(raw))
+
+		mtx.Lock()
+		received = append(received, req.Timeseries...)
+		mtx.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	sink, err := NewRemoteWriteSink(srv.URL, "s3cret", 2, 0, "myhost", nil, &http.Client{}, logrus.New())
+	require.NoError(t, err)
+	require.NoError(t, sink.Start(nil))
+
+	now := time.Now().Unix()
+	metrics := []samplers.InterMetric{
+		{Name: "a.counter", Timestamp: now, Value: 2, Type: samplers.CounterMetric},
+		{Name: "a.gauge", Timestamp: now, Value: 1.5, Type: samplers.GaugeMetric},
+		{Name: "a.check", Timestamp: now, Value: 1, Type: samplers.StatusMetric},
+		{Name: "elsewhere", Timestamp: now, Value: 1, Type: samplers.GaugeMetric,
+			Sinks: samplers.RouteInformation{"datadog": struct{}{}}},
+		{Name: "another.gauge", Timestamp: now, Value: 3, Type: samplers.GaugeMetric},
+	}
+	require.NoError(t, sink.Flush(context.Background(), metrics))
+	assert.Len(t, received, 3)
+	for _, ts := range received {
+		assert.Equal(t, now*1000, ts.Samples[0].Timestamp)
+	}
+
+	// counters are reported as running totals
+	received = nil
+	require.NoError(t, sink.Flush(context.Background(), metrics[:1]))
+	require.Len(t, received, 1)
+	assert.Equal(t, float64(4), received[0].Samples[0].Value)
+}
+
+func TestRemoteWriteFlushError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "out of order sample", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	sink, err := NewRemoteWriteSink(srv.URL, "", 0, 0, "myhost", nil, &http.Client{}, logrus.New())
+	require.NoError(t, err)
+	require.NoError(t, sink.Start(nil))
+
+	err = sink.Flush(context.Background(), []samplers.InterMetric{
+		{Name: "a.gauge", Timestamp: time.Now().Unix(), Value: 1, Type: samplers.GaugeMetric},
+	})
+	assert.Error(t, err)
+}
+
+func TestCounterTotalsExpire(t *testing.T) {
+	ct := newCounterTotals(2)
+	assert.Equal(t, float64(1), ct.add("a", 1))
+	ct.add("b", 1)
+	ct.expire()
+
+	// "a" keeps reporting, "b" stops:
+	ct.add("a", 1)
+	ct.expire()
+	assert.Equal(t, 2, ct.len(), "series should be kept for the expiry window")
+	ct.add("a", 1)
+	ct.expire()
+	assert.Equal(t, 1, ct.len(), "series missing from expiry flushes should be dropped")
+
+	assert.Equal(t, float64(4), ct.add("a", 1))
+	assert.Equal(t, float64(3), ct.add("b", 3), "a dropped series should start over")
+}
@@ -0,0 +1,307 @@
+package prometheus
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/sirupsen/logrus"
+	"github.com/stripe/veneur/samplers"
+	"github.com/stripe/veneur/sinks"
+	"github.com/stripe/veneur/ssf"
+	"github.com/stripe/veneur/trace"
+)
+
+// percentileName matches the names that samplers.Histo.Flush gives to
+// percentile metrics, e.g. "request.duration.99percentile" or
+// "request.duration.99_9percentile".
+var percentileName = regexp.MustCompile(`^(.+)\.(\d+(?:_\d+)?)percentile$`)
+
+// ScrapeSink is a MetricSink that keeps the metrics of the most recent
+// flush and serves them to Prometheus in the text exposition format. It
+// implements http.Handler.
+type ScrapeSink struct {
+	tags         []string
+	excludedTags []string
+	counters     *counterTotals
+
+	// exposition holds the rendered []byte of the last flush.
+	exposition atomic.Value
+
+	traceClient *trace.Client
+	log         *logrus.Logger
+}
+
+// NewScrapeSink creates a new sink that serves the last flushed
+// interval. tags are added to every series. The running totals of
+// counters are dropped once they haven't been flushed for counterExpiry
+// flushes.
+func NewScrapeSink(tags []string, counterExpiry int, log *logrus.Logger) *ScrapeSink {
+	s := &ScrapeSink{
+		tags:     tags,
+		counters: newCounterTotals(counterExpiry),
+		log:      log,
+	}
+	s.exposition.Store([]byte{})
+	return s
+}
+
+// Name returns the name of this sink.
+func (ps *ScrapeSink) Name() string {
+	return "prometheus_scrape"
+}
+
+// Start sets the sink up.
+func (ps *ScrapeSink) Start(cl *trace.Client) error {
+	ps.traceClient = cl
+	return nil
+}
+
+// SetExcludedTags sets the excluded tag names. Any tags with the
+// provided key (name) will be excluded.
+func (ps *ScrapeSink) SetExcludedTags(excludes []string) {
+	ps.excludedTags = excludes
+}
+
+// Flush replaces the snapshot served by ServeHTTP with the passed
+// metrics.
+func (ps *ScrapeSink) Flush(ctx context.Context, interMetrics []samplers.InterMetric) error {
+	span, _ := trace.StartSpanFromContext(ctx, "")
+	defer span.ClientFinish(ps.traceClient)
+
+	flushStart := time.Now()
+	families, skipped := ps.metricFamilies(interMetrics)
+	ps.counters.expire()
+
+	var buf bytes.Buffer
+	flushed := 0
+	for _, mf := range families {
+		if _, err := expfmt.MetricFamilyToText(&buf, mf); err != nil {
+			span.Error(err)
+			ps.log.WithError(err).WithField("name", mf.GetName()).Error("Could not render metric family")
+			return err
+		}
+		flushed += len(mf.Metric)
+	}
+	ps.exposition.Store(buf.Bytes())
+
+	tags := map[string]string{"sink": ps.Name()}
+	span.Add(
+		ssf.Timing(sinks.MetricKeyMetricFlushDuration, time.Since(flushStart), time.Nanosecond, tags),
+		ssf.Count(sinks.MetricKeyTotalMetricsFlushed, float32(flushed), tags),
+		ssf.Count(sinks.MetricKeyTotalMetricsSkipped, float32(skipped), tags),
+	)
+	return nil
+}
+
+// FlushOtherSamples is a no-op; Prometheus has no notion of events or
+// service checks.
+func (ps *ScrapeSink) FlushOtherSamples(ctx context.Context, samples []ssf.SSFSample) {}
+
+// ServeHTTP responds with the metrics of the last flush.
+func (ps *ScrapeSink) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", string(expfmt.FmtText))
+	w.Write(ps.exposition.Load().([]byte))
+}
+
+// summaryParts collects the pieces of a histogram that Histo.Flush
+// emitted as separate metrics.
+type summaryParts struct {
+	name      string
+	labels    []*dto.LabelPair
+	quantiles []*dto.Quantile
+	// count and sum are cumulative, like a summary's
+	count *float64
+	sum   *float64
+	// intervalSum is the sum aggregate of this interval alone
+	intervalSum float64
+}
+
+// metricFamilies converts metrics into Prometheus metric families,
+// sorted by name. Percentiles of the same histogram are combined into a
+// summary, together with the histogram's count and sum aggregates if
+// both were flushed. Without both of those, the percentiles become a
+// gauge with a "quantile" label, rather than a summary that claims to have
+// seen zero samples, and the count or sum is flushed as it would be without
+// percentiles.
+func (ps *ScrapeSink) metricFamilies(metrics []samplers.InterMetric) ([]*dto.MetricFamily, int) {
+	skipped := 0
+	summaries := map[string]*summaryParts{}
+	var summaryOrder []string
+	var rest []samplers.InterMetric
+	for _, m := range metrics {
+		if !sinks.IsAcceptableMetric(m, ps) {
+			skipped++
+			continue
+		}
+		match := percentileName.FindStringSubmatch(m.Name)
+		if m.Type != samplers.GaugeMetric || match == nil {
+			rest = append(rest, m)
+			continue
+		}
+		pct, err := strconv.ParseFloat(strings.Replace(match[2], "_", ".", 1), 64)
+		if err != nil {
+			rest = append(rest, m)
+			continue
+		}
+		name := SanitizeMetricName(match[1])
+		labels := ps.labelPairs(m)
+		key := familyKey(name, labels)
+		parts, ok := summaries[key]
+		if !ok {
+			parts = &summaryParts{name: name, labels: labels}
+			summaries[key] = parts
+			summaryOrder = append(summaryOrder, key)
+		}
+		parts.quantiles = append(parts.quantiles, &dto.Quantile{
+			Quantile: proto.Float64(pct / 100),
+			Value:    proto.Float64(m.Value),
+		})
+	}
+
+	families := map[string]*dto.MetricFamily{}
+	add := func(name string, typ dto.MetricType, metric *dto.Metric) bool {
+		mf, ok := families[name]
+		if !ok {
+			mf = &dto.MetricFamily{Name: proto.String(name), Type: typ.Enum()}
+			families[name] = mf
+		}
+		if mf.GetType() != typ {
+			ps.log.WithFields(logrus.Fields{
+				"name": name,
+				"type": typ,
+			}).Debug("Skipping metric whose type conflicts with an existing family")
+			return false
+		}
+		mf.Metric = append(mf.Metric, metric)
+		return true
+	}
+
+	for _, m := range rest {
+		name := SanitizeMetricName(m.Name)
+		labels := ps.labelPairs(m)
+		switch m.Type {
+		case samplers.CounterMetric:
+			if strings.HasSuffix(m.Name, ".count") {
+				base := SanitizeMetricName(strings.TrimSuffix(m.Name, ".count"))
+				if parts, ok := summaries[familyKey(base, labels)]; ok {
+					count := ps.counters.add(seriesKey(m.Name, m.Tags), m.Value)
+					parts.count = &count
+					continue
+				}
+			}
+			value := ps.counters.add(seriesKey(m.Name, m.Tags), m.Value)
+			if !add(name, dto.MetricType_COUNTER, &dto.Metric{
+				Label:   labels,
+				Counter: &dto.Counter{Value: proto.Float64(value)},
+			}) {
+				skipped++
+			}
+		case samplers.GaugeMetric:
+			if strings.HasSuffix(m.Name, ".sum") {
+				base := SanitizeMetricName(strings.TrimSuffix(m.Name, ".sum"))
+				if parts, ok := summaries[familyKey(base, labels)]; ok {
+					// The sum aggregate only covers this interval,
+					// but a summary's sum is cumulative.
+					sum := ps.counters.add(seriesKey(m.Name, m.Tags), m.Value)
+					parts.sum = &sum
+					parts.intervalSum = m.Value
+					continue
+				}
+			}
+			if !add(name, dto.MetricType_GAUGE, &dto.Metric{
+				Label: labels,
+				Gauge: &dto.Gauge{Value: proto.Float64(m.Value)},
+			}) {
+				skipped++
+			}
+		default:
+			skipped++
+		}
+	}
+
+	for _, key := range summaryOrder {
+		parts := summaries[key]
+		if parts.count != nil && parts.sum != nil {
+			if !add(parts.name, dto.MetricType_SUMMARY, &dto.Metric{
+				Label: parts.labels,
+				Summary: &dto.Summary{
+					SampleCount: proto.Uint64(uint64(*parts.count)),
+					SampleSum:   parts.sum,
+					Quantile:    parts.quantiles,
+				},
+			}) {
+				skipped += len(parts.quantiles)
+			}
+			continue
+		}
+		if parts.count != nil && !add(parts.name+"_count", dto.MetricType_COUNTER, &dto.Metric{
+			Label:   parts.labels,
+			Counter: &dto.Counter{Value: proto.Float64(*parts.count)},
+		}) {
+			skipped++
+		}
+		if parts.sum != nil && !add(parts.name+"_sum", dto.MetricType_GAUGE, &dto.Metric{
+			Label: parts.labels,
+			Gauge: &dto.Gauge{Value: proto.Float64(parts.intervalSum)},
+		}) {
+			skipped++
+		}
+		for _, q := range parts.quantiles {
+			labels := append(parts.labels[:len(parts.labels):len(parts.labels)], &dto.LabelPair{
+				Name:  proto.String("quantile"),
+				Value: proto.String(strconv.FormatFloat(q.GetQuantile(), 'f', -1, 64)),
+			})
+			if !add(parts.name, dto.MetricType_GAUGE, &dto.Metric{
+				Label: labels,
+				Gauge: &dto.Gauge{Value: proto.Float64(q.GetValue())},
+			}) {
+				skipped++
+			}
+		}
+	}
+
+	result := make([]*dto.MetricFamily, 0, len(families))
+	for _, mf := range families {
+		result = append(result, mf)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].GetName() < result[j].GetName()
+	})
+	return result, skipped
+}
+
+// labelPairs returns the sorted labels of a metric, without its name.
+func (ps *ScrapeSink) labelPairs(m samplers.InterMetric) []*dto.LabelPair {
+	labels := labelsFor(m, "", ps.tags, ps.excludedTags)
+	pairs := make([]*dto.LabelPair, 0, len(labels)-1)
+	for _, l := range labels {
+		if l.Name == metricNameLabel {
+			continue
+		}
+		pairs = append(pairs, &dto.LabelPair{
+			Name:  proto.String(l.Name),
+			Value: proto.String(l.Value),
+		})
+	}
+	return pairs
+}
+
+// familyKey identifies a series by its name and labels.
+func familyKey(name string, labels []*dto.LabelPair) string {
+	key := make([]string, 0, len(labels)+1)
+	key = append(key, name)
+	for _, l := range labels {
+		key = append(key, l.GetName()+"="+l.GetValue())
+	}
+	return strings.Join(key, ",")
+}
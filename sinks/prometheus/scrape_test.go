@@ -0,0 +1,120 @@
+package prometheus
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/veneur/samplers"
+)
+
+func scrape(t *testing.T, sink *ScrapeSink) map[string]*dto.MetricFamily {
+	rec := httptest.NewRecorder()
+	sink.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(rec.Body)
+	require.NoError(t, err, "exposition should be valid: %s", rec.Body.String())
+	return families
+}
+
+func TestScrapeSink(t *testing.T) {
+	sink := NewScrapeSink([]string{"region:us"}, 0, logrus.New())
+	require.NoError(t, sink.Start(nil))
+
+	now := time.Now().Unix()
+	tags := []string{"endpoint:/a"}
+	metrics := []samplers.InterMetric{
+		{Name: "api.hits", Timestamp: now, Value: 3, Tags: tags, Type: samplers.CounterMetric},
+		{Name: "api.queue", Timestamp: now, Value: 7, Tags: tags, Type: samplers.GaugeMetric},
+		{Name: "api.latency.50percentile", Timestamp: now, Value: 10, Tags: tags, Type: samplers.GaugeMetric},
+		{Name: "api.latency.99percentile", Timestamp: now, Value: 90, Tags: tags, Type: samplers.GaugeMetric},
+		{Name: "api.latency.99_9percentile", Timestamp: now, Value: 99, Tags: tags, Type: samplers.GaugeMetric},
+		{Name: "api.latency.count", Timestamp: now, Value: 20, Tags: tags, Type: samplers.CounterMetric},
+		{Name: "api.latency.sum", Timestamp: now, Value: 400, Tags: tags, Type: samplers.GaugeMetric},
+		{Name: "api.size.99percentile", Timestamp: now, Value: 5, Tags: tags, Type: samplers.GaugeMetric},
+		{Name: "api.check", Timestamp: now, Value: 0, Tags: tags, Type: samplers.StatusMetric},
+	}
+	require.NoError(t, sink.Flush(context.Background(), metrics))
+	families := scrape(t, sink)
+
+	require.Contains(t, families, "api_hits")
+	assert.Equal(t, dto.MetricType_COUNTER, families["api_hits"].GetType())
+	assert.Equal(t, float64(3), families["api_hits"].Metric[0].GetCounter().GetValue())
+
+	require.Contains(t, families, "api_queue")
+	assert.Equal(t, dto.MetricType_GAUGE, families["api_queue"].GetType())
+
+	require.Contains(t, families, "api_latency")
+	latency := families["api_latency"]
+	assert.Equal(t, dto.MetricType_SUMMARY, latency.GetType())
+	summary := latency.Metric[0].GetSummary()
+	assert.Equal(t, uint64(20), summary.GetSampleCount())
+	assert.Equal(t, float64(400), summary.GetSampleSum())
+	require.Len(t, summary.Quantile, 3)
+	assert.Equal(t, 0.99, summary.Quantile[1].GetQuantile())
+	assert.Equal(t, float64(90), summary.Quantile[1].GetValue())
+	assert.InDelta(t, 0.999, summary.Quantile[2].GetQuantile(), 1e-9)
+	assert.NotContains(t, families, "api_latency_count", "count should be part of the summary")
+
+	// without count and sum, percentiles are a gauge with a quantile label:
+	require.Contains(t, families, "api_size")
+	assert.Equal(t, dto.MetricType_GAUGE, families["api_size"].GetType())
+	assert.Contains(t, families["api_size"].Metric[0].Label, &dto.LabelPair{
+		Name: stringPtr("quantile"), Value: stringPtr("0.99"),
+	})
+
+	assert.NotContains(t, families, "api_check")
+	assert.Contains(t, families["api_queue"].Metric[0].Label, &dto.LabelPair{
+		Name: stringPtr("region"), Value: stringPtr("us"),
+	})
+
+	// the next flush replaces the snapshot, and counters keep counting:
+	require.NoError(t, sink.Flush(context.Background(), metrics[:1]))
+	families = scrape(t, sink)
+	assert.Len(t, families, 1)
+	assert.Equal(t, float64(6), families["api_hits"].Metric[0].GetCounter().GetValue())
+}
+
+func TestScrapeSinkDefaultAggregates(t *testing.T) {
+	sink := NewScrapeSink(nil, 0, logrus.New())
+	require.NoError(t, sink.Start(nil))
+
+	// the default aggregates are min, max and count, without a sum
+	now := time.Now().Unix()
+	metrics := []samplers.InterMetric{
+		{Name: "api.latency.min", Timestamp: now, Value: 1, Type: samplers.GaugeMetric},
+		{Name: "api.latency.max", Timestamp: now, Value: 95, Type: samplers.GaugeMetric},
+		{Name: "api.latency.count", Timestamp: now, Value: 20, Type: samplers.CounterMetric},
+		{Name: "api.latency.99percentile", Timestamp: now, Value: 90, Type: samplers.GaugeMetric},
+		{Name: "api.size.sum", Timestamp: now, Value: 400, Type: samplers.GaugeMetric},
+		{Name: "api.size.50percentile", Timestamp: now, Value: 5, Type: samplers.GaugeMetric},
+	}
+	require.NoError(t, sink.Flush(context.Background(), metrics))
+	require.NoError(t, sink.Flush(context.Background(), metrics))
+	families := scrape(t, sink)
+
+	require.Contains(t, families, "api_latency")
+	assert.Equal(t, dto.MetricType_GAUGE, families["api_latency"].GetType())
+	require.Contains(t, families, "api_latency_count", "the count shouldn't disappear without a sum")
+	assert.Equal(t, dto.MetricType_COUNTER, families["api_latency_count"].GetType())
+	assert.Equal(t, float64(40), families["api_latency_count"].Metric[0].GetCounter().GetValue())
+	assert.Contains(t, families, "api_latency_min")
+	assert.Contains(t, families, "api_latency_max")
+
+	require.Contains(t, families, "api_size_sum", "the sum shouldn't disappear without a count")
+	assert.Equal(t, dto.MetricType_GAUGE, families["api_size_sum"].GetType())
+	assert.Equal(t, float64(400), families["api_size_sum"].Metric[0].GetGauge().GetValue())
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -55,6 +55,23 @@ func IsAcceptableMetric(metric samplers.InterMetric, sink MetricSink) bool {
 	return metric.Sinks.RouteTo(sink.Name())
 }
 
+// SketchSink is a MetricSink that can compute quantiles itself. If
+// AcceptsSketches returns true, the sink receives a single
+// samplers.SketchMetric carrying the quantile sketch of each histogram,
+// timer and distribution, instead of their percentiles. Aggregates (min,
+// max, count etc.) are still flushed as usual.
+type SketchSink interface {
+	MetricSink
+	AcceptsSketches() bool
+}
+
+// AcceptsSketches returns true if the sink wants histograms flushed as
+// quantile sketches.
+func AcceptsSketches(sink MetricSink) bool {
+	ss, ok := sink.(SketchSink)
+	return ok && ss.AcceptsSketches()
+}
+
 // MetricKeySpanFlushDuration should be emitted as a timer by a SpanSink
 // if possible. Tagged with `sink:sink.Name()`. The `Flush` function is a great
 // place to do this. If your sync does async sends, this might not be necessary.
@@ -18,10 +18,13 @@ type metricExtractionSink struct {
 	workers                []Processor
 	indicatorSpanTimerName string
 	objectiveSpanTimerName string
-	log                    *logrus.Logger
-	traceClient            *trace.Client
-	spansProcessed         int64
-	metricsGenerated       int64
+	red                    bool
+	// graph is nil unless the service graph is enabled
+	graph            *serviceGraph
+	log              *logrus.Logger
+	traceClient      *trace.Client
+	spansProcessed   int64
+	metricsGenerated int64
 }
 
 var _ sinks.SpanSink = &metricExtractionSink{}
@@ -40,15 +43,21 @@ type DerivedMetricsSink interface {
 
 // NewMetricExtractionSink sets up and creates a span sink that
 // extracts metrics ("samples") from SSF spans and reports them to a
-// veneur's metrics workers.
-func NewMetricExtractionSink(mw []Processor, indicatorTimerName, objectiveTimerName string, cl *trace.Client, log *logrus.Logger) (DerivedMetricsSink, error) {
-	return &metricExtractionSink{
+// veneur's metrics workers. It also derives the metrics configured by
+// spanMetrics from every span.
+func NewMetricExtractionSink(mw []Processor, indicatorTimerName, objectiveTimerName string, spanMetrics SpanMetricsOptions, cl *trace.Client, log *logrus.Logger) (DerivedMetricsSink, error) {
+	sink := &metricExtractionSink{
 		workers:                mw,
 		indicatorSpanTimerName: indicatorTimerName,
 		objectiveSpanTimerName: objectiveTimerName,
+		red:                    spanMetrics.RED,
 		traceClient:            cl,
 		log:                    log,
-	}, nil
+	}
+	if spanMetrics.ServiceGraphWindow > 0 {
+		sink.graph = newServiceGraph(spanMetrics.ServiceGraphWindow, spanMetrics.ServiceGraphMaxSpans)
+	}
+	return sink, nil
 }
 
 // Name returns "metric_extraction".
@@ -134,6 +143,34 @@ func (m *metricExtractionSink) Ingest(span *ssf.SSFSpan) error {
 	metricsCount += len(spanMetrics)
 
 	m.sendMetrics(append(indicatorMetrics, spanMetrics...))
+
+	if m.red {
+		redMetrics, err := convertREDMetrics(span)
+		if err != nil {
+			m.log.WithError(err).
+				WithField("span_name", span.Name).
+				Warn("Couldn't extract RED metrics for span")
+			return err
+		}
+		metricsCount += len(redMetrics)
+		m.sendMetrics(redMetrics)
+	}
+
+	if m.graph != nil {
+		var samples []*ssf.SSFSample
+		for _, e := range m.graph.join(span) {
+			samples = append(samples, e.metrics()...)
+		}
+		graphMetrics, err := parseUnprefixed(samples)
+		if err != nil {
+			m.log.WithError(err).
+				WithField("span_name", span.Name).
+				Warn("Couldn't extract service graph metrics for span")
+			return err
+		}
+		metricsCount += len(graphMetrics)
+		m.sendMetrics(graphMetrics)
+	}
 	return nil
 }
 
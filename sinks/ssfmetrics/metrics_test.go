@@ -17,7 +17,7 @@ func TestMetricExtractor(t *testing.T) {
 	logger := logrus.StandardLogger()
 	worker := veneur.NewWorker(0, true, false, nil, logger, nil)
 	workers := []ssfmetrics.Processor{worker}
-	sink, err := ssfmetrics.NewMetricExtractionSink(workers, "foo", "", nil, logger)
+	sink, err := ssfmetrics.NewMetricExtractionSink(workers, "foo", "", ssfmetrics.SpanMetricsOptions{}, nil, logger)
 	require.NoError(t, err)
 
 	start := time.Now()
@@ -58,7 +58,7 @@ func setupBench() (*ssf.SSFSpan, sinks.SpanSink) {
 	logger := logrus.StandardLogger()
 	worker := veneur.NewWorker(0, true, false, nil, logger, nil)
 	workers := []ssfmetrics.Processor{worker}
-	sink, err := ssfmetrics.NewMetricExtractionSink(workers, "foo", "", nil, logger)
+	sink, err := ssfmetrics.NewMetricExtractionSink(workers, "foo", "", ssfmetrics.SpanMetricsOptions{}, nil, logger)
 	if err != nil {
 		panic(err)
 	}
@@ -111,7 +111,7 @@ func TestIndicatorMetricExtractor(t *testing.T) {
 	logger := logrus.StandardLogger()
 	worker := veneur.NewWorker(0, true, false, nil, logger, nil)
 	workers := []ssfmetrics.Processor{worker}
-	sink, err := ssfmetrics.NewMetricExtractionSink(workers, "foo", "bar", nil, logger)
+	sink, err := ssfmetrics.NewMetricExtractionSink(workers, "foo", "bar", ssfmetrics.SpanMetricsOptions{}, nil, logger)
 	require.NoError(t, err)
 
 	start := time.Now()
@@ -146,3 +146,54 @@ func TestIndicatorMetricExtractor(t *testing.T) {
 	close(worker.PacketChan)
 	assert.Equal(t, 2, <-done, "Should have sent the right number of metrics")
 }
+
+func TestSpanMetricsExtractor(t *testing.T) {
+	logger := logrus.StandardLogger()
+	worker := veneur.NewWorker(0, true, false, nil, logger, nil)
+	workers := []ssfmetrics.Processor{worker}
+	sink, err := ssfmetrics.NewMetricExtractionSink(workers, "", "", ssfmetrics.SpanMetricsOptions{
+		RED:                true,
+		ServiceGraphWindow: time.Minute,
+	}, nil, logger)
+	require.NoError(t, err)
+
+	start := time.Now()
+	end := start.Add(5 * time.Second)
+	child := &ssf.SSFSpan{
+		Id:             6,
+		TraceId:        5,
+		ParentId:       5,
+		Service:        "db",
+		Name:           "query",
+		StartTimestamp: start.UnixNano(),
+		EndTimestamp:   end.UnixNano(),
+		Error:          true,
+	}
+	parent := &ssf.SSFSpan{
+		Id:             5,
+		TraceId:        5,
+		Service:        "api",
+		Name:           "request",
+		StartTimestamp: start.UnixNano(),
+		EndTimestamp:   end.UnixNano(),
+	}
+	done := make(chan map[string]int)
+	go func() {
+		names := map[string]int{}
+		for m := range worker.PacketChan {
+			names[m.Name]++
+		}
+		done <- names
+	}()
+	assert.NoError(t, sink.Ingest(child))
+	assert.NoError(t, sink.Ingest(parent))
+	close(worker.PacketChan)
+
+	names := <-done
+	assert.Equal(t, 2, names["ssf.red.requests_total"])
+	assert.Equal(t, 1, names["ssf.red.errors_total"])
+	assert.Equal(t, 2, names["ssf.red.duration_ns"])
+	assert.Equal(t, 1, names["ssf.service_graph.requests_total"])
+	assert.Equal(t, 1, names["ssf.service_graph.errors_total"])
+	assert.Equal(t, 1, names["ssf.service_graph.duration_ns"])
+}
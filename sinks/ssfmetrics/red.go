@@ -0,0 +1,262 @@
+package ssfmetrics
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stripe/veneur/samplers"
+	"github.com/stripe/veneur/ssf"
+)
+
+// The names of the metrics derived from every span.
+const (
+	redRequestsMetric = "ssf.red.requests_total"
+	redErrorsMetric   = "ssf.red.errors_total"
+	redDurationMetric = "ssf.red.duration_ns"
+
+	graphRequestsMetric = "ssf.service_graph.requests_total"
+	graphErrorsMetric   = "ssf.service_graph.errors_total"
+	graphDurationMetric = "ssf.service_graph.duration_ns"
+)
+
+// The tags that name the service a client span calls, as OpenTracing, and
+// veneur's translations of OTLP, Jaeger and Zipkin spans, set them.
+const (
+	spanKindTag    = "span.kind"
+	peerServiceTag = "peer.service"
+)
+
+// DefaultServiceGraphMaxSpans is the number of spans that wait to be joined
+// with their parent or children at once if
+// SpanMetricsOptions.ServiceGraphMaxSpans is zero.
+const DefaultServiceGraphMaxSpans = 100000
+
+// SpanMetricsOptions configures the metrics that the metric extraction sink
+// derives from every span, in addition to the timers of indicator spans.
+type SpanMetricsOptions struct {
+	// RED counts the requests and errors of each operation (the service
+	// and name of a span), and times them.
+	RED bool
+	// ServiceGraphWindow, if it's not zero, is how long each span waits
+	// for its parent, and its children, to arrive. When a span and its
+	// parent are in different services, the sink counts and times the
+	// request from the parent's service (the client) to the span's (the
+	// server). Client spans that name the service they call, with the
+	// "peer.service" tag, are counted and timed as soon as they arrive,
+	// and don't wait, since the server's spans may go to another veneur.
+	ServiceGraphWindow time.Duration
+	// ServiceGraphMaxSpans is the number of spans that wait at once,
+	// counting the spans that wait for both their parent and their
+	// children twice. When there are more, the oldest stop waiting.
+	ServiceGraphMaxSpans int
+}
+
+// convertREDMetrics returns the request count, error count and duration of
+// the operation of a span.
+func convertREDMetrics(span *ssf.SSFSpan) ([]samplers.UDPMetric, error) {
+	tags := map[string]string{
+		"service":   span.Service,
+		"span_name": span.Name,
+	}
+	samples := []*ssf.SSFSample{ssf.Count(redRequestsMetric, 1, tags)}
+	if span.Error {
+		samples = append(samples, ssf.Count(redErrorsMetric, 1, tags))
+	}
+	samples = append(samples, ssf.Timing(redDurationMetric, spanDuration(span), time.Nanosecond,
+		map[string]string{
+			"service":   span.Service,
+			"span_name": span.Name,
+			"error":     strconv.FormatBool(span.Error),
+		}))
+	return parseUnprefixed(samples)
+}
+
+// edge is a request from a span in one service to its child in another.
+type edge struct {
+	client string
+	server graphSpan
+}
+
+// metrics returns the request count, error count and duration of an edge.
+func (e edge) metrics() []*ssf.SSFSample {
+	tags := map[string]string{
+		"client": e.client,
+		"server": e.server.service,
+	}
+	samples := []*ssf.SSFSample{ssf.Count(graphRequestsMetric, 1, tags)}
+	if e.server.err {
+		samples = append(samples, ssf.Count(graphErrorsMetric, 1, tags))
+	}
+	return append(samples, ssf.Timing(graphDurationMetric, e.server.duration, time.Nanosecond,
+		map[string]string{
+			"client": e.client,
+			"server": e.server.service,
+			"error":  strconv.FormatBool(e.server.err),
+		}))
+}
+
+type spanKey struct {
+	traceID int64
+	id      int64
+}
+
+type graphSpan struct {
+	service  string
+	duration time.Duration
+	err      bool
+	expires  time.Time
+}
+
+type queuedSpan struct {
+	key spanKey
+	// orphan is true if the span waits for its parent under key, and
+	// false if it waits for its children under its own key
+	orphan  bool
+	expires time.Time
+}
+
+// serviceGraph joins spans with their parents to find the requests between
+// services. Its methods are safe for concurrent use.
+type serviceGraph struct {
+	window   time.Duration
+	maxSpans int
+	now      func() time.Time
+
+	mtx sync.Mutex
+	// spans are the spans that wait for their children, by their key
+	spans map[spanKey]graphSpan
+	// orphans are the spans that wait for their parent, by its key
+	orphans map[spanKey][]graphSpan
+	// queue has an entry for each span in spans or orphans, in the
+	// order they arrived
+	queue []queuedSpan
+}
+
+func newServiceGraph(window time.Duration, maxSpans int) *serviceGraph {
+	if maxSpans <= 0 {
+		maxSpans = DefaultServiceGraphMaxSpans
+	}
+	return &serviceGraph{
+		window:   window,
+		maxSpans: maxSpans,
+		now:      time.Now,
+		spans:    make(map[spanKey]graphSpan),
+		orphans:  make(map[spanKey][]graphSpan),
+	}
+}
+
+// join returns the edges between span and its parent and children that
+// arrived already, and keeps span waiting for the others. A client span
+// that names its peer service is an edge by itself instead, so its
+// children are never joined with it.
+func (g *serviceGraph) join(span *ssf.SSFSpan) []edge {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	now := g.now()
+	for len(g.queue) > 0 && !g.queue[0].expires.After(now) {
+		g.pop(now)
+	}
+
+	self := spanKey{traceID: span.TraceId, id: span.Id}
+	gs := graphSpan{
+		service:  span.Service,
+		duration: spanDuration(span),
+		err:      span.Error,
+		expires:  now.Add(g.window),
+	}
+
+	var edges []edge
+	peer := peerService(span)
+	if peer != "" && peer != span.Service {
+		edges = append(edges, edge{client: span.Service, server: graphSpan{
+			service:  peer,
+			duration: gs.duration,
+			err:      gs.err,
+		}})
+	}
+	for _, child := range g.orphans[self] {
+		if peer == "" && child.service != span.Service {
+			edges = append(edges, edge{client: span.Service, server: child})
+		}
+	}
+	delete(g.orphans, self)
+
+	if span.ParentId != 0 {
+		parentKey := spanKey{traceID: span.TraceId, id: span.ParentId}
+		if parent, ok := g.spans[parentKey]; ok {
+			if parent.service != span.Service {
+				edges = append(edges, edge{client: parent.service, server: gs})
+			}
+		} else {
+			g.orphans[parentKey] = append(g.orphans[parentKey], gs)
+			g.queue = append(g.queue, queuedSpan{key: parentKey, orphan: true, expires: gs.expires})
+		}
+	}
+
+	if peer == "" {
+		g.spans[self] = gs
+		g.queue = append(g.queue, queuedSpan{key: self, expires: gs.expires})
+	}
+	for len(g.queue) > g.maxSpans {
+		g.pop(now)
+	}
+	return edges
+}
+
+// pop stops the span at the front of the queue from waiting, along with
+// any other spans that wait for the same parent and expire by now.
+func (g *serviceGraph) pop(now time.Time) {
+	qs := g.queue[0]
+	g.queue = g.queue[1:]
+
+	if !qs.orphan {
+		if gs, ok := g.spans[qs.key]; ok && gs.expires.Equal(qs.expires) {
+			delete(g.spans, qs.key)
+		}
+		return
+	}
+
+	var waiting []graphSpan
+	for _, gs := range g.orphans[qs.key] {
+		if gs.expires.After(now) && !gs.expires.Equal(qs.expires) {
+			waiting = append(waiting, gs)
+		}
+	}
+	if len(waiting) == 0 {
+		delete(g.orphans, qs.key)
+	} else {
+		g.orphans[qs.key] = waiting
+	}
+}
+
+// peerService returns the service that span calls, if it's a client or
+// producer span that names it.
+func peerService(span *ssf.SSFSpan) string {
+	switch span.Tags[spanKindTag] {
+	case "client", "producer":
+		return span.Tags[peerServiceTag]
+	}
+	return ""
+}
+
+func spanDuration(span *ssf.SSFSpan) time.Duration {
+	return time.Duration(span.EndTimestamp - span.StartTimestamp)
+}
+
+// parseUnprefixed parses samples into metrics, without the name prefix
+// that the ssf package adds to their names.
+func parseUnprefixed(samples []*ssf.SSFSample) ([]samplers.UDPMetric, error) {
+	metrics := make([]samplers.UDPMetric, 0, len(samples))
+	for _, sample := range samples {
+		sample.Name = strings.TrimPrefix(sample.Name, ssf.NamePrefix)
+		metric, err := samplers.ParseMetricSSF(sample)
+		if err != nil {
+			return metrics, err
+		}
+		metrics = append(metrics, metric)
+	}
+	return metrics, nil
+}
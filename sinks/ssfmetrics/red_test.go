@@ -0,0 +1,110 @@
+package ssfmetrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/veneur/ssf"
+)
+
+func testGraphSpan(service string, id, parent int64, err bool) *ssf.SSFSpan {
+	return &ssf.SSFSpan{
+		TraceId:        1,
+		Id:             id,
+		ParentId:       parent,
+		Service:        service,
+		Name:           "request",
+		StartTimestamp: 1,
+		EndTimestamp:   1 + int64(id)*int64(time.Millisecond),
+		Error:          err,
+	}
+}
+
+func TestConvertREDMetrics(t *testing.T) {
+	metrics, err := convertREDMetrics(testGraphSpan("api", 2, 1, true))
+	require.NoError(t, err)
+	require.Len(t, metrics, 3)
+
+	assert.Equal(t, "ssf.red.requests_total", metrics[0].Name)
+	assert.ElementsMatch(t, []string{"service:api", "span_name:request"}, metrics[0].Tags)
+	assert.Equal(t, "ssf.red.errors_total", metrics[1].Name)
+	assert.Equal(t, "ssf.red.duration_ns", metrics[2].Name)
+	assert.Equal(t, float64(2*time.Millisecond), metrics[2].Value)
+	assert.Contains(t, metrics[2].Tags, "error:true")
+
+	metrics, err = convertREDMetrics(testGraphSpan("api", 2, 1, false))
+	require.NoError(t, err)
+	assert.Len(t, metrics, 2, "only errors are counted as errors")
+}
+
+func TestServiceGraph(t *testing.T) {
+	g := newServiceGraph(time.Minute, 0)
+	now := time.Unix(1000, 0)
+	g.now = func() time.Time { return now }
+
+	// children usually arrive before their parent, since they finish first
+	assert.Empty(t, g.join(testGraphSpan("db", 3, 2, true)))
+	assert.Empty(t, g.join(testGraphSpan("api", 4, 2, false)), "the parent hasn't arrived")
+	edges := g.join(testGraphSpan("api", 2, 1, false))
+	require.Len(t, edges, 1, "a call within a service isn't an edge")
+	assert.Equal(t, "api", edges[0].client)
+	assert.Equal(t, "db", edges[0].server.service)
+	assert.True(t, edges[0].server.err)
+	assert.Equal(t, 3*time.Millisecond, edges[0].server.duration)
+
+	// and children that arrive after their parent are joined too
+	edges = g.join(testGraphSpan("cache", 5, 2, false))
+	require.Len(t, edges, 1)
+	assert.Equal(t, "api", edges[0].client)
+	assert.Equal(t, "cache", edges[0].server.service)
+
+	// but not after the window
+	now = now.Add(time.Minute)
+	assert.Empty(t, g.join(testGraphSpan("cache", 6, 2, false)))
+	assert.Empty(t, g.join(testGraphSpan("frontend", 1, 0, false)),
+		"span 2 stopped waiting for its parent")
+	assert.Len(t, g.spans, 2)
+	assert.Len(t, g.orphans, 1)
+}
+
+func TestServiceGraphPeerService(t *testing.T) {
+	g := newServiceGraph(time.Minute, 0)
+
+	call := testGraphSpan("api", 2, 1, true)
+	call.Tags = map[string]string{"span.kind": "client", "peer.service": "db"}
+	edges := g.join(call)
+	require.Len(t, edges, 1, "the client span should be an edge without the server's span")
+	assert.Equal(t, "api", edges[0].client)
+	assert.Equal(t, "db", edges[0].server.service)
+	assert.True(t, edges[0].server.err)
+	assert.Equal(t, 2*time.Millisecond, edges[0].server.duration)
+
+	// the server's span, if the same veneur gets it, isn't another edge
+	assert.Empty(t, g.join(testGraphSpan("db", 3, 2, false)))
+	assert.Empty(t, g.join(testGraphSpan("db", 5, 4, false)))
+	call = testGraphSpan("api", 4, 1, false)
+	call.Tags = map[string]string{"span.kind": "client", "peer.service": "db"}
+	assert.Len(t, g.join(call), 1, "the child that arrived first isn't another edge")
+	assert.Empty(t, g.orphans[spanKey{traceID: 1, id: 4}])
+
+	// server spans don't call their peer
+	served := testGraphSpan("db", 6, 1, false)
+	served.Tags = map[string]string{"span.kind": "server", "peer.service": "api"}
+	assert.Empty(t, g.join(served))
+}
+
+func TestServiceGraphMaxSpans(t *testing.T) {
+	g := newServiceGraph(time.Minute, 4)
+	now := time.Unix(1000, 0)
+	g.now = func() time.Time { return now }
+
+	for id := int64(2); id < 10; id++ {
+		g.join(testGraphSpan("db", id, 1, false))
+		now = now.Add(time.Second)
+	}
+	assert.Len(t, g.queue, 4, "only 4 spans should wait")
+	assert.Len(t, g.join(testGraphSpan("api", 1, 0, false)), 2,
+		"only the last children should still be waiting for their parent")
+}
@@ -0,0 +1,183 @@
+// Package zipkin provides a span sink that posts spans to any Zipkin
+// compatible tracing backend, over Zipkin's v2 HTTP API.
+package zipkin
+
+import (
+	"container/ring"
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	vhttp "github.com/stripe/veneur/http"
+	"github.com/stripe/veneur/protocol"
+	"github.com/stripe/veneur/sinks"
+	"github.com/stripe/veneur/ssf"
+	"github.com/stripe/veneur/trace"
+	"github.com/stripe/veneur/trace/metrics"
+	zipkinapi "github.com/stripe/veneur/zipkin"
+)
+
+const (
+	// DefaultSpanBufferSize is the number of spans retained between
+	// flushes if no buffer size is configured.
+	DefaultSpanBufferSize = 1 << 14
+
+	// DefaultBatchSize is the maximum number of spans sent in a single
+	// request if no batch size is configured.
+	DefaultBatchSize = 512
+
+	// postTimeout bounds each request, so that a slow backend can't hold
+	// up the next flush.
+	postTimeout = 10 * time.Second
+)
+
+// SpanSink buffers spans and posts them to a Zipkin /api/v2/spans endpoint
+// on every flush.
+type SpanSink struct {
+	endpoint   string
+	httpClient *http.Client
+	buffer     *ring.Ring
+	bufferSize int
+	batchSize  int
+	// commonTags are added to every span's tags, unless the span has a
+	// tag of the same name.
+	commonTags  map[string]string
+	mutex       *sync.Mutex
+	traceClient *trace.Client
+	log         *logrus.Logger
+}
+
+var _ sinks.SpanSink = &SpanSink{}
+
+// NewSpanSink creates a span sink that posts spans as JSON to endpoint,
+// the URL of a Zipkin v2 span API like http://zipkin:9411/api/v2/spans.
+// At most bufferSize spans are retained between flushes, and they are
+// posted in requests of at most batchSize spans.
+func NewSpanSink(endpoint string, bufferSize, batchSize int, commonTags map[string]string, httpClient *http.Client, log *logrus.Logger) (*SpanSink, error) {
+	if bufferSize == 0 {
+		bufferSize = DefaultSpanBufferSize
+	}
+	if batchSize == 0 {
+		batchSize = DefaultBatchSize
+	}
+	if _, err := url.ParseRequestURI(endpoint); err != nil {
+		return nil, err
+	}
+
+	return &SpanSink{
+		endpoint:   endpoint,
+		httpClient: httpClient,
+		buffer:     ring.New(bufferSize),
+		bufferSize: bufferSize,
+		batchSize:  batchSize,
+		commonTags: commonTags,
+		mutex:      &sync.Mutex{},
+		log:        log,
+	}, nil
+}
+
+// Name returns the name of this sink.
+func (zs *SpanSink) Name() string {
+	return "zipkin"
+}
+
+// Start performs final adjustments on the sink.
+func (zs *SpanSink) Start(cl *trace.Client) error {
+	zs.traceClient = cl
+	return nil
+}
+
+// Ingest takes the span and adds it to the ringbuffer. If the buffer is
+// full, the oldest span is overwritten.
+func (zs *SpanSink) Ingest(span *ssf.SSFSpan) error {
+	if err := protocol.ValidateTrace(span); err != nil {
+		return err
+	}
+	zs.mutex.Lock()
+	defer zs.mutex.Unlock()
+
+	zs.buffer.Value = span
+	zs.buffer = zs.buffer.Next()
+	return nil
+}
+
+// Flush posts the spans buffered since the last flush, in batches of at
+// most batchSize spans.
+func (zs *SpanSink) Flush() {
+	samples := &ssf.Samples{}
+	defer metrics.Report(zs.traceClient, samples)
+
+	flushStart := time.Now()
+	zs.mutex.Lock()
+	spans := make([]*ssf.SSFSpan, 0, zs.buffer.Len())
+	zs.buffer.Do(func(t interface{}) {
+		if span, ok := t.(*ssf.SSFSpan); ok {
+			spans = append(spans, span)
+		}
+	})
+	// Reset the ring.
+	zs.buffer = ring.New(zs.bufferSize)
+	zs.mutex.Unlock()
+
+	if len(spans) == 0 {
+		zs.log.Debug("No spans to flush to Zipkin, skipping.")
+		return
+	}
+
+	tags := map[string]string{"sink": zs.Name()}
+	serviceCount := map[string]int64{}
+	var dropped int
+	for start := 0; start < len(spans); start += zs.batchSize {
+		end := start + zs.batchSize
+		if end > len(spans) {
+			end = len(spans)
+		}
+		batch := spans[start:end]
+
+		body := make([]zipkinapi.Span, 0, len(batch))
+		for _, span := range batch {
+			body = append(body, zs.zipkinSpan(span))
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), postTimeout)
+		err := vhttp.PostHelper(ctx, zs.httpClient, zs.traceClient, http.MethodPost,
+			zs.endpoint, body, "flush_spans", false, tags, zs.log)
+		cancel()
+		if err != nil {
+			dropped += len(batch)
+			continue
+		}
+		for _, span := range batch {
+			serviceCount[span.Service]++
+		}
+	}
+
+	for service, count := range serviceCount {
+		samples.Add(ssf.Count(sinks.MetricKeyTotalSpansFlushed, float32(count),
+			map[string]string{"sink": zs.Name(), "service": service}))
+	}
+	samples.Add(
+		ssf.Count(sinks.MetricKeyTotalSpansDropped, float32(dropped), tags),
+		ssf.Timing(sinks.MetricKeySpanFlushDuration, time.Since(flushStart), time.Nanosecond, tags),
+	)
+	zs.log.WithFields(logrus.Fields{
+		"spans":   len(spans) - dropped,
+		"dropped": dropped,
+	}).Info("Completed flushing spans to Zipkin")
+}
+
+// zipkinSpan converts an SSF span to Zipkin's model, with the sink's
+// common tags.
+func (zs *SpanSink) zipkinSpan(span *ssf.SSFSpan) zipkinapi.Span {
+	ret := zipkinapi.FromSSF(span)
+	for k, v := range zs.commonTags {
+		if _, ok := span.Tags[k]; !ok {
+			ret.Tags[k] = v
+		}
+	}
+	return ret
+}
@@ -0,0 +1,130 @@
+package zipkin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/veneur/ssf"
+	zipkinapi "github.com/stripe/veneur/zipkin"
+)
+
+type testZipkinServer struct {
+	mtx      sync.Mutex
+	requests [][]zipkinapi.Span
+	fail     bool
+}
+
+func (ts *testZipkinServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ts.mtx.Lock()
+	defer ts.mtx.Unlock()
+	if ts.fail {
+		http.Error(w, "try again later", http.StatusServiceUnavailable)
+		return
+	}
+	var spans []zipkinapi.Span
+	if err := json.NewDecoder(r.Body).Decode(&spans); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	ts.requests = append(ts.requests, spans)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func newTestSink(t *testing.T, batchSize int) (*SpanSink, *testZipkinServer, *httptest.Server) {
+	ts := &testZipkinServer{}
+	srv := httptest.NewServer(ts)
+
+	sink, err := NewSpanSink(srv.URL+zipkinapi.SpansPath, 0, batchSize,
+		map[string]string{"region": "us", "env": "prod"}, srv.Client(), logrus.New())
+	require.NoError(t, err)
+	require.NoError(t, sink.Start(nil))
+	return sink, ts, srv
+}
+
+func testSpan(id int64, service string) *ssf.SSFSpan {
+	return &ssf.SSFSpan{
+		TraceId:        1,
+		Id:             id,
+		ParentId:       1,
+		StartTimestamp: 1000,
+		EndTimestamp:   3500,
+		Service:        service,
+		Name:           "charge",
+		Tags:           map[string]string{"env": "canary"},
+	}
+}
+
+func TestZipkinSpanSinkFlush(t *testing.T) {
+	sink, ts, srv := newTestSink(t, 2)
+	defer srv.Close()
+
+	errSpan := testSpan(0x7fffffffffffffff, "checkout")
+	errSpan.SetFullTraceID(0x463ac35c9f6413ad, 0xc8485a3953bb6124)
+	errSpan.Error = true
+	errSpan.Tags = map[string]string{"error.msg": "declined", "span.kind": "server", "peer.service": "bank"}
+	for _, span := range []*ssf.SSFSpan{testSpan(2, "checkout"), testSpan(3, "billing"), errSpan} {
+		require.NoError(t, sink.Ingest(span))
+	}
+	assert.Error(t, sink.Ingest(&ssf.SSFSpan{}), "invalid spans should be rejected")
+	sink.Flush()
+
+	require.Len(t, ts.requests, 2, "spans should be posted in batches of 2")
+	require.Len(t, ts.requests[0], 2)
+	first := ts.requests[0][0]
+	assert.Equal(t, zipkinapi.Span{
+		TraceID:       "0000000000000001",
+		ParentID:      "0000000000000001",
+		ID:            "0000000000000002",
+		Name:          "charge",
+		Timestamp:     1,
+		Duration:      2,
+		LocalEndpoint: &zipkinapi.Endpoint{ServiceName: "checkout"},
+		Tags:          map[string]string{"env": "canary", "region": "us"},
+	}, first, "the span's tags should take precedence over the common tags")
+
+	require.Len(t, ts.requests[1], 1)
+	last := ts.requests[1][0]
+	assert.Equal(t, "7fffffffffffffff", last.ID)
+	assert.Equal(t, "463ac35c9f6413adc8485a3953bb6124", last.TraceID)
+	assert.Equal(t, "SERVER", last.Kind)
+	assert.Equal(t, &zipkinapi.Endpoint{ServiceName: "bank"}, last.RemoteEndpoint)
+	assert.Equal(t, map[string]string{"error": "declined", "env": "prod", "region": "us"}, last.Tags)
+
+	// everything round-trips through veneur's own Zipkin listener
+	converted, err := zipkinapi.ToSSF(last)
+	require.NoError(t, err)
+	assert.Equal(t, errSpan.Id, converted.Id)
+	assert.Equal(t, errSpan.TraceId, converted.TraceId)
+	assert.Equal(t, errSpan.TraceIdHigh, converted.TraceIdHigh)
+	assert.Equal(t, errSpan.TraceIdLow, converted.TraceIdLow)
+	assert.True(t, converted.Error)
+	assert.Equal(t, "declined", converted.Tags["error.msg"])
+	assert.Equal(t, "server", converted.Tags["span.kind"])
+}
+
+func TestZipkinSpanSinkDropsFailedBatches(t *testing.T) {
+	sink, ts, srv := newTestSink(t, 1)
+	defer srv.Close()
+
+	ts.fail = true
+	require.NoError(t, sink.Ingest(testSpan(2, "checkout")))
+	sink.Flush()
+	assert.Empty(t, ts.requests)
+
+	// the spans aren't retried
+	ts.fail = false
+	sink.Flush()
+	assert.Empty(t, ts.requests)
+}
+
+func TestNewSpanSinkInvalidEndpoint(t *testing.T) {
+	_, err := NewSpanSink("not a url", 0, 0, nil, http.DefaultClient, logrus.New())
+	assert.Error(t, err)
+}
@@ -0,0 +1,70 @@
+package veneur
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/stripe/veneur/jaeger/jaegerpb"
+)
+
+// TestZipkinAndJaegerIngestion sends spans to a Server's Zipkin and
+// Jaeger listeners, and checks that they come out of its span sinks.
+func TestZipkinAndJaegerIngestion(t *testing.T) {
+	spanSink := &fakeSpanSink{wg: &sync.WaitGroup{}}
+
+	config := localConfig()
+	config.ZipkinListenAddress = "127.0.0.1:0"
+	config.JaegerListenAddress = "127.0.0.1:0"
+	config.GrpcAddress = unusedLocalTCPAddress(t)
+	s := setupVeneurServer(t, config, nil, nil, spanSink, nil)
+	defer s.Shutdown()
+	go s.Serve()
+
+	spanSink.wg.Add(1)
+	res, err := http.Post("http://"+s.ZipkinListenAddr+"/api/v2/spans", "application/json",
+		bytes.NewBufferString(`[{
+			"traceId": "5af7183fb1d4cf5f", "id": "352bff9a74ca9ad2", "parentId": "6b221d5bc9e6496c",
+			"name": "get /api", "timestamp": 1556604172355737, "duration": 1431,
+			"localEndpoint": {"serviceName": "backend"}
+		}]`))
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusAccepted, res.StatusCode)
+	spanSink.wg.Wait()
+	assert.Equal(t, "backend", spanSink.latestSpan().Service)
+	assert.Equal(t, int64(0x5af7183fb1d4cf5f), spanSink.latestSpan().TraceId)
+	assert.Equal(t, int64(0x6b221d5bc9e6496c), spanSink.latestSpan().ParentId)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, config.GrpcAddress, grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	spanSink.wg.Add(1)
+	_, err = jaegerpb.NewCollectorServiceClient(conn).PostSpans(ctx, &jaegerpb.PostSpansRequest{
+		Batch: &jaegerpb.Batch{
+			Process: &jaegerpb.Process{ServiceName: "checkout"},
+			Spans: []*jaegerpb.Span{{
+				TraceId:       []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 7},
+				SpanId:        []byte{0, 0, 0, 0, 0, 0, 0, 8},
+				OperationName: "charge",
+				StartTime:     &jaegerpb.Timestamp{Seconds: time.Now().Unix()},
+				Duration:      &jaegerpb.Duration{Nanos: 1000},
+			}},
+		},
+	})
+	require.NoError(t, err)
+	spanSink.wg.Wait()
+	assert.Equal(t, "checkout", spanSink.latestSpan().Service)
+	assert.Equal(t, "charge", spanSink.latestSpan().Name)
+	assert.Equal(t, int64(7), spanSink.latestSpan().TraceId)
+}
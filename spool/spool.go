@@ -0,0 +1,300 @@
+// Package spool keeps batches of forwarded metrics that could not be
+// delivered in a directory on disk, so that they can be replayed once their
+// destination is reachable again.
+//
+// Each batch is a forwardrpc.MetricList, stored in a file of its own whose
+// name starts with the Unix timestamp of the interval it was aggregated in.
+// Batches are replayed oldest first, and the spool drops the oldest ones
+// when it grows past its size limit, as well as any that are older than its
+// age limit. Batches that their destination rejects outright are moved to a
+// "rejected" subdirectory, where they are kept for inspection but never
+// replayed again.
+package spool
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stripe/veneur/forwardrpc"
+	vhttp "github.com/stripe/veneur/http"
+	"github.com/stripe/veneur/scopedstatsd"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// suffix is the file extension of batches in the spool. Files without
+	// it, like the temporary files that batches are written to, are
+	// ignored.
+	suffix = ".metrics"
+	// tempPrefix starts the names of the files that batches are written to
+	// before they are complete.
+	tempPrefix = ".spooling-"
+	// rejectedDir is the subdirectory that batches which can't ever be
+	// delivered are moved to.
+	rejectedDir = "rejected"
+)
+
+// SendFunc delivers a batch of metrics read back from the spool.
+type SendFunc func(ctx context.Context, list *forwardrpc.MetricList) error
+
+// Spool is a directory of undelivered batches of metrics. It is safe for
+// concurrent use.
+type Spool struct {
+	dir      string
+	maxBytes int64
+	maxAge   time.Duration
+	stats    scopedstatsd.Client
+
+	mtx sync.Mutex
+	seq uint64
+}
+
+// New creates a Spool in dir, creating the directory if it doesn't exist.
+// Batches that are already in the directory, from a previous run, are kept
+// and will be replayed. If maxBytes or maxAge are zero, the spool's size or
+// the age of its batches, respectively, are unlimited.
+func New(dir string, maxBytes int64, maxAge time.Duration, stats scopedstatsd.Client) (*Spool, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("could not create the spool directory %q: %v", dir, err)
+	}
+	return &Spool{
+		dir:      dir,
+		maxBytes: maxBytes,
+		maxAge:   maxAge,
+		stats:    scopedstatsd.Ensure(stats),
+		seq:      uint64(time.Now().UnixNano()),
+	}, nil
+}
+
+// entry is a batch in the spool.
+type entry struct {
+	name      string
+	timestamp int64
+	size      int64
+}
+
+// Write persists a batch of metrics, whose Timestamp must be set, so that it
+// is replayed later. It drops the oldest batches in the spool if the new one
+// makes it exceed its size limit.
+func (s *Spool) Write(list *forwardrpc.MetricList) error {
+	if list.Timestamp == 0 {
+		return fmt.Errorf("can't spool a batch of %d metrics without a timestamp", len(list.Metrics))
+	}
+	data, err := list.Marshal()
+	if err != nil {
+		return err
+	}
+	if s.maxBytes > 0 && int64(len(data)) > s.maxBytes {
+		s.stats.Count("forward.spool.dropped_total", 1, []string{"cause:bytes"}, 1.0)
+		return fmt.Errorf("a batch of %d bytes is larger than the spool", len(data))
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	// write to a temporary file first, so that a crash can't leave a
+	// truncated batch behind
+	f, err := ioutil.TempFile(s.dir, tempPrefix)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	s.seq++
+	name := fmt.Sprintf("%020d-%020d%s", list.Timestamp, s.seq, suffix)
+	if err := os.Rename(f.Name(), filepath.Join(s.dir, name)); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	s.stats.Count("forward.spool.written_total", 1, nil, 1.0)
+
+	_, err = s.trim(time.Now())
+	return err
+}
+
+// Replay sends every batch in the spool, oldest first, and removes the ones
+// that were delivered. It stops at the first batch that can't be sent,
+// returning the error, which leaves that batch and the ones after it to be
+// replayed again later; batches that are rejected with an error that
+// retrying can't fix are moved out of the way instead, so that they don't
+// hold up the rest.
+func (s *Spool) Replay(ctx context.Context, send SendFunc) (int, error) {
+	s.mtx.Lock()
+	entries, err := s.trim(time.Now())
+	s.mtx.Unlock()
+	if err != nil {
+		return 0, err
+	}
+
+	replayed := 0
+	defer func() {
+		s.stats.Count("forward.spool.replayed_total", int64(replayed), nil, 1.0)
+	}()
+	for _, e := range entries {
+		if err := ctx.Err(); err != nil {
+			return replayed, err
+		}
+		path := filepath.Join(s.dir, e.name)
+		data, err := ioutil.ReadFile(path)
+		if os.IsNotExist(err) {
+			// it was dropped by a Write since we listed the spool
+			continue
+		} else if err != nil {
+			return replayed, err
+		}
+		list := &forwardrpc.MetricList{}
+		if err := list.Unmarshal(data); err != nil {
+			s.stats.Count("forward.spool.dropped_total", 1, []string{"cause:corrupt"}, 1.0)
+			os.Remove(path)
+			continue
+		}
+		if err := send(ctx, list); err != nil {
+			if !rejected(err) {
+				return replayed, err
+			}
+			if err := s.reject(e); err != nil {
+				return replayed, err
+			}
+			continue
+		}
+		replayed++
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return replayed, err
+		}
+	}
+	return replayed, nil
+}
+
+// rejected returns whether a batch that failed to send with err would fail
+// the same way every time: the gRPC status or the HTTP status it was
+// rejected with says it's invalid or not allowed.
+func rejected(err error) bool {
+	if serr, ok := err.(*vhttp.StatusError); ok {
+		return serr.Permanent()
+	}
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.Unauthenticated, codes.PermissionDenied:
+		return true
+	}
+	return false
+}
+
+// reject moves a batch to the rejected subdirectory.
+func (s *Spool) reject(e entry) error {
+	dir := filepath.Join(s.dir, rejectedDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	err := os.Rename(filepath.Join(s.dir, e.name), filepath.Join(dir, e.name))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	s.stats.Count("forward.spool.dropped_total", 1, []string{"cause:rejected"}, 1.0)
+	return nil
+}
+
+// Run replays the spool until ctx is cancelled. It waits minBackoff
+// between replays, doubling the wait after every failed one up to
+// maxBackoff.
+func (s *Spool) Run(ctx context.Context, send SendFunc, minBackoff, maxBackoff time.Duration) {
+	backoff := minBackoff
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if _, err := s.Replay(ctx, send); err != nil {
+			backoff *= 2
+			if backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+			continue
+		}
+		backoff = minBackoff
+	}
+}
+
+// trim drops the batches that are older than the age limit, and then the
+// oldest ones until the spool fits in its size limit. It returns the batches
+// that are left, oldest first. It must be called with the mutex held.
+func (s *Spool) trim(now time.Time) ([]entry, error) {
+	entries, err := s.list()
+	if err != nil {
+		return nil, err
+	}
+
+	var total int64
+	for _, e := range entries {
+		total += e.size
+	}
+	for len(entries) > 0 {
+		e := entries[0]
+		cause := ""
+		switch {
+		case s.maxAge > 0 && now.Sub(time.Unix(e.timestamp, 0)) > s.maxAge:
+			cause = "cause:age"
+		case s.maxBytes > 0 && total > s.maxBytes:
+			cause = "cause:bytes"
+		}
+		if cause == "" {
+			break
+		}
+		if err := os.Remove(filepath.Join(s.dir, e.name)); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		s.stats.Count("forward.spool.dropped_total", 1, []string{cause}, 1.0)
+		total -= e.size
+		entries = entries[1:]
+	}
+
+	s.stats.Gauge("forward.spool.batches", float64(len(entries)), nil, 1.0)
+	s.stats.Gauge("forward.spool.bytes", float64(total), nil, 1.0)
+	return entries, nil
+}
+
+// list returns the batches in the spool, oldest first.
+func (s *Spool) list() ([]entry, error) {
+	infos, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]entry, 0, len(infos))
+	for _, info := range infos {
+		name := info.Name()
+		if info.IsDir() || !strings.HasSuffix(name, suffix) {
+			continue
+		}
+		i := strings.IndexByte(name, '-')
+		if i < 0 {
+			continue
+		}
+		timestamp, err := strconv.ParseInt(name[:i], 10, 64)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, entry{name: name, timestamp: timestamp, size: info.Size()})
+	}
+	// the names are zero-padded, so they sort by timestamp and then by the
+	// order they were written in
+	sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+	return entries, nil
+}
@@ -0,0 +1,220 @@
+package spool
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/veneur/forwardrpc"
+	vhttp "github.com/stripe/veneur/http"
+	"github.com/stripe/veneur/samplers/metricpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func testSpool(t *testing.T, maxBytes int64, maxAge time.Duration) (*Spool, func()) {
+	dir, err := ioutil.TempDir("", "test_spool")
+	require.NoError(t, err)
+	s, err := New(filepath.Join(dir, "spool"), maxBytes, maxAge, nil)
+	require.NoError(t, err)
+	return s, func() { os.RemoveAll(dir) }
+}
+
+func testList(timestamp int64, names ...string) *forwardrpc.MetricList {
+	list := &forwardrpc.MetricList{Timestamp: timestamp}
+	for _, name := range names {
+		list.Metrics = append(list.Metrics, &metricpb.Metric{
+			Name:  name,
+			Type:  metricpb.Type_Counter,
+			Value: &metricpb.Metric_Counter{Counter: &metricpb.CounterValue{Value: 1}},
+		})
+	}
+	return list
+}
+
+func TestSpoolReplaysInOrder(t *testing.T) {
+	s, cleanup := testSpool(t, 0, 0)
+	defer cleanup()
+
+	now := time.Now().Unix()
+	require.NoError(t, s.Write(testList(now, "b")))
+	require.NoError(t, s.Write(testList(now-10, "a")))
+	require.NoError(t, s.Write(testList(now, "c")))
+
+	var got []*forwardrpc.MetricList
+	n, err := s.Replay(context.Background(), func(ctx context.Context, list *forwardrpc.MetricList) error {
+		got = append(got, list)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, []*forwardrpc.MetricList{testList(now-10, "a"), testList(now, "b"), testList(now, "c")}, got,
+		"batches should be replayed by timestamp, then in the order they were written")
+
+	n, err = s.Replay(context.Background(), func(ctx context.Context, list *forwardrpc.MetricList) error {
+		t.Errorf("replayed batch %v twice", list)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestSpoolKeepsFailedBatches(t *testing.T) {
+	s, cleanup := testSpool(t, 0, 0)
+	defer cleanup()
+
+	now := time.Now().Unix()
+	require.NoError(t, s.Write(testList(now-1, "a")))
+	require.NoError(t, s.Write(testList(now, "b")))
+
+	sendErr := errors.New("unavailable")
+	n, err := s.Replay(context.Background(), func(ctx context.Context, list *forwardrpc.MetricList) error {
+		if list.Metrics[0].Name == "b" {
+			return sendErr
+		}
+		return nil
+	})
+	assert.Equal(t, sendErr, err)
+	assert.Equal(t, 1, n)
+
+	// a new spool in the same directory picks up where this one left off:
+	s, err = New(s.dir, 0, 0, nil)
+	require.NoError(t, err)
+	var got []string
+	_, err = s.Replay(context.Background(), func(ctx context.Context, list *forwardrpc.MetricList) error {
+		got = append(got, list.Metrics[0].Name)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, got)
+}
+
+func TestSpoolSkipsRejectedBatches(t *testing.T) {
+	s, cleanup := testSpool(t, 0, 0)
+	defer cleanup()
+
+	now := time.Now().Unix()
+	require.NoError(t, s.Write(testList(now-1, "a")))
+	require.NoError(t, s.Write(testList(now, "b")))
+
+	var got []string
+	n, err := s.Replay(context.Background(), func(ctx context.Context, list *forwardrpc.MetricList) error {
+		if list.Metrics[0].Name == "a" {
+			return status.Error(codes.InvalidArgument, "bad metric")
+		}
+		got = append(got, list.Metrics[0].Name)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []string{"b"}, got, "a rejected batch shouldn't hold up the ones after it")
+
+	rejected, err := ioutil.ReadDir(filepath.Join(s.dir, rejectedDir))
+	require.NoError(t, err)
+	assert.Len(t, rejected, 1, "the rejected batch should be kept aside")
+
+	_, err = s.Replay(context.Background(), func(ctx context.Context, list *forwardrpc.MetricList) error {
+		t.Errorf("replayed batch %v again", list)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestSpoolSkipsRejectedHTTPBatches(t *testing.T) {
+	s, cleanup := testSpool(t, 0, 0)
+	defer cleanup()
+
+	// the global veneur rejects a as invalid, and rate limits b
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("name") {
+		case "a":
+			w.WriteHeader(http.StatusBadRequest)
+		case "b":
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer srv.Close()
+
+	now := time.Now().Unix()
+	require.NoError(t, s.Write(testList(now-2, "a")))
+	require.NoError(t, s.Write(testList(now-1, "c")))
+	require.NoError(t, s.Write(testList(now, "b")))
+
+	var got []string
+	n, err := s.Replay(context.Background(), func(ctx context.Context, list *forwardrpc.MetricList) error {
+		name := list.Metrics[0].Name
+		err := vhttp.PostHelper(ctx, http.DefaultClient, nil, http.MethodPost, srv.URL+"/import?name="+name,
+			list.Metrics, "forward_spool", false, nil, logrus.New())
+		if err == nil {
+			got = append(got, name)
+		}
+		return err
+	})
+	assert.Error(t, err, "a rate limited batch should be retried")
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []string{"c"}, got, "a rejected batch shouldn't hold up the ones after it")
+
+	rejected, err := ioutil.ReadDir(filepath.Join(s.dir, rejectedDir))
+	require.NoError(t, err)
+	assert.Len(t, rejected, 1, "the rejected batch should be kept aside")
+	entries, err := s.list()
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "the rate limited batch should stay in the spool")
+}
+
+func TestSpoolLimits(t *testing.T) {
+	now := time.Now().Unix()
+	size := int64(testList(now, "a").Size())
+	s, cleanup := testSpool(t, 2*size, time.Hour)
+	defer cleanup()
+
+	require.NoError(t, s.Write(testList(now-2*3600, "a")))
+	require.NoError(t, s.Write(testList(now-2, "b")))
+	require.NoError(t, s.Write(testList(now-1, "c")))
+	require.NoError(t, s.Write(testList(now, "d")))
+	assert.Error(t, s.Write(testList(now, strings.Repeat("a", 100))), "batches larger than the spool should be refused")
+
+	var got []string
+	_, err := s.Replay(context.Background(), func(ctx context.Context, list *forwardrpc.MetricList) error {
+		got = append(got, list.Metrics[0].Name)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "d"}, got, "the oldest batches should be dropped")
+}
+
+func TestSpoolDropsCorruptBatches(t *testing.T) {
+	s, cleanup := testSpool(t, 0, 0)
+	defer cleanup()
+
+	now := time.Now().Unix()
+	require.NoError(t, s.Write(testList(now, "a")))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(s.dir, "00000000000000000001-1"+suffix), []byte{0xff}, 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(s.dir, tempPrefix+"123"), []byte{0xff}, 0644))
+
+	var got []string
+	_, err := s.Replay(context.Background(), func(ctx context.Context, list *forwardrpc.MetricList) error {
+		got = append(got, list.Metrics[0].Name)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, got)
+}
+
+func TestSpoolRequiresTimestamp(t *testing.T) {
+	s, cleanup := testSpool(t, 0, 0)
+	defer cleanup()
+	assert.Error(t, s.Write(testList(0, "a")))
+}
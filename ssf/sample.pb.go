@@ -9,6 +9,7 @@ import (
 	proto "github.com/gogo/protobuf/proto"
 	io "io"
 	math "math"
+	math_bits "math/bits"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -156,7 +157,7 @@ func (m *SSFSample) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return xxx_messageInfo_SSFSample.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
@@ -254,7 +255,7 @@ func (m *SSFSample) GetScope() SSFSample_Scope {
 // include metrics, just as it does not *have* to include information
 // necessary to reconstruct a trace.
 //
-// Compatibility
+// # Compatibility
 //
 // On ingestion, an SSFSpan with an empty string for a name field but
 // a tag "name" will have that name field replaced with the name tag,
@@ -263,7 +264,7 @@ func (m *SSFSample) GetScope() SSFSample_Scope {
 // Metric SSFSamples with a zero sample_rate (indicating it was left
 // out) have the sample_rate field set to 1 on ingestion.
 //
-// Validity Criteria
+// # Validity Criteria
 //
 // Programs consuming SSFSpans should take care to only process spans
 // and metrics that fulfill the following criteria:
@@ -309,6 +310,13 @@ type SSFSpan struct {
 	// (/customer/:id), the function (class::name.method), a friendly name
 	// (foo middleware) or whatever makes sense in your context.
 	Name string `protobuf:"bytes,13,opt,name=name,proto3" json:"name,omitempty"`
+	// The high and low 64 bits of a 128-bit trace ID, as used by W3C
+	// trace-context, OpenTelemetry, Zipkin and Jaeger. They are only set
+	// when the trace's ID doesn't fit in trace_id; trace_id then holds
+	// the low 63 bits of trace_id_low, so that consumers that only
+	// understand 64-bit IDs can still assemble the trace.
+	TraceIdHigh uint64 `protobuf:"fixed64,14,opt,name=trace_id_high,json=traceIdHigh,proto3" json:"trace_id_high,omitempty"`
+	TraceIdLow  uint64 `protobuf:"fixed64,15,opt,name=trace_id_low,json=traceIdLow,proto3" json:"trace_id_low,omitempty"`
 }
 
 func (m *SSFSpan) Reset()         { *m = SSFSpan{} }
@@ -325,7 +333,7 @@ func (m *SSFSpan) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return xxx_messageInfo_SSFSpan.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
@@ -428,6 +436,20 @@ func (m *SSFSpan) GetName() string {
 	return ""
 }
 
+func (m *SSFSpan) GetTraceIdHigh() uint64 {
+	if m != nil {
+		return m.TraceIdHigh
+	}
+	return 0
+}
+
+func (m *SSFSpan) GetTraceIdLow() uint64 {
+	if m != nil {
+		return m.TraceIdLow
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("ssf.SSFSample_Metric", SSFSample_Metric_name, SSFSample_Metric_value)
 	proto.RegisterEnum("ssf.SSFSample_Status", SSFSample_Status_name, SSFSample_Status_value)
@@ -441,53 +463,55 @@ func init() {
 func init() { proto.RegisterFile("ssf/sample.proto", fileDescriptor_7ef0544ca34aff6f) }
 
 var fileDescriptor_7ef0544ca34aff6f = []byte{
-	// 628 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0x41, 0x6f, 0xd3, 0x30,
-	0x14, 0x6e, 0x92, 0x26, 0x4d, 0x5e, 0xb7, 0x62, 0x59, 0x03, 0x19, 0x98, 0x4a, 0x55, 0x0e, 0x54,
-	0x03, 0x8a, 0x34, 0x0e, 0x4c, 0xdc, 0xba, 0xad, 0x2b, 0x65, 0x5d, 0x2b, 0x39, 0xa9, 0x76, 0x9c,
-	0x4c, 0xe3, 0x4d, 0x11, 0x6b, 0x1a, 0xd9, 0xde, 0xa4, 0xfd, 0x0b, 0x7e, 0x0a, 0x3f, 0x81, 0x23,
-	0xc7, 0x1d, 0x39, 0xa2, 0xed, 0x8f, 0x20, 0xdb, 0x5d, 0x03, 0x83, 0x13, 0x37, 0x7f, 0xef, 0xfb,
-	0xfa, 0xf2, 0xbe, 0xe7, 0xcf, 0x05, 0x24, 0xe5, 0xe9, 0x1b, 0xc9, 0xe6, 0xc5, 0x39, 0xef, 0x16,
-	0x62, 0xa1, 0x16, 0xd8, 0x93, 0xf2, 0xb4, 0xfd, 0xad, 0x0a, 0x51, 0x1c, 0x1f, 0xc4, 0x86, 0xc0,
-	0xaf, 0x21, 0x98, 0x73, 0x25, 0xb2, 0x19, 0x71, 0x5a, 0x4e, 0xa7, 0xb1, 0xfd, 0xb0, 0x2b, 0xe5,
-	0x69, 0x77, 0xc5, 0x77, 0x8f, 0x0c, 0x49, 0x97, 0x22, 0x8c, 0xa1, 0x9a, 0xb3, 0x39, 0x27, 0x6e,
-	0xcb, 0xe9, 0x44, 0xd4, 0x9c, 0xf1, 0x06, 0xf8, 0x97, 0xec, 0xfc, 0x82, 0x13, 0xaf, 0xe5, 0x74,
-	0x5c, 0x6a, 0x01, 0xde, 0x84, 0x48, 0x65, 0x73, 0x2e, 0x15, 0x9b, 0x17, 0xa4, 0xda, 0x72, 0x3a,
-	0x1e, 0x2d, 0x0b, 0x98, 0x40, 0x6d, 0xce, 0xa5, 0x64, 0x67, 0x9c, 0xf8, 0xa6, 0xd5, 0x1d, 0xd4,
-	0x03, 0x49, 0xc5, 0xd4, 0x85, 0x24, 0xc1, 0x3f, 0x07, 0x8a, 0x0d, 0x49, 0x97, 0x22, 0xfc, 0x0c,
-	0xea, 0xd6, 0xe2, 0x89, 0x60, 0x8a, 0x93, 0x9a, 0x19, 0x01, 0x6c, 0x89, 0x32, 0xc5, 0xf1, 0x2b,
-	0xa8, 0x2a, 0x76, 0x26, 0x49, 0xd8, 0xf2, 0x3a, 0xf5, 0x6d, 0x72, 0xaf, 0x5b, 0xc2, 0xce, 0x64,
-	0x3f, 0x57, 0xe2, 0x8a, 0x1a, 0x95, 0xf6, 0x77, 0x91, 0x67, 0x8a, 0x44, 0xd6, 0x9f, 0x3e, 0xe3,
-	0x2d, 0xf0, 0xe5, 0x6c, 0x51, 0x70, 0x02, 0x66, 0xa0, 0x8d, 0xfb, 0x03, 0x69, 0x8e, 0x5a, 0xc9,
-	0x93, 0x77, 0x10, 0xad, 0x5a, 0x62, 0x04, 0xde, 0x67, 0x7e, 0x65, 0x16, 0x1b, 0x51, 0x7d, 0x2c,
-	0x57, 0x65, 0xf7, 0x67, 0xc1, 0x7b, 0x77, 0xc7, 0x69, 0xef, 0x43, 0x60, 0x57, 0x8d, 0xeb, 0x50,
-	0xdb, 0x9b, 0x4c, 0xc7, 0x49, 0x9f, 0xa2, 0x0a, 0x8e, 0xc0, 0x1f, 0xf4, 0xa6, 0x83, 0x3e, 0x72,
-	0xf0, 0x3a, 0x44, 0x1f, 0x86, 0x71, 0x32, 0x19, 0xd0, 0xde, 0x11, 0x72, 0x71, 0x0d, 0xbc, 0xb8,
-	0x9f, 0x20, 0x0f, 0x03, 0x04, 0x71, 0xd2, 0x4b, 0xa6, 0x31, 0xaa, 0xb6, 0x77, 0x20, 0xb0, 0xfb,
-	0xc1, 0x01, 0xb8, 0x93, 0x43, 0x54, 0xd1, 0xdd, 0x8e, 0x7b, 0x74, 0x3c, 0x1c, 0x0f, 0x90, 0x83,
-	0xd7, 0x20, 0xdc, 0xa3, 0xc3, 0x64, 0xb8, 0xd7, 0x1b, 0x21, 0x57, 0x53, 0xd3, 0xf1, 0xe1, 0x78,
-	0x72, 0x3c, 0x46, 0x5e, 0xfb, 0x25, 0xf8, 0xc6, 0x88, 0xae, 0xee, 0xf7, 0x0f, 0x7a, 0xd3, 0x51,
-	0x62, 0x3f, 0x3f, 0x9a, 0x68, 0xb5, 0xa3, 0x3f, 0x33, 0x18, 0x4d, 0x76, 0xf5, 0x2f, 0xdb, 0x5f,
-	0x3d, 0xa8, 0xe9, 0x05, 0x14, 0x2c, 0xd7, 0x37, 0x79, 0xc9, 0x85, 0xcc, 0x16, 0xb9, 0x31, 0xea,
-	0xd3, 0x3b, 0x88, 0x1f, 0x43, 0xa8, 0x04, 0x9b, 0xf1, 0x93, 0x2c, 0x35, 0x7e, 0x3d, 0x5a, 0x33,
-	0x78, 0x98, 0xe2, 0x06, 0xb8, 0x59, 0x6a, 0xf2, 0xe2, 0x51, 0x37, 0x4b, 0xf1, 0x53, 0x88, 0x0a,
-	0x26, 0x78, 0xae, 0xb4, 0xd6, 0x86, 0x25, 0xb4, 0x85, 0x61, 0x8a, 0x5f, 0xc0, 0x03, 0xa9, 0x98,
-	0x50, 0x27, 0x65, 0x9e, 0x7c, 0x23, 0x69, 0x98, 0x72, 0xb2, 0x0a, 0xd5, 0x73, 0x58, 0xe7, 0x79,
-	0xfa, 0x9b, 0x2c, 0x30, 0xb2, 0x35, 0x9e, 0xa7, 0xa5, 0x68, 0x03, 0x7c, 0x2e, 0xc4, 0x42, 0x98,
-	0xa8, 0x84, 0xd4, 0x02, 0xed, 0x42, 0x72, 0x71, 0x99, 0xcd, 0x38, 0x09, 0x6d, 0x1e, 0x97, 0x10,
-	0x77, 0x74, 0x52, 0xf5, 0xc5, 0x48, 0x02, 0x26, 0x42, 0x8d, 0x3f, 0xef, 0x9f, 0xde, 0xd1, 0x78,
-	0x6b, 0x99, 0xb4, 0xba, 0x91, 0x3d, 0x5a, 0xc9, 0x0a, 0x96, 0xff, 0x95, 0xb3, 0x4d, 0x88, 0xb2,
-	0x3c, 0xcd, 0x66, 0x4c, 0x2d, 0x04, 0x59, 0x33, 0x93, 0x94, 0x85, 0xd5, 0x2b, 0x5b, 0x2f, 0x5f,
-	0xd9, 0x7f, 0x27, 0xeb, 0x63, 0x35, 0x8c, 0x10, 0xec, 0x92, 0xef, 0x37, 0x4d, 0xe7, 0xfa, 0xa6,
-	0xe9, 0xfc, 0xbc, 0x69, 0x3a, 0x5f, 0x6e, 0x9b, 0x95, 0xeb, 0xdb, 0x66, 0xe5, 0xc7, 0x6d, 0xb3,
-	0xf2, 0x29, 0x30, 0xff, 0x0d, 0x6f, 0x7f, 0x05, 0x00, 0x00, 0xff, 0xff, 0x2b, 0x10, 0x26, 0x15,
-	0x2f, 0x04, 0x00, 0x00,
+	// 665 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x94, 0x4d, 0x6f, 0xd3, 0x4c,
+	0x10, 0xc7, 0x63, 0x3b, 0x76, 0xe2, 0xc9, 0x4b, 0xad, 0x55, 0x9f, 0x47, 0x0b, 0x54, 0x21, 0x0a,
+	0x07, 0xac, 0x02, 0x41, 0x2a, 0x07, 0x2a, 0x6e, 0x69, 0x9b, 0xa6, 0xa1, 0x69, 0x22, 0xad, 0x1d,
+	0xf5, 0x18, 0x2d, 0xf1, 0x36, 0xb5, 0x68, 0x6c, 0xcb, 0xbb, 0x6d, 0xd5, 0x6f, 0xc1, 0x47, 0xe2,
+	0xc8, 0xb1, 0x47, 0x8e, 0xa8, 0xfd, 0x0e, 0x9c, 0xd1, 0xee, 0xe6, 0xa5, 0x14, 0x4e, 0xdc, 0x76,
+	0x66, 0x7e, 0x19, 0xcf, 0xfc, 0xf7, 0xbf, 0x01, 0x8f, 0xf3, 0xb3, 0xb7, 0x9c, 0xce, 0xb3, 0x0b,
+	0xd6, 0xce, 0xf2, 0x54, 0xa4, 0xc8, 0xe2, 0xfc, 0xac, 0xf5, 0xb5, 0x08, 0x6e, 0x10, 0x1c, 0x06,
+	0xaa, 0x80, 0xde, 0x80, 0x33, 0x67, 0x22, 0x8f, 0xa7, 0xd8, 0x68, 0x1a, 0x7e, 0x7d, 0xe7, 0xbf,
+	0x36, 0xe7, 0x67, 0xed, 0x55, 0xbd, 0x7d, 0xa2, 0x8a, 0x64, 0x01, 0x21, 0x04, 0xc5, 0x84, 0xce,
+	0x19, 0x36, 0x9b, 0x86, 0xef, 0x12, 0x75, 0x46, 0x9b, 0x60, 0x5f, 0xd1, 0x8b, 0x4b, 0x86, 0xad,
+	0xa6, 0xe1, 0x9b, 0x44, 0x07, 0x68, 0x0b, 0x5c, 0x11, 0xcf, 0x19, 0x17, 0x74, 0x9e, 0xe1, 0x62,
+	0xd3, 0xf0, 0x2d, 0xb2, 0x4e, 0x20, 0x0c, 0xa5, 0x39, 0xe3, 0x9c, 0xce, 0x18, 0xb6, 0x55, 0xab,
+	0x65, 0x28, 0x07, 0xe2, 0x82, 0x8a, 0x4b, 0x8e, 0x9d, 0xbf, 0x0e, 0x14, 0xa8, 0x22, 0x59, 0x40,
+	0xe8, 0x39, 0x54, 0xf4, 0x8a, 0x93, 0x9c, 0x0a, 0x86, 0x4b, 0x6a, 0x04, 0xd0, 0x29, 0x42, 0x05,
+	0x43, 0xaf, 0xa1, 0x28, 0xe8, 0x8c, 0xe3, 0x72, 0xd3, 0xf2, 0x2b, 0x3b, 0xf8, 0x51, 0xb7, 0x90,
+	0xce, 0x78, 0x37, 0x11, 0xf9, 0x0d, 0x51, 0x94, 0xdc, 0xef, 0x32, 0x89, 0x05, 0x76, 0xf5, 0x7e,
+	0xf2, 0x8c, 0xb6, 0xc1, 0xe6, 0xd3, 0x34, 0x63, 0x18, 0xd4, 0x40, 0x9b, 0x8f, 0x07, 0x92, 0x35,
+	0xa2, 0x91, 0xa7, 0xef, 0xc1, 0x5d, 0xb5, 0x44, 0x1e, 0x58, 0x9f, 0xd9, 0x8d, 0x12, 0xd6, 0x25,
+	0xf2, 0xb8, 0x96, 0x4a, 0xeb, 0xa7, 0x83, 0x0f, 0xe6, 0xae, 0xd1, 0x3a, 0x00, 0x47, 0x4b, 0x8d,
+	0x2a, 0x50, 0xda, 0x1f, 0x8d, 0x87, 0x61, 0x97, 0x78, 0x05, 0xe4, 0x82, 0xdd, 0xeb, 0x8c, 0x7b,
+	0x5d, 0xcf, 0x40, 0x35, 0x70, 0x8f, 0xfa, 0x41, 0x38, 0xea, 0x91, 0xce, 0x89, 0x67, 0xa2, 0x12,
+	0x58, 0x41, 0x37, 0xf4, 0x2c, 0x04, 0xe0, 0x04, 0x61, 0x27, 0x1c, 0x07, 0x5e, 0xb1, 0xb5, 0x0b,
+	0x8e, 0xd6, 0x07, 0x39, 0x60, 0x8e, 0x8e, 0xbd, 0x82, 0xec, 0x76, 0xda, 0x21, 0xc3, 0xfe, 0xb0,
+	0xe7, 0x19, 0xa8, 0x0a, 0xe5, 0x7d, 0xd2, 0x0f, 0xfb, 0xfb, 0x9d, 0x81, 0x67, 0xca, 0xd2, 0x78,
+	0x78, 0x3c, 0x1c, 0x9d, 0x0e, 0x3d, 0xab, 0xf5, 0x0a, 0x6c, 0xb5, 0x88, 0xcc, 0x1e, 0x74, 0x0f,
+	0x3b, 0xe3, 0x41, 0xa8, 0x3f, 0x3f, 0x18, 0x49, 0xda, 0x90, 0x9f, 0xe9, 0x0d, 0x46, 0x7b, 0xf2,
+	0x97, 0xad, 0x9f, 0x16, 0x94, 0xa4, 0x00, 0x19, 0x4d, 0xe4, 0x4d, 0x5e, 0xb1, 0x9c, 0xc7, 0x69,
+	0xa2, 0x16, 0xb5, 0xc9, 0x32, 0x44, 0x4f, 0xa0, 0x2c, 0x72, 0x3a, 0x65, 0x93, 0x38, 0x52, 0xfb,
+	0x5a, 0xa4, 0xa4, 0xe2, 0x7e, 0x84, 0xea, 0x60, 0xc6, 0x91, 0xf2, 0x8b, 0x45, 0xcc, 0x38, 0x42,
+	0xcf, 0xc0, 0xcd, 0x68, 0xce, 0x12, 0x21, 0x59, 0x6d, 0x96, 0xb2, 0x4e, 0xf4, 0x23, 0xf4, 0x12,
+	0x36, 0xb8, 0xa0, 0xb9, 0x98, 0xac, 0xfd, 0x64, 0x2b, 0xa4, 0xae, 0xd2, 0xe1, 0x32, 0x8b, 0x5e,
+	0x40, 0x8d, 0x25, 0xd1, 0x03, 0xcc, 0x51, 0x58, 0x95, 0x25, 0xd1, 0x1a, 0xda, 0x04, 0x9b, 0xe5,
+	0x79, 0x9a, 0x2b, 0xab, 0x94, 0x89, 0x0e, 0xe4, 0x16, 0x9c, 0xe5, 0x57, 0xf1, 0x94, 0xe1, 0xb2,
+	0xf6, 0xe3, 0x22, 0x44, 0xbe, 0x74, 0xaa, 0xbc, 0x18, 0x8e, 0x41, 0x59, 0xa8, 0xfe, 0xfb, 0xfd,
+	0x93, 0x65, 0x19, 0x6d, 0x2f, 0x9c, 0x56, 0x51, 0xd8, 0xff, 0x2b, 0x2c, 0xa3, 0xc9, 0x1f, 0x3e,
+	0xdb, 0x02, 0x37, 0x4e, 0xa2, 0x78, 0x4a, 0x45, 0x9a, 0xe3, 0xaa, 0x9a, 0x64, 0x9d, 0x58, 0xbd,
+	0xb2, 0xda, 0x83, 0x57, 0xd6, 0x82, 0xda, 0x52, 0xcd, 0xc9, 0x79, 0x3c, 0x3b, 0xc7, 0xf5, 0xa6,
+	0xe1, 0x3b, 0xa4, 0xb2, 0x90, 0xf4, 0x28, 0x9e, 0x9d, 0xa3, 0x26, 0x54, 0x57, 0xcc, 0x45, 0x7a,
+	0x8d, 0x37, 0x14, 0x02, 0x0b, 0x64, 0x90, 0x5e, 0xff, 0xb3, 0x3f, 0x3f, 0x16, 0xcb, 0xae, 0x07,
+	0x7b, 0xf8, 0xdb, 0x5d, 0xc3, 0xb8, 0xbd, 0x6b, 0x18, 0x3f, 0xee, 0x1a, 0xc6, 0x97, 0xfb, 0x46,
+	0xe1, 0xf6, 0xbe, 0x51, 0xf8, 0x7e, 0xdf, 0x28, 0x7c, 0x72, 0xd4, 0x3f, 0xcc, 0xbb, 0x5f, 0x03,
+	0x00, 0x45, 0xf2, 0x36, 0xf2, 0x75, 0x04, 0x00, 0x00,
 }
 
 func (m *SSFSample) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -495,84 +519,94 @@ func (m *SSFSample) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *SSFSample) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SSFSample) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Metric != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintSample(dAtA, i, uint64(m.Metric))
-	}
-	if len(m.Name) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintSample(dAtA, i, uint64(len(m.Name)))
-		i += copy(dAtA[i:], m.Name)
+	if m.Scope != 0 {
+		i = encodeVarintSample(dAtA, i, uint64(m.Scope))
+		i--
+		dAtA[i] = 0x50
 	}
-	if m.Value != 0 {
-		dAtA[i] = 0x1d
-		i++
-		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(math.Float32bits(float32(m.Value))))
-		i += 4
+	if len(m.Unit) > 0 {
+		i -= len(m.Unit)
+		copy(dAtA[i:], m.Unit)
+		i = encodeVarintSample(dAtA, i, uint64(len(m.Unit)))
+		i--
+		dAtA[i] = 0x4a
 	}
-	if m.Timestamp != 0 {
-		dAtA[i] = 0x20
-		i++
-		i = encodeVarintSample(dAtA, i, uint64(m.Timestamp))
+	if len(m.Tags) > 0 {
+		for k := range m.Tags {
+			v := m.Tags[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintSample(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintSample(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintSample(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x42
+		}
 	}
-	if len(m.Message) > 0 {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintSample(dAtA, i, uint64(len(m.Message)))
-		i += copy(dAtA[i:], m.Message)
+	if m.SampleRate != 0 {
+		i -= 4
+		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(math.Float32bits(float32(m.SampleRate))))
+		i--
+		dAtA[i] = 0x3d
 	}
 	if m.Status != 0 {
-		dAtA[i] = 0x30
-		i++
 		i = encodeVarintSample(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x30
 	}
-	if m.SampleRate != 0 {
-		dAtA[i] = 0x3d
-		i++
-		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(math.Float32bits(float32(m.SampleRate))))
-		i += 4
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintSample(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x2a
 	}
-	if len(m.Tags) > 0 {
-		for k, _ := range m.Tags {
-			dAtA[i] = 0x42
-			i++
-			v := m.Tags[k]
-			mapSize := 1 + len(k) + sovSample(uint64(len(k))) + 1 + len(v) + sovSample(uint64(len(v)))
-			i = encodeVarintSample(dAtA, i, uint64(mapSize))
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintSample(dAtA, i, uint64(len(k)))
-			i += copy(dAtA[i:], k)
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintSample(dAtA, i, uint64(len(v)))
-			i += copy(dAtA[i:], v)
-		}
+	if m.Timestamp != 0 {
+		i = encodeVarintSample(dAtA, i, uint64(m.Timestamp))
+		i--
+		dAtA[i] = 0x20
 	}
-	if len(m.Unit) > 0 {
-		dAtA[i] = 0x4a
-		i++
-		i = encodeVarintSample(dAtA, i, uint64(len(m.Unit)))
-		i += copy(dAtA[i:], m.Unit)
+	if m.Value != 0 {
+		i -= 4
+		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(math.Float32bits(float32(m.Value))))
+		i--
+		dAtA[i] = 0x1d
 	}
-	if m.Scope != 0 {
-		dAtA[i] = 0x50
-		i++
-		i = encodeVarintSample(dAtA, i, uint64(m.Scope))
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintSample(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
 	}
-	return i, nil
+	if m.Metric != 0 {
+		i = encodeVarintSample(dAtA, i, uint64(m.Metric))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
 }
 
 func (m *SSFSpan) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -580,112 +614,137 @@ func (m *SSFSpan) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *SSFSpan) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SSFSpan) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Version != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintSample(dAtA, i, uint64(m.Version))
-	}
-	if m.TraceId != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintSample(dAtA, i, uint64(m.TraceId))
+	if m.TraceIdLow != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.TraceIdLow))
+		i--
+		dAtA[i] = 0x79
+	}
+	if m.TraceIdHigh != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.TraceIdHigh))
+		i--
+		dAtA[i] = 0x71
 	}
-	if m.Id != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintSample(dAtA, i, uint64(m.Id))
-	}
-	if m.ParentId != 0 {
-		dAtA[i] = 0x20
-		i++
-		i = encodeVarintSample(dAtA, i, uint64(m.ParentId))
-	}
-	if m.StartTimestamp != 0 {
-		dAtA[i] = 0x28
-		i++
-		i = encodeVarintSample(dAtA, i, uint64(m.StartTimestamp))
-	}
-	if m.EndTimestamp != 0 {
-		dAtA[i] = 0x30
-		i++
-		i = encodeVarintSample(dAtA, i, uint64(m.EndTimestamp))
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintSample(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x6a
 	}
-	if m.Error {
-		dAtA[i] = 0x38
-		i++
-		if m.Error {
+	if m.Indicator {
+		i--
+		if m.Indicator {
 			dAtA[i] = 1
 		} else {
 			dAtA[i] = 0
 		}
-		i++
+		i--
+		dAtA[i] = 0x60
 	}
-	if len(m.Service) > 0 {
-		dAtA[i] = 0x42
-		i++
-		i = encodeVarintSample(dAtA, i, uint64(len(m.Service)))
-		i += copy(dAtA[i:], m.Service)
+	if len(m.Tags) > 0 {
+		for k := range m.Tags {
+			v := m.Tags[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintSample(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintSample(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintSample(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x5a
+		}
 	}
 	if len(m.Metrics) > 0 {
-		for _, msg := range m.Metrics {
-			dAtA[i] = 0x52
-			i++
-			i = encodeVarintSample(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
+		for iNdEx := len(m.Metrics) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Metrics[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSample(dAtA, i, uint64(size))
 			}
-			i += n
+			i--
+			dAtA[i] = 0x52
 		}
 	}
-	if len(m.Tags) > 0 {
-		for k, _ := range m.Tags {
-			dAtA[i] = 0x5a
-			i++
-			v := m.Tags[k]
-			mapSize := 1 + len(k) + sovSample(uint64(len(k))) + 1 + len(v) + sovSample(uint64(len(v)))
-			i = encodeVarintSample(dAtA, i, uint64(mapSize))
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintSample(dAtA, i, uint64(len(k)))
-			i += copy(dAtA[i:], k)
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintSample(dAtA, i, uint64(len(v)))
-			i += copy(dAtA[i:], v)
-		}
+	if len(m.Service) > 0 {
+		i -= len(m.Service)
+		copy(dAtA[i:], m.Service)
+		i = encodeVarintSample(dAtA, i, uint64(len(m.Service)))
+		i--
+		dAtA[i] = 0x42
 	}
-	if m.Indicator {
-		dAtA[i] = 0x60
-		i++
-		if m.Indicator {
+	if m.Error {
+		i--
+		if m.Error {
 			dAtA[i] = 1
 		} else {
 			dAtA[i] = 0
 		}
-		i++
+		i--
+		dAtA[i] = 0x38
 	}
-	if len(m.Name) > 0 {
-		dAtA[i] = 0x6a
-		i++
-		i = encodeVarintSample(dAtA, i, uint64(len(m.Name)))
-		i += copy(dAtA[i:], m.Name)
+	if m.EndTimestamp != 0 {
+		i = encodeVarintSample(dAtA, i, uint64(m.EndTimestamp))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.StartTimestamp != 0 {
+		i = encodeVarintSample(dAtA, i, uint64(m.StartTimestamp))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.ParentId != 0 {
+		i = encodeVarintSample(dAtA, i, uint64(m.ParentId))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Id != 0 {
+		i = encodeVarintSample(dAtA, i, uint64(m.Id))
+		i--
+		dAtA[i] = 0x18
 	}
-	return i, nil
+	if m.TraceId != 0 {
+		i = encodeVarintSample(dAtA, i, uint64(m.TraceId))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Version != 0 {
+		i = encodeVarintSample(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
 }
 
 func encodeVarintSample(dAtA []byte, offset int, v uint64) int {
+	offset -= sovSample(v)
+	base := offset
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
 		v >>= 7
 		offset++
 	}
 	dAtA[offset] = uint8(v)
-	return offset + 1
+	return base
 }
 func (m *SSFSample) Size() (n int) {
 	if m == nil {
@@ -786,18 +845,17 @@ func (m *SSFSpan) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovSample(uint64(l))
 	}
+	if m.TraceIdHigh != 0 {
+		n += 9
+	}
+	if m.TraceIdLow != 0 {
+		n += 9
+	}
 	return n
 }
 
 func sovSample(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
-	}
-	return n
+	return (math_bits.Len64(x|1) + 6) / 7
 }
 func sozSample(x uint64) (n int) {
 	return sovSample(uint64((x << 1) ^ uint64((int64(x) >> 63))))
@@ -1090,7 +1148,7 @@ func (m *SSFSample) Unmarshal(dAtA []byte) error {
 					if err != nil {
 						return err
 					}
-					if skippy < 0 {
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
 						return ErrInvalidLengthSample
 					}
 					if (iNdEx + skippy) > postIndex {
@@ -1158,10 +1216,7 @@ func (m *SSFSample) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthSample
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthSample
 			}
 			if (iNdEx + skippy) > l {
@@ -1521,7 +1576,7 @@ func (m *SSFSpan) Unmarshal(dAtA []byte) error {
 					if err != nil {
 						return err
 					}
-					if skippy < 0 {
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
 						return ErrInvalidLengthSample
 					}
 					if (iNdEx + skippy) > postIndex {
@@ -1584,16 +1639,33 @@ func (m *SSFSpan) Unmarshal(dAtA []byte) error {
 			}
 			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 14:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TraceIdHigh", wireType)
+			}
+			m.TraceIdHigh = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TraceIdHigh = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 15:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TraceIdLow", wireType)
+			}
+			m.TraceIdLow = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TraceIdLow = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSample(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthSample
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthSample
 			}
 			if (iNdEx + skippy) > l {
@@ -1611,6 +1683,7 @@ func (m *SSFSpan) Unmarshal(dAtA []byte) error {
 func skipSample(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
+	depth := 0
 	for iNdEx < l {
 		var wire uint64
 		for shift := uint(0); ; shift += 7 {
@@ -1642,10 +1715,8 @@ func skipSample(dAtA []byte) (n int, err error) {
 					break
 				}
 			}
-			return iNdEx, nil
 		case 1:
 			iNdEx += 8
-			return iNdEx, nil
 		case 2:
 			var length int
 			for shift := uint(0); ; shift += 7 {
@@ -1666,55 +1737,30 @@ func skipSample(dAtA []byte) (n int, err error) {
 				return 0, ErrInvalidLengthSample
 			}
 			iNdEx += length
-			if iNdEx < 0 {
-				return 0, ErrInvalidLengthSample
-			}
-			return iNdEx, nil
 		case 3:
-			for {
-				var innerWire uint64
-				var start int = iNdEx
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return 0, ErrIntOverflowSample
-					}
-					if iNdEx >= l {
-						return 0, io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					innerWire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				innerWireType := int(innerWire & 0x7)
-				if innerWireType == 4 {
-					break
-				}
-				next, err := skipSample(dAtA[start:])
-				if err != nil {
-					return 0, err
-				}
-				iNdEx = start + next
-				if iNdEx < 0 {
-					return 0, ErrInvalidLengthSample
-				}
-			}
-			return iNdEx, nil
+			depth++
 		case 4:
-			return iNdEx, nil
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupSample
+			}
+			depth--
 		case 5:
 			iNdEx += 4
-			return iNdEx, nil
 		default:
 			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
 		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthSample
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
 	}
-	panic("unreachable")
+	return 0, io.ErrUnexpectedEOF
 }
 
 var (
-	ErrInvalidLengthSample = fmt.Errorf("proto: negative length found during unmarshaling")
-	ErrIntOverflowSample   = fmt.Errorf("proto: integer overflow")
+	ErrInvalidLengthSample        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowSample          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupSample = fmt.Errorf("proto: unexpected end of group")
 )
@@ -0,0 +1,25 @@
+package ssf
+
+import "math"
+
+// FullTraceID returns the span's 128-bit trace ID as its high and low
+// 64 bits. Spans whose trace ID fits in trace_id have a high half of
+// zero.
+func (m *SSFSpan) FullTraceID() (high, low uint64) {
+	if m.TraceIdHigh == 0 && m.TraceIdLow == 0 {
+		return 0, uint64(m.TraceId)
+	}
+	return m.TraceIdHigh, m.TraceIdLow
+}
+
+// SetFullTraceID sets the span's trace ID from a 128-bit ID. trace_id
+// is set to the low 63 bits of the ID, and trace_id_high and
+// trace_id_low are only set if that loses any bits.
+func (m *SSFSpan) SetFullTraceID(high, low uint64) {
+	m.TraceId = int64(low & math.MaxInt64)
+	if high == 0 && low <= math.MaxInt64 {
+		m.TraceIdHigh, m.TraceIdLow = 0, 0
+		return
+	}
+	m.TraceIdHigh, m.TraceIdLow = high, low
+}
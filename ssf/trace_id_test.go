@@ -0,0 +1,27 @@
+package ssf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFullTraceID(t *testing.T) {
+	span := &SSFSpan{TraceId: 42}
+	high, low := span.FullTraceID()
+	assert.Equal(t, uint64(0), high)
+	assert.Equal(t, uint64(42), low)
+
+	span.SetFullTraceID(0, 7)
+	assert.Equal(t, &SSFSpan{TraceId: 7}, span, "IDs that fit in trace_id shouldn't set the 128-bit fields")
+
+	span.SetFullTraceID(0x4bf92f3577b34da6, 0xa3ce929d0e0e4736)
+	assert.Equal(t, int64(0x23ce929d0e0e4736), span.TraceId)
+	high, low = span.FullTraceID()
+	assert.Equal(t, uint64(0x4bf92f3577b34da6), high)
+	assert.Equal(t, uint64(0xa3ce929d0e0e4736), low)
+
+	span.SetFullTraceID(0, 0x8000000000000001)
+	assert.Equal(t, int64(1), span.TraceId)
+	assert.Equal(t, uint64(0x8000000000000001), span.TraceIdLow, "the top bit of a 64-bit ID should be kept")
+}
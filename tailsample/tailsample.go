@@ -0,0 +1,420 @@
+// Package tailsample implements tail-based sampling of traces.
+//
+// A Sampler is a span sink that wraps other span sinks. It holds on to the
+// spans of each trace for a decision window, starting when the first of
+// them arrives, and then decides whether to keep the whole trace from all
+// of its spans: whether any of them is an error or an indicator span, or
+// has one of some tags, or whether the root span took too long. The spans
+// of the traces it keeps go to the wrapped sinks, and the others are
+// dropped. The spans of a trace that arrive after it was decided follow
+// the decision.
+//
+// Unlike the sampling that each sink does on its own, which only looks at
+// trace IDs, this keeps the slow and failing traces that are the most
+// interesting.
+//
+// The spans of a trace often go to several veneurs. Samplers that are
+// configured with each other as Peers agree on which of them decides each
+// trace, by its trace ID, and send the spans of the traces they don't
+// decide to the one that does, so that it sees the whole trace.
+package tailsample
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stripe/veneur/hashring"
+	"github.com/stripe/veneur/protocol"
+	"github.com/stripe/veneur/sinks"
+	"github.com/stripe/veneur/ssf"
+	"github.com/stripe/veneur/trace"
+	"github.com/stripe/veneur/trace/metrics"
+)
+
+// DefaultMaxTraces is the number of traces a Sampler holds on to at once if
+// Options.MaxTraces is zero.
+const DefaultMaxTraces = 100000
+
+// The policies that keep a trace, as reported in the Sampler's metrics.
+const (
+	PolicyError       = "error"
+	PolicyIndicator   = "indicator"
+	PolicyDuration    = "duration"
+	PolicyTag         = "tag"
+	PolicyProbability = "probability"
+)
+
+// Options configures which traces a Sampler keeps. A trace is kept if any
+// of the policies that are set keeps it.
+type Options struct {
+	// DecisionWait is how long the spans of a trace are held on to after
+	// the first of them arrives, before the trace is decided. Since spans
+	// are sent when they finish, the root span usually arrives last: the
+	// window must be longer than the traces it samples take.
+	DecisionWait time.Duration
+	// MaxTraces is the number of traces held on to at once. When there
+	// are more, the oldest are decided before their window is over.
+	MaxTraces int
+
+	// KeepErrors keeps the traces with a span that is an error.
+	KeepErrors bool
+	// KeepIndicators keeps the traces with an indicator span.
+	KeepIndicators bool
+	// MinRootDuration keeps the traces whose root span took at least this
+	// long, if it's not zero.
+	MinRootDuration time.Duration
+	// Tags keeps the traces with a span that has one of these tags. Each
+	// is either "key:value", or "key" to match any value.
+	Tags []string
+	// Percent is the percentage of the other traces that are kept, by
+	// their trace ID, so that every Sampler keeps the same ones.
+	Percent float64
+
+	// Self and the keys of Peers name the Samplers that share the traces
+	// they sample, and every one of them must be configured with the same
+	// names. Each trace is decided by one of them, picked by its trace ID;
+	// the spans of the traces that a peer decides are sent to it with its
+	// span sink, which must pass them to its Sampler's IngestFromPeer.
+	Self  string
+	Peers map[string]sinks.SpanSink
+}
+
+// Sampler is a span sink that forwards the spans of the traces its Options
+// keep to the sinks it wraps. Its methods are safe for concurrent use.
+type Sampler struct {
+	opts        Options
+	tags        map[string]string
+	sinks       []sinks.SpanSink
+	owners      *hashring.Rendezvous // nil unless the Sampler has peers
+	log         *logrus.Logger
+	traceClient *trace.Client
+	now         func() time.Time
+
+	mtx sync.Mutex
+	// pending are the traces in their decision window, and queue their
+	// IDs in the order they arrived in
+	pending map[int64]*pendingTrace
+	queue   []queuedTrace
+	// decided are the decisions on the traces whose window is over, kept
+	// for another window for the spans that arrive late
+	decided      map[int64]bool
+	decidedQueue []queuedTrace
+	stats        stats
+}
+
+type pendingTrace struct {
+	deadline time.Time
+	spans    []*ssf.SSFSpan
+}
+
+type queuedTrace struct {
+	id       int64
+	deadline time.Time
+}
+
+// stats counts what happened to traces and spans since the last flush.
+type stats struct {
+	kept         map[string]int64
+	dropped      int64
+	early        int64
+	keptSpans    int64
+	droppedSpans int64
+	peerSpans    int64
+	ingestErrors int64
+}
+
+var _ sinks.SpanSink = &Sampler{}
+
+// New creates a Sampler that forwards the spans of the traces that opts
+// keep to each of sinks.
+func New(sinks []sinks.SpanSink, opts Options, log *logrus.Logger) *Sampler {
+	if opts.MaxTraces <= 0 {
+		opts.MaxTraces = DefaultMaxTraces
+	}
+	tags := make(map[string]string, len(opts.Tags))
+	for _, tag := range opts.Tags {
+		kv := strings.SplitN(tag, ":", 2)
+		if len(kv) == 1 {
+			tags[kv[0]] = ""
+		} else {
+			tags[kv[0]] = kv[1]
+		}
+	}
+
+	var owners *hashring.Rendezvous
+	if len(opts.Peers) > 0 {
+		members := []string{opts.Self}
+		for peer := range opts.Peers {
+			members = append(members, peer)
+		}
+		owners = hashring.NewRendezvous()
+		owners.Set(members)
+	}
+
+	return &Sampler{
+		opts:    opts,
+		tags:    tags,
+		sinks:   sinks,
+		owners:  owners,
+		log:     log,
+		now:     time.Now,
+		pending: make(map[int64]*pendingTrace),
+		decided: make(map[int64]bool),
+		stats:   stats{kept: make(map[string]int64)},
+	}
+}
+
+// Name returns the name of the sink.
+func (s *Sampler) Name() string {
+	return "tail_sampling"
+}
+
+// Start starts the wrapped sinks, and decides the traces whose window is
+// over in the background.
+func (s *Sampler) Start(cl *trace.Client) error {
+	s.traceClient = cl
+	for _, sink := range s.sinks {
+		if err := sink.Start(cl); err != nil {
+			return err
+		}
+	}
+	for _, peer := range s.opts.Peers {
+		if err := peer.Start(cl); err != nil {
+			return err
+		}
+	}
+
+	tick := s.opts.DecisionWait / 10
+	if tick < time.Millisecond {
+		tick = time.Millisecond
+	}
+	go func() {
+		for range time.Tick(tick) {
+			s.send(s.decide())
+		}
+	}()
+	return nil
+}
+
+// Ingest holds on to a span until its trace is decided, or forwards it to
+// the wrapped sinks right away if its trace was kept already. If a peer
+// decides the span's trace, the span is sent to that peer instead.
+func (s *Sampler) Ingest(span *ssf.SSFSpan) error {
+	if err := protocol.ValidateTrace(span); err != nil {
+		return err
+	}
+	if peer := s.owner(span); peer != nil {
+		s.mtx.Lock()
+		s.stats.peerSpans++
+		s.mtx.Unlock()
+		return peer.Ingest(span)
+	}
+	return s.hold(span)
+}
+
+// IngestFromPeer holds on to a span that a peer sent, because this Sampler
+// decides its trace, the way Ingest does. It's never sent on to another
+// peer, so that peers that disagree on the owner of a trace, while they're
+// being reconfigured, can't send its spans back and forth.
+func (s *Sampler) IngestFromPeer(span *ssf.SSFSpan) error {
+	if err := protocol.ValidateTrace(span); err != nil {
+		return err
+	}
+	return s.hold(span)
+}
+
+// owner returns the span sink of the peer that decides span's trace, or
+// nil if this Sampler does.
+func (s *Sampler) owner(span *ssf.SSFSpan) sinks.SpanSink {
+	if s.owners == nil {
+		return nil
+	}
+	high, low := span.FullTraceID()
+	owner, err := s.owners.Get(fmt.Sprintf("%016x%016x", high, low))
+	if err != nil || owner == s.opts.Self {
+		return nil
+	}
+	return s.opts.Peers[owner]
+}
+
+// hold holds on to a span until its trace is decided, or forwards it to
+// the wrapped sinks right away if its trace was kept already.
+func (s *Sampler) hold(span *ssf.SSFSpan) error {
+	s.mtx.Lock()
+	if keep, ok := s.decided[span.TraceId]; ok {
+		s.count(keep, 1)
+		s.mtx.Unlock()
+		if keep {
+			s.send([]*ssf.SSFSpan{span})
+		}
+		return nil
+	}
+
+	var early []*ssf.SSFSpan
+	t, ok := s.pending[span.TraceId]
+	if !ok {
+		if len(s.pending) >= s.opts.MaxTraces {
+			early = s.decideOldest()
+		}
+		t = &pendingTrace{deadline: s.now().Add(s.opts.DecisionWait)}
+		s.pending[span.TraceId] = t
+		s.queue = append(s.queue, queuedTrace{id: span.TraceId, deadline: t.deadline})
+	}
+	t.spans = append(t.spans, span)
+	s.mtx.Unlock()
+
+	s.send(early)
+	return nil
+}
+
+// Flush reports what happened to traces since the last flush, and flushes
+// the wrapped sinks.
+func (s *Sampler) Flush() {
+	s.mtx.Lock()
+	st := s.stats
+	s.stats = stats{kept: make(map[string]int64)}
+	pending := len(s.pending)
+	s.mtx.Unlock()
+
+	samples := []*ssf.SSFSample{
+		ssf.Count("tail_sampling.traces_total", float32(st.dropped),
+			map[string]string{"decision": "dropped"}),
+		ssf.Count("tail_sampling.spans_total", float32(st.keptSpans),
+			map[string]string{"decision": "kept"}),
+		ssf.Count("tail_sampling.spans_total", float32(st.droppedSpans),
+			map[string]string{"decision": "dropped"}),
+		ssf.Count("tail_sampling.early_decisions_total", float32(st.early), nil),
+		ssf.Count("tail_sampling.peer_spans_total", float32(st.peerSpans), nil),
+		ssf.Count("tail_sampling.ingest_errors_total", float32(st.ingestErrors), nil),
+		ssf.Gauge("tail_sampling.pending_traces", float32(pending), nil),
+	}
+	for policy, n := range st.kept {
+		samples = append(samples, ssf.Count("tail_sampling.traces_total", float32(n),
+			map[string]string{"decision": "kept", "policy": policy}))
+	}
+	_ = metrics.ReportBatch(s.traceClient, samples)
+
+	for _, sink := range s.sinks {
+		sink.Flush()
+	}
+	for _, peer := range s.opts.Peers {
+		peer.Flush()
+	}
+}
+
+// decide decides the traces whose window is over, and forgets the decisions
+// that are old enough. It returns the spans of the traces it keeps.
+func (s *Sampler) decide() []*ssf.SSFSpan {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	now := s.now()
+	for len(s.decidedQueue) > 0 && !s.decidedQueue[0].deadline.After(now) {
+		delete(s.decided, s.decidedQueue[0].id)
+		s.decidedQueue = s.decidedQueue[1:]
+	}
+
+	var kept []*ssf.SSFSpan
+	for len(s.queue) > 0 && !s.queue[0].deadline.After(now) {
+		kept = append(kept, s.decideTrace(s.queue[0])...)
+		s.queue = s.queue[1:]
+	}
+	return kept
+}
+
+// decideOldest decides the trace that arrived first before its window is
+// over, to make room for another one, and returns its spans if it's kept.
+func (s *Sampler) decideOldest() []*ssf.SSFSpan {
+	for len(s.queue) > 0 {
+		qt := s.queue[0]
+		s.queue = s.queue[1:]
+		if _, ok := s.pending[qt.id]; ok {
+			s.stats.early++
+			return s.decideTrace(qt)
+		}
+	}
+	return nil
+}
+
+// decideTrace decides a trace in the queue and records the decision. It
+// returns the spans of the trace if it's kept.
+func (s *Sampler) decideTrace(qt queuedTrace) []*ssf.SSFSpan {
+	t, ok := s.pending[qt.id]
+	if !ok || !t.deadline.Equal(qt.deadline) {
+		// the trace was decided early already
+		return nil
+	}
+	delete(s.pending, qt.id)
+
+	policy, keep := s.keep(qt.id, t.spans)
+	s.decided[qt.id] = keep
+	s.decidedQueue = append(s.decidedQueue, queuedTrace{
+		id:       qt.id,
+		deadline: s.now().Add(s.opts.DecisionWait),
+	})
+	s.count(keep, len(t.spans))
+	if !keep {
+		s.stats.dropped++
+		return nil
+	}
+	s.stats.kept[policy]++
+	return t.spans
+}
+
+// keep returns whether a trace is kept, and the policy that keeps it.
+func (s *Sampler) keep(traceID int64, spans []*ssf.SSFSpan) (string, bool) {
+	for _, span := range spans {
+		if s.opts.KeepErrors && span.Error {
+			return PolicyError, true
+		}
+		if s.opts.KeepIndicators && span.Indicator {
+			return PolicyIndicator, true
+		}
+		if s.opts.MinRootDuration > 0 && (span.ParentId == 0 || span.Id == span.TraceId) &&
+			span.EndTimestamp-span.StartTimestamp >= s.opts.MinRootDuration.Nanoseconds() {
+			return PolicyDuration, true
+		}
+		for k, v := range span.Tags {
+			if want, ok := s.tags[k]; ok && (want == "" || want == v) {
+				return PolicyTag, true
+			}
+		}
+	}
+
+	if s.opts.Percent > 0 && float64(uint64(traceID)%10000) < s.opts.Percent*100 {
+		return PolicyProbability, true
+	}
+	return "", false
+}
+
+func (s *Sampler) count(keep bool, spans int) {
+	if keep {
+		s.stats.keptSpans += int64(spans)
+	} else {
+		s.stats.droppedSpans += int64(spans)
+	}
+}
+
+// send forwards spans to each of the wrapped sinks.
+func (s *Sampler) send(spans []*ssf.SSFSpan) {
+	var errs int64
+	for _, span := range spans {
+		for _, sink := range s.sinks {
+			if err := sink.Ingest(span); err != nil {
+				if _, ok := err.(*protocol.InvalidTrace); !ok {
+					errs++
+					s.log.WithError(err).WithField("sink", sink.Name()).
+						Debug("Failed to ingest a sampled span")
+				}
+			}
+		}
+	}
+	if errs > 0 {
+		s.mtx.Lock()
+		s.stats.ingestErrors += errs
+		s.mtx.Unlock()
+	}
+}
@@ -0,0 +1,217 @@
+package tailsample
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/veneur/sinks"
+	"github.com/stripe/veneur/ssf"
+	"github.com/stripe/veneur/trace"
+)
+
+type recordingSink struct {
+	mtx   sync.Mutex
+	spans []*ssf.SSFSpan
+}
+
+func (rs *recordingSink) Start(*trace.Client) error { return nil }
+func (rs *recordingSink) Name() string              { return "recording" }
+func (rs *recordingSink) Flush()                    {}
+
+func (rs *recordingSink) Ingest(span *ssf.SSFSpan) error {
+	rs.mtx.Lock()
+	defer rs.mtx.Unlock()
+	rs.spans = append(rs.spans, span)
+	return nil
+}
+
+// traceIDs returns the trace IDs of the spans the sink got, once each.
+func (rs *recordingSink) traceIDs() []int64 {
+	rs.mtx.Lock()
+	defer rs.mtx.Unlock()
+	var ids []int64
+	seen := map[int64]bool{}
+	for _, span := range rs.spans {
+		if !seen[span.TraceId] {
+			seen[span.TraceId] = true
+			ids = append(ids, span.TraceId)
+		}
+	}
+	return ids
+}
+
+func newTestSampler(opts Options) (*Sampler, *recordingSink, *time.Time) {
+	sink := &recordingSink{}
+	s := New([]sinks.SpanSink{sink}, opts, logrus.New())
+	now := time.Unix(1000, 0)
+	s.now = func() time.Time { return now }
+	return s, sink, &now
+}
+
+// testSpan returns a span of a trace that took duration; it's the root
+// span if id is the trace ID.
+func testSpan(traceID, id int64, duration time.Duration) *ssf.SSFSpan {
+	parent := traceID
+	if id == traceID {
+		parent = 0
+	}
+	return &ssf.SSFSpan{
+		TraceId:        traceID,
+		Id:             id,
+		ParentId:       parent,
+		StartTimestamp: 1,
+		EndTimestamp:   1 + duration.Nanoseconds(),
+		Service:        "test",
+		Name:           "span",
+		Tags:           map[string]string{},
+	}
+}
+
+func TestPolicies(t *testing.T) {
+	s, sink, now := newTestSampler(Options{
+		DecisionWait:    time.Second,
+		KeepErrors:      true,
+		KeepIndicators:  true,
+		MinRootDuration: time.Second,
+		Tags:            []string{"debug", "customer:important"},
+	})
+
+	errored := testSpan(1, 11, 0)
+	errored.Error = true
+	indicator := testSpan(2, 21, 0)
+	indicator.Indicator = true
+	tagged := testSpan(4, 41, 0)
+	tagged.Tags["debug"] = "yes"
+	important := testSpan(5, 51, 0)
+	important.Tags["customer"] = "important"
+	other := testSpan(6, 61, 0)
+	other.Tags["customer"] = "other"
+
+	for _, span := range []*ssf.SSFSpan{
+		testSpan(1, 1, 0), errored,
+		indicator,
+		testSpan(3, 31, 0), testSpan(3, 3, 2*time.Second),
+		tagged, important, other,
+		testSpan(7, 7, time.Millisecond),
+	} {
+		require.NoError(t, s.Ingest(span))
+	}
+
+	s.send(s.decide())
+	assert.Empty(t, sink.traceIDs(), "the decision window isn't over yet")
+
+	*now = now.Add(time.Second)
+	s.send(s.decide())
+	assert.Equal(t, []int64{1, 2, 3, 4, 5}, sink.traceIDs())
+	assert.Len(t, sink.spans, 7, "every span of the kept traces should be sent")
+	assert.Equal(t, int64(1), s.stats.kept[PolicyError])
+	assert.Equal(t, int64(1), s.stats.kept[PolicyDuration])
+	assert.Equal(t, int64(2), s.stats.dropped)
+	assert.Empty(t, s.pending)
+}
+
+func TestLateSpans(t *testing.T) {
+	s, sink, now := newTestSampler(Options{DecisionWait: time.Second, KeepErrors: true})
+
+	errored := testSpan(1, 11, 0)
+	errored.Error = true
+	require.NoError(t, s.Ingest(errored))
+	require.NoError(t, s.Ingest(testSpan(2, 21, 0)))
+	*now = now.Add(time.Second)
+	s.send(s.decide())
+	require.Len(t, sink.spans, 1)
+
+	// the late spans follow the decision on their trace
+	require.NoError(t, s.Ingest(testSpan(1, 1, 0)))
+	require.NoError(t, s.Ingest(testSpan(2, 2, 0)))
+	assert.Len(t, sink.spans, 2)
+	assert.Empty(t, s.pending)
+
+	// until the decision is forgotten
+	*now = now.Add(time.Second)
+	s.decide()
+	require.NoError(t, s.Ingest(testSpan(1, 12, 0)))
+	assert.Len(t, s.pending, 1)
+}
+
+func TestMaxTraces(t *testing.T) {
+	s, sink, _ := newTestSampler(Options{DecisionWait: time.Minute, MaxTraces: 2, Percent: 100})
+
+	for id := int64(1); id <= 3; id++ {
+		require.NoError(t, s.Ingest(testSpan(id, id, time.Millisecond)))
+	}
+	assert.Equal(t, []int64{1}, sink.traceIDs(), "the oldest trace should be decided early")
+	assert.Len(t, s.pending, 2)
+	assert.Equal(t, int64(1), s.stats.early)
+}
+
+func TestPercent(t *testing.T) {
+	s, sink, now := newTestSampler(Options{DecisionWait: time.Second, Percent: 25})
+
+	for id := int64(1); id <= 10000; id++ {
+		require.NoError(t, s.Ingest(testSpan(id, id, time.Millisecond)))
+	}
+	*now = now.Add(time.Second)
+	s.send(s.decide())
+	assert.Len(t, sink.traceIDs(), 2500)
+}
+
+func TestInvalidSpans(t *testing.T) {
+	s, sink, _ := newTestSampler(Options{DecisionWait: time.Second, Percent: 100})
+
+	assert.Error(t, s.Ingest(&ssf.SSFSpan{}), "spans without a trace aren't sampled")
+	assert.Empty(t, s.pending)
+	assert.Empty(t, sink.spans)
+}
+
+// peerSink sends spans to another Sampler, the way the span sinks of a
+// Sampler's peers do.
+type peerSink struct {
+	recordingSink
+	to *Sampler
+}
+
+func (ps *peerSink) Ingest(span *ssf.SSFSpan) error {
+	return ps.to.IngestFromPeer(span)
+}
+
+func TestPeers(t *testing.T) {
+	toA, toB := &peerSink{}, &peerSink{}
+	a, sinkA, nowA := newTestSampler(Options{
+		DecisionWait: time.Second,
+		KeepErrors:   true,
+		Self:         "a",
+		Peers:        map[string]sinks.SpanSink{"b": toB},
+	})
+	b, sinkB, nowB := newTestSampler(Options{
+		DecisionWait: time.Second,
+		KeepErrors:   true,
+		Self:         "b",
+		Peers:        map[string]sinks.SpanSink{"a": toA},
+	})
+	toA.to, toB.to = a, b
+
+	// every trace is split across the samplers, and only the half that
+	// goes to a has the error that keeps it
+	for id := int64(1); id <= 20; id++ {
+		errored := testSpan(id, id*10, 0)
+		errored.Error = true
+		require.NoError(t, a.Ingest(errored))
+		require.NoError(t, b.Ingest(testSpan(id, id, 0)))
+	}
+	*nowA = nowA.Add(time.Second)
+	*nowB = nowB.Add(time.Second)
+	a.send(a.decide())
+	b.send(b.decide())
+
+	assert.NotEmpty(t, sinkA.traceIDs(), "a should decide some of the traces")
+	assert.NotEmpty(t, sinkB.traceIDs(), "b should decide some of the traces")
+	assert.Len(t, append(sinkA.traceIDs(), sinkB.traceIDs()...), 20, "every trace should be kept by one sampler")
+	assert.Len(t, append(sinkA.spans, sinkB.spans...), 40, "every span of the kept traces should be sent")
+	assert.Equal(t, int64(len(sinkB.traceIDs())), a.stats.peerSpans)
+	assert.Equal(t, int64(len(sinkA.traceIDs())), b.stats.peerSpans)
+}
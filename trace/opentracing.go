@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math"
 	"net/http"
 	"runtime"
 	"strconv"
@@ -64,6 +65,15 @@ var HeaderFormats = []HeaderGroup{
 	},
 }
 
+// W3C trace-context (https://www.w3.org/TR/trace-context/) headers.
+// They are injected alongside defaultHeaderFormat, so that tracers that
+// only speak W3C trace-context can continue veneur's traces, and they
+// carry the full 128-bit trace ID of traces that started in one.
+const (
+	traceparentHeader = "traceparent"
+	tracestateHeader  = "tracestate"
+)
+
 // defaultHeaderFormat is the way .Inject sets HTTP headers by
 // default.
 var defaultHeaderFormat = HeaderFormats[0]
@@ -166,6 +176,35 @@ func (c *spanContext) SpanID() int64 {
 	return c.parseBaggageInt64("spanid")
 }
 
+// FullTraceID extracts the 128-bit trace ID from the BaggageItems, as
+// its high and low 64 bits. Both are zero if the trace ID fits in
+// TraceID.
+func (c *spanContext) FullTraceID() (high, low uint64) {
+	high, _ = strconv.ParseUint(c.baggageItem("traceidhigh"), 10, 64)
+	low, _ = strconv.ParseUint(c.baggageItem("traceidlow"), 10, 64)
+	return high, low
+}
+
+// TraceState returns the W3C tracestate of the trace, if it was
+// continued from a traceparent header.
+func (c *spanContext) TraceState() string {
+	return c.baggageItem("tracestate")
+}
+
+// baggageItem searches for the target key in the BaggageItems and
+// returns its value. It treats keys as case-insensitive.
+func (c *spanContext) baggageItem(key string) string {
+	var val string
+	c.ForeachBaggageItem(func(k, v string) bool {
+		if strings.ToLower(k) == strings.ToLower(key) {
+			val = v
+			return false
+		}
+		return true
+	})
+	return val
+}
+
 // parseBaggageInt64 searches for the target key in the BaggageItems
 // and parses it as an int64. It treats keys as case-insensitive.
 func (c *spanContext) parseBaggageInt64(key string) int64 {
@@ -439,6 +478,7 @@ func (t Tracer) StartSpan(operationName string, opts ...opentracing.StartSpanOpt
 				parent.TraceID = ctx.TraceID()
 				parent.SpanID = ctx.SpanID()
 				parent.Resource = ctx.Resource()
+				parent.setFullTraceContext(ctx)
 
 			default:
 				// TODO handle error
@@ -505,12 +545,14 @@ func (tracer Tracer) ExtractRequestChild(resource string, req *http.Request, nam
 
 	parent := parentSpan.(*spanContext)
 
-	t := StartChildSpan(&Trace{
+	parentTrace := &Trace{
 		SpanID:   parent.SpanID(),
 		TraceID:  parent.TraceID(),
 		ParentID: parent.ParentID(),
 		Resource: resource,
-	})
+	}
+	parentTrace.setFullTraceContext(parent)
+	t := StartChildSpan(parentTrace)
 
 	t.Name = name
 	return &Span{
@@ -547,6 +589,7 @@ func (t Tracer) Inject(sm opentracing.SpanContext, format interface{}, carrier i
 			Resource: sc.Resource(),
 			Tags:     map[string]string{ResourceKey: sc.Resource()},
 		}
+		trace.setFullTraceContext(sc)
 
 		return trace.ProtoMarshalTo(w)
 	case opentracing.HTTPHeaders:
@@ -562,6 +605,7 @@ func (t Tracer) Inject(sm opentracing.SpanContext, format interface{}, carrier i
 				h.Set(name, value)
 			}
 		}
+		injectTraceContext(sc, h)
 		return nil
 	}
 
@@ -569,6 +613,7 @@ func (t Tracer) Inject(sm opentracing.SpanContext, format interface{}, carrier i
 	if w, ok := carrier.(opentracing.TextMapWriter); ok {
 
 		textMapReaderWriter(sc.baggageItems).CloneTo(w)
+		injectTraceContext(sc, w)
 		return nil
 	}
 
@@ -603,9 +648,11 @@ func (t Tracer) Extract(format interface{}, carrier interface{}) (ctx opentracin
 		resource := sample.Tags[ResourceKey]
 
 		trace := &Trace{
-			TraceID:  sample.TraceId,
-			SpanID:   sample.Id,
-			Resource: resource,
+			TraceID:     sample.TraceId,
+			TraceIDHigh: sample.TraceIdHigh,
+			TraceIDLow:  sample.TraceIdLow,
+			SpanID:      sample.Id,
+			Resource:    resource,
 		}
 
 		return trace.context(), nil
@@ -630,6 +677,16 @@ func (t Tracer) Extract(format interface{}, carrier interface{}) (ctx opentracin
 				break
 			}
 		}
+
+		// The W3C traceparent header carries the full 128-bit trace
+		// ID. It's only used for the span ID if none of the formats
+		// above were found: like with Envoy's headers, one of those is
+		// likely to have been set by a nearer parent.
+		high, low, w3cSpanID, w3cOK := parseTraceparent(textMapReaderGet(tm, traceparentHeader))
+		w3cTraceID := int64(low & math.MaxInt64)
+		if w3cOK && (traceID == 0 || spanID == 0) {
+			traceID, spanID = w3cTraceID, w3cSpanID
+		}
 		if traceID == 0 && spanID == 0 {
 			return nil, errors.New("error parsing fields from TextMapReader")
 		}
@@ -639,6 +696,10 @@ func (t Tracer) Extract(format interface{}, carrier interface{}) (ctx opentracin
 			SpanID:   spanID,
 			Resource: textMapReaderGet(tm, ResourceKey),
 		}
+		if w3cOK && traceID == w3cTraceID {
+			trace.setFullTraceID(high, low)
+			trace.TraceState = textMapReaderGetAll(tm, tracestateHeader)
+		}
 
 		return trace.context(), nil
 	}
@@ -657,3 +718,66 @@ func textMapReaderGet(tmr opentracing.TextMapReader, key string) (value string)
 	})
 	return value
 }
+
+// textMapReaderGetAll returns every value of a key, joined by commas
+// the way repeated HTTP headers are combined.
+func textMapReaderGetAll(tmr opentracing.TextMapReader, key string) string {
+	var values []string
+	tmr.ForeachKey(func(k, v string) error {
+		if strings.ToLower(key) == strings.ToLower(k) {
+			values = append(values, v)
+		}
+		return nil
+	})
+	return strings.Join(values, ",")
+}
+
+// injectTraceContext writes the W3C traceparent and tracestate headers
+// for a spanContext. Traces that veneur started have a 128-bit ID whose
+// high 64 bits are zero.
+func injectTraceContext(sc *spanContext, w opentracing.TextMapWriter) {
+	high, low := sc.FullTraceID()
+	if high == 0 && low == 0 {
+		low = uint64(sc.TraceID())
+	}
+	w.Set(traceparentHeader, fmt.Sprintf("00-%016x%016x-%016x-01", high, low, uint64(sc.SpanID())))
+	if state := sc.TraceState(); state != "" {
+		w.Set(tracestateHeader, state)
+	}
+}
+
+// parseTraceparent parses a W3C traceparent header of the form
+// version-traceid-parentid-flags, e.g.
+// 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01. It returns
+// the trace ID as its high and low 64 bits, and the low 63 bits of the
+// parent's span ID.
+func parseTraceparent(value string) (high, low uint64, spanID int64, ok bool) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || len(parts[1]) != 32 ||
+		len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return 0, 0, 0, false
+	}
+	// Version ff is invalid, and version 00 has exactly four fields.
+	// Later versions may add fields, which we ignore.
+	version, err := strconv.ParseUint(parts[0], 16, 8)
+	if err != nil || version == 0xff || (version == 0 && len(parts) != 4) {
+		return 0, 0, 0, false
+	}
+
+	if high, err = strconv.ParseUint(parts[1][:16], 16, 64); err != nil {
+		return 0, 0, 0, false
+	}
+	if low, err = strconv.ParseUint(parts[1][16:], 16, 64); err != nil {
+		return 0, 0, 0, false
+	}
+	id, err := strconv.ParseUint(parts[2], 16, 64)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	spanID = int64(id & math.MaxInt64)
+
+	if low&math.MaxInt64 == 0 || spanID == 0 {
+		return 0, 0, 0, false
+	}
+	return high, low, spanID, true
+}
@@ -4,6 +4,7 @@ package trace
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -329,3 +330,103 @@ func TestInjectRequestInjectHeaderExtractRequestChild(t *testing.T) {
 	assert.Equal(t, trace.SpanID, span.ParentID, "child should have the original trace's SpanId as its ParentId")
 	assert.Equal(t, trace.TraceID, span.TraceID)
 }
+
+func TestTraceExtractHeaderW3C(t *testing.T) {
+	tracer := Tracer{}
+	req, err := http.NewRequest(http.MethodGet, "/test", nil)
+	assert.NoError(t, err)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	req.Header.Add("tracestate", "congo=t61rcWkgMzE")
+	req.Header.Add("tracestate", "rojo=00f067aa0ba902b7")
+
+	span, err := tracer.ExtractRequestChild("/test", req, "w3c")
+	assert.NoError(t, err)
+
+	assert.Equal(t, int64(0x23ce929d0e0e4736), span.TraceID)
+	assert.Equal(t, int64(0x00f067aa0ba902b7), span.ParentID)
+	assert.Equal(t, uint64(0x4bf92f3577b34da6), span.TraceIDHigh)
+	assert.Equal(t, uint64(0xa3ce929d0e0e4736), span.TraceIDLow)
+	assert.Equal(t, "congo=t61rcWkgMzE,rojo=00f067aa0ba902b7", span.TraceState)
+
+	sample := span.SSFSpan()
+	assert.Equal(t, uint64(0x4bf92f3577b34da6), sample.TraceIdHigh)
+	assert.Equal(t, uint64(0xa3ce929d0e0e4736), sample.TraceIdLow)
+
+	// The child passes the full trace ID and the tracestate on to
+	// the next hop, with itself as the parent.
+	next := http.Header{}
+	assert.NoError(t, tracer.InjectHeader(span.Trace, next))
+	assert.Equal(t, fmt.Sprintf("00-4bf92f3577b34da6a3ce929d0e0e4736-%016x-01", span.SpanID),
+		next.Get("traceparent"))
+	assert.Equal(t, "congo=t61rcWkgMzE,rojo=00f067aa0ba902b7", next.Get("tracestate"))
+
+	// Children started through the OpenTracing API keep them too.
+	child := tracer.StartSpan("child", opentracing.ChildOf(span.context())).(*Span)
+	assert.Equal(t, span.TraceID, child.TraceID)
+	assert.Equal(t, span.TraceIDHigh, child.TraceIDHigh)
+	assert.Equal(t, span.TraceIDLow, child.TraceIDLow)
+	assert.Equal(t, span.TraceState, child.TraceState)
+}
+
+func TestTraceInjectHeaderW3C(t *testing.T) {
+	trace := DummySpan().Trace
+	tracer := Tracer{}
+
+	h := http.Header{}
+	assert.NoError(t, tracer.InjectHeader(trace, h))
+	assert.Equal(t, fmt.Sprintf("00-0000000000000000%016x-%016x-01", trace.TraceID, trace.SpanID),
+		h.Get("traceparent"), "veneur's own traces have a 64-bit ID")
+	assert.Empty(t, h.Get("tracestate"))
+
+	// W3C-native tracers only read traceparent
+	c, err := tracer.Extract(opentracing.HTTPHeaders,
+		opentracing.HTTPHeadersCarrier(http.Header{"Traceparent": h["Traceparent"]}))
+	assert.NoError(t, err)
+	ctx := c.(*spanContext)
+	assert.Equal(t, trace.TraceID, ctx.TraceID())
+	assert.Equal(t, trace.SpanID, ctx.SpanID())
+}
+
+func TestTraceExtractHeaderPrefersNearerParent(t *testing.T) {
+	tracer := Tracer{}
+	tm := textMapReaderWriter(map[string]string{
+		"traceparent":       "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"ot-tracer-traceid": "23ce929d0e0e4736",
+		"ot-tracer-spanid":  "10932",
+	})
+
+	c, err := tracer.Extract(opentracing.TextMap, tm)
+	assert.NoError(t, err)
+
+	ctx := c.(*spanContext)
+	assert.Equal(t, int64(0x23ce929d0e0e4736), ctx.TraceID())
+	assert.Equal(t, int64(67890), ctx.SpanID(), "Envoy's span ID should win")
+	high, low := ctx.FullTraceID()
+	assert.Equal(t, uint64(0x4bf92f3577b34da6), high, "the full trace ID should still be used")
+	assert.Equal(t, uint64(0xa3ce929d0e0e4736), low)
+}
+
+func TestParseTraceparent(t *testing.T) {
+	high, low, spanID, ok := parseTraceparent("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(0x0af7651916cd43dd), high)
+	assert.Equal(t, uint64(0x8448eb211c80319c), low)
+	assert.Equal(t, int64(0x37ad6b7169203331), spanID, "the top bit of the span ID is dropped")
+
+	_, _, _, ok = parseTraceparent("01-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01-future")
+	assert.True(t, ok, "later versions may add fields")
+
+	for _, invalid := range []string{
+		"",
+		"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331",
+		"ff-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+		"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01-extra",
+		"00-00000000000000000000000000000000-b7ad6b7169203331-01",
+		"00-0af7651916cd43dd8448eb211c80319c-0000000000000000-01",
+		"00-0af7651916cd43dd8448eb211c80319-b7ad6b7169203331-01",
+		"00-0af7651916cd43dd8448eb211c80319x-b7ad6b7169203331-01",
+	} {
+		_, _, _, ok := parseTraceparent(invalid)
+		assert.False(t, ok, "%q should be rejected", invalid)
+	}
+}
@@ -61,6 +61,17 @@ type Trace struct {
 	// For the root span, this will be <= 0
 	ParentID int64
 
+	// TraceIDHigh and TraceIDLow hold the full 128-bit ID of a
+	// trace that was continued from a W3C traceparent header. They
+	// are only set if the ID doesn't fit in TraceID, which then
+	// holds its low 63 bits.
+	TraceIDHigh uint64
+	TraceIDLow  uint64
+
+	// TraceState is the W3C tracestate header of the trace, which
+	// is propagated to its children unchanged.
+	TraceState string
+
 	// The Resource should be the same for all spans in the same trace
 	Resource string
 
@@ -149,6 +160,8 @@ func (t *Trace) SSFSpan() *ssf.SSFSpan {
 		TraceId:        t.TraceID,
 		Id:             t.SpanID,
 		ParentId:       t.ParentID,
+		TraceIdHigh:    t.TraceIDHigh,
+		TraceIdLow:     t.TraceIDLow,
 		EndTimestamp:   t.End.UnixNano(),
 		Name:           name,
 		Tags:           t.Tags,
@@ -293,9 +306,41 @@ func StartSpanFromContext(ctx context.Context, name string, opts ...opentracing.
 func (t *Trace) SetParent(parent *Trace) {
 	t.ParentID = parent.SpanID
 	t.TraceID = parent.TraceID
+	t.TraceIDHigh = parent.TraceIDHigh
+	t.TraceIDLow = parent.TraceIDLow
+	t.TraceState = parent.TraceState
 	t.Resource = parent.Resource
 }
 
+// setFullTraceID sets the trace's ID from a 128-bit trace ID, in the
+// same way as ssf.SSFSpan's SetFullTraceID.
+func (t *Trace) setFullTraceID(high, low uint64) {
+	span := ssf.SSFSpan{}
+	span.SetFullTraceID(high, low)
+	t.TraceID, t.TraceIDHigh, t.TraceIDLow = span.TraceId, span.TraceIdHigh, span.TraceIdLow
+}
+
+// setFullTraceContext copies the 128-bit trace ID and the tracestate
+// of a spanContext to the trace, if it has them.
+func (t *Trace) setFullTraceContext(c *spanContext) {
+	if high, low := c.FullTraceID(); high != 0 || low != 0 {
+		t.setFullTraceID(high, low)
+	}
+	t.TraceState = c.TraceState()
+}
+
+// addTraceContext adds the trace's 128-bit ID and tracestate, if it
+// has them, to the baggage of a spanContext.
+func (t *Trace) addTraceContext(c *spanContext) {
+	if t.TraceIDHigh != 0 || t.TraceIDLow != 0 {
+		c.baggageItems["traceidhigh"] = strconv.FormatUint(t.TraceIDHigh, 10)
+		c.baggageItems["traceidlow"] = strconv.FormatUint(t.TraceIDLow, 10)
+	}
+	if t.TraceState != "" {
+		c.baggageItems["tracestate"] = t.TraceState
+	}
+}
+
 // context returns a spanContext representing the trace
 // from the point of view of itself .
 // (The parentid for the trace will be set as the parentid for the context)
@@ -307,6 +352,7 @@ func (t *Trace) context() *spanContext {
 	c.baggageItems["parentid"] = strconv.FormatInt(t.ParentID, 10)
 	c.baggageItems["spanid"] = strconv.FormatInt(t.SpanID, 10)
 	c.baggageItems[ResourceKey] = t.Resource
+	t.addTraceContext(c)
 	return c
 }
 
@@ -320,6 +366,7 @@ func (t *Trace) contextAsParent() *spanContext {
 	c.baggageItems["traceid"] = strconv.FormatInt(t.TraceID, 10)
 	c.baggageItems["parentid"] = strconv.FormatInt(t.SpanID, 10)
 	c.baggageItems[ResourceKey] = t.Resource
+	t.addTraceContext(c)
 	return c
 }
 
@@ -26,6 +26,7 @@ const histogramTypeName = "histogram"
 const setTypeName = "set"
 const timerTypeName = "timer"
 const statusTypeName = "status"
+const distributionTypeName = "distribution"
 
 // Worker is the doodad that does work.
 type Worker struct {
@@ -44,6 +45,8 @@ type Worker struct {
 	traceClient           *trace.Client
 	logger                *logrus.Logger
 	wm                    WorkerMetrics
+	sketches              samplers.SketchSelector
+	limiter               *cardinalityLimiter
 	stats                 scopedstatsd.Client
 }
 
@@ -75,12 +78,19 @@ type WorkerMetrics struct {
 	// Instead, everything is forwarded.
 	globalHistograms map[samplers.MetricKey]*samplers.Histo
 	globalTimers     map[samplers.MetricKey]*samplers.Histo
+	// distributions are always global, and are flushed as digests to the
+	// sinks that accept them.
+	distributions map[samplers.MetricKey]*samplers.Histo
 
 	// these are used for metrics that shouldn't be forwarded
 	localHistograms   map[samplers.MetricKey]*samplers.Histo
 	localSets         map[samplers.MetricKey]*samplers.Set
 	localTimers       map[samplers.MetricKey]*samplers.Histo
 	localStatusChecks map[samplers.MetricKey]*samplers.StatusCheck
+
+	// sketches chooses the sketch backing new histograms, timers and
+	// distributions
+	sketches samplers.SketchSelector
 }
 
 // NewWorkerMetrics initializes a WorkerMetrics struct
@@ -91,6 +101,7 @@ func NewWorkerMetrics() WorkerMetrics {
 		globalGauges:      map[samplers.MetricKey]*samplers.Gauge{},
 		globalHistograms:  map[samplers.MetricKey]*samplers.Histo{},
 		globalTimers:      map[samplers.MetricKey]*samplers.Histo{},
+		distributions:     map[samplers.MetricKey]*samplers.Histo{},
 		gauges:            map[samplers.MetricKey]*samplers.Gauge{},
 		histograms:        map[samplers.MetricKey]*samplers.Histo{},
 		sets:              map[samplers.MetricKey]*samplers.Set{},
@@ -131,15 +142,15 @@ func (wm WorkerMetrics) Upsert(mk samplers.MetricKey, Scope samplers.MetricScope
 	case histogramTypeName:
 		if Scope == samplers.LocalOnly {
 			if _, present = wm.localHistograms[mk]; !present {
-				wm.localHistograms[mk] = samplers.NewHist(mk.Name, tags)
+				wm.localHistograms[mk] = wm.newHist(mk.Name, tags)
 			}
 		} else if Scope == samplers.GlobalOnly {
 			if _, present = wm.globalHistograms[mk]; !present {
-				wm.globalHistograms[mk] = samplers.NewHist(mk.Name, tags)
+				wm.globalHistograms[mk] = wm.newHist(mk.Name, tags)
 			}
 		} else {
 			if _, present = wm.histograms[mk]; !present {
-				wm.histograms[mk] = samplers.NewHist(mk.Name, tags)
+				wm.histograms[mk] = wm.newHist(mk.Name, tags)
 			}
 		}
 	case setTypeName:
@@ -155,17 +166,21 @@ func (wm WorkerMetrics) Upsert(mk samplers.MetricKey, Scope samplers.MetricScope
 	case timerTypeName:
 		if Scope == samplers.LocalOnly {
 			if _, present = wm.localTimers[mk]; !present {
-				wm.localTimers[mk] = samplers.NewHist(mk.Name, tags)
+				wm.localTimers[mk] = wm.newHist(mk.Name, tags)
 			}
 		} else if Scope == samplers.GlobalOnly {
 			if _, present = wm.globalTimers[mk]; !present {
-				wm.globalTimers[mk] = samplers.NewHist(mk.Name, tags)
+				wm.globalTimers[mk] = wm.newHist(mk.Name, tags)
 			}
 		} else {
 			if _, present = wm.timers[mk]; !present {
-				wm.timers[mk] = samplers.NewHist(mk.Name, tags)
+				wm.timers[mk] = wm.newHist(mk.Name, tags)
 			}
 		}
+	case distributionTypeName:
+		if _, present = wm.distributions[mk]; !present {
+			wm.distributions[mk] = wm.newHist(mk.Name, tags)
+		}
 	case statusTypeName:
 		if _, present = wm.localStatusChecks[mk]; !present {
 			wm.localStatusChecks[mk] = samplers.NewStatusCheck(mk.Name, tags)
@@ -176,11 +191,15 @@ func (wm WorkerMetrics) Upsert(mk samplers.MetricKey, Scope samplers.MetricScope
 	return !present
 }
 
+func (wm WorkerMetrics) newHist(name string, tags []string) *samplers.Histo {
+	return samplers.NewHistWithSketch(name, tags, wm.sketches.For(name))
+}
+
 // ForwardableMetrics converts all metrics that should be forwarded to
 // metricpb.Metric (protobuf-compatible).
 func (wm WorkerMetrics) ForwardableMetrics(cl *trace.Client) []*metricpb.Metric {
 	bufLen := len(wm.histograms) + len(wm.sets) + len(wm.timers) +
-		len(wm.globalCounters) + len(wm.globalGauges)
+		len(wm.globalCounters) + len(wm.globalGauges) + len(wm.distributions)
 
 	metrics := make([]*metricpb.Metric, 0, bufLen)
 	for _, count := range wm.globalCounters {
@@ -204,10 +223,69 @@ func (wm WorkerMetrics) ForwardableMetrics(cl *trace.Client) []*metricpb.Metric
 	for _, histo := range wm.globalTimers {
 		metrics = wm.appendExportedMetric(metrics, histo, metricpb.Type_Timer, cl, samplers.GlobalOnly)
 	}
+	for _, histo := range wm.distributions {
+		metrics = wm.appendExportedMetric(metrics, histo, metricpb.Type_Distribution, cl, samplers.GlobalOnly)
+	}
 
 	return metrics
 }
 
+// handOff removes the forwardable metrics that owner says moved to another
+// veneur from wm, and returns them converted to metricpb.Metric, by the
+// veneur they moved to.
+func (wm WorkerMetrics) handOff(owner func(samplers.MetricKey) (string, bool), cl *trace.Client) map[string][]*metricpb.Metric {
+	moved := map[string][]*metricpb.Metric{}
+	for key, count := range wm.globalCounters {
+		if dest, ok := owner(key); ok {
+			moved[dest] = wm.appendExportedMetric(moved[dest], count, metricpb.Type_Counter, cl, samplers.GlobalOnly)
+			delete(wm.globalCounters, key)
+		}
+	}
+	for key, gauge := range wm.globalGauges {
+		if dest, ok := owner(key); ok {
+			moved[dest] = wm.appendExportedMetric(moved[dest], gauge, metricpb.Type_Gauge, cl, samplers.GlobalOnly)
+			delete(wm.globalGauges, key)
+		}
+	}
+	for key, histo := range wm.histograms {
+		if dest, ok := owner(key); ok {
+			moved[dest] = wm.appendExportedMetric(moved[dest], histo, metricpb.Type_Histogram, cl, samplers.MixedScope)
+			delete(wm.histograms, key)
+		}
+	}
+	for key, histo := range wm.globalHistograms {
+		if dest, ok := owner(key); ok {
+			moved[dest] = wm.appendExportedMetric(moved[dest], histo, metricpb.Type_Histogram, cl, samplers.GlobalOnly)
+			delete(wm.globalHistograms, key)
+		}
+	}
+	for key, set := range wm.sets {
+		if dest, ok := owner(key); ok {
+			moved[dest] = wm.appendExportedMetric(moved[dest], set, metricpb.Type_Set, cl, samplers.MixedScope)
+			delete(wm.sets, key)
+		}
+	}
+	for key, timer := range wm.timers {
+		if dest, ok := owner(key); ok {
+			moved[dest] = wm.appendExportedMetric(moved[dest], timer, metricpb.Type_Timer, cl, samplers.MixedScope)
+			delete(wm.timers, key)
+		}
+	}
+	for key, timer := range wm.globalTimers {
+		if dest, ok := owner(key); ok {
+			moved[dest] = wm.appendExportedMetric(moved[dest], timer, metricpb.Type_Timer, cl, samplers.GlobalOnly)
+			delete(wm.globalTimers, key)
+		}
+	}
+	for key, histo := range wm.distributions {
+		if dest, ok := owner(key); ok {
+			moved[dest] = wm.appendExportedMetric(moved[dest], histo, metricpb.Type_Distribution, cl, samplers.GlobalOnly)
+			delete(wm.distributions, key)
+		}
+	}
+	return moved
+}
+
 // A type implemented by all valid samplers
 type metricExporter interface {
 	GetName() string
@@ -286,6 +364,28 @@ func (w *Worker) Work() {
 	}
 }
 
+// SetSketchSelector chooses the sketch backing the histograms, timers and
+// distributions that the worker creates from now on.
+func (w *Worker) SetSketchSelector(sketches samplers.SketchSelector) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.sketches = sketches
+	w.wm.sketches = sketches
+}
+
+// SetCardinalityLimits sets the budgets on the number of timeseries the
+// worker creates in each interval. Metrics beyond the budgets are dropped
+// or collapsed, and counted in veneur.cardinality.limited.
+func (w *Worker) SetCardinalityLimits(limits []CardinalityLimit) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if len(limits) == 0 {
+		w.limiter = nil
+		return
+	}
+	w.limiter = newCardinalityLimiter(limits)
+}
+
 // MetricsProcessedCount is a convenince method for testing
 // that allows us to fetch the Worker's processed count
 // in a non-racey way.
@@ -333,6 +433,8 @@ func (w *Worker) SampleTimeseries(m *samplers.UDPMetric) {
 		if m.Scope == samplers.LocalOnly {
 			w.uniqueMTS.Insert(digest)
 		}
+	case distributionTypeName:
+		// distributions are always forwarded
 	case statusTypeName:
 		w.uniqueMTS.Insert(digest)
 	default:
@@ -345,46 +447,52 @@ func (w *Worker) ProcessMetric(m *samplers.UDPMetric) {
 	w.mutex.Lock()
 	defer w.mutex.Unlock()
 	w.processed++
-	w.wm.Upsert(m.MetricKey, m.Scope, m.Tags)
+	key, tags, ok := w.limiter.admit(m.MetricKey, m.Tags)
+	if !ok {
+		return
+	}
+	w.wm.Upsert(key, m.Scope, tags)
 
 	switch m.Type {
 	case counterTypeName:
 		if m.Scope == samplers.GlobalOnly {
-			w.wm.globalCounters[m.MetricKey].Sample(m.Value.(float64), m.SampleRate)
+			w.wm.globalCounters[key].Sample(m.Value.(float64), m.SampleRate)
 		} else {
-			w.wm.counters[m.MetricKey].Sample(m.Value.(float64), m.SampleRate)
+			w.wm.counters[key].Sample(m.Value.(float64), m.SampleRate)
 		}
 	case gaugeTypeName:
 		if m.Scope == samplers.GlobalOnly {
-			w.wm.globalGauges[m.MetricKey].Sample(m.Value.(float64), m.SampleRate)
+			w.wm.globalGauges[key].Sample(m.Value.(float64), m.SampleRate)
 		} else {
-			w.wm.gauges[m.MetricKey].Sample(m.Value.(float64), m.SampleRate)
+			w.wm.gauges[key].Sample(m.Value.(float64), m.SampleRate)
 		}
 	case histogramTypeName:
 		if m.Scope == samplers.LocalOnly {
-			w.wm.localHistograms[m.MetricKey].Sample(m.Value.(float64), m.SampleRate)
+			w.wm.localHistograms[key].Sample(m.Value.(float64), m.SampleRate)
 		} else if m.Scope == samplers.GlobalOnly {
-			w.wm.globalHistograms[m.MetricKey].Sample(m.Value.(float64), m.SampleRate)
+			w.wm.globalHistograms[key].Sample(m.Value.(float64), m.SampleRate)
 		} else {
-			w.wm.histograms[m.MetricKey].Sample(m.Value.(float64), m.SampleRate)
+			w.wm.histograms[key].Sample(m.Value.(float64), m.SampleRate)
 		}
 	case setTypeName:
 		if m.Scope == samplers.LocalOnly {
-			w.wm.localSets[m.MetricKey].Sample(m.Value.(string))
+			w.wm.localSets[key].Sample(m.Value.(string))
 		} else {
-			w.wm.sets[m.MetricKey].Sample(m.Value.(string))
+			w.wm.sets[key].Sample(m.Value.(string))
 		}
 	case timerTypeName:
 		if m.Scope == samplers.LocalOnly {
-			w.wm.localTimers[m.MetricKey].Sample(m.Value.(float64), m.SampleRate)
+			w.wm.localTimers[key].Sample(m.Value.(float64), m.SampleRate)
 		} else if m.Scope == samplers.GlobalOnly {
-			w.wm.globalTimers[m.MetricKey].Sample(m.Value.(float64), m.SampleRate)
+			w.wm.globalTimers[key].Sample(m.Value.(float64), m.SampleRate)
 		} else {
-			w.wm.timers[m.MetricKey].Sample(m.Value.(float64), m.SampleRate)
+			w.wm.timers[key].Sample(m.Value.(float64), m.SampleRate)
 		}
+	case distributionTypeName:
+		w.wm.distributions[key].Sample(m.Value.(float64), m.SampleRate)
 	case statusTypeName:
 		v := float64(m.Value.(ssf.SSFSample_Status))
-		w.wm.localStatusChecks[m.MetricKey].Sample(v, m.SampleRate, m.Message, m.HostName)
+		w.wm.localStatusChecks[key].Sample(v, m.SampleRate, m.Message, m.HostName)
 	default:
 		log.WithField("type", m.Type).Error("Unknown metric type for processing")
 	}
@@ -398,34 +506,42 @@ func (w *Worker) ImportMetric(other samplers.JSONMetric) {
 	// we don't increment the processed metric counter here, it was already
 	// counted by the original veneur that sent this to us
 	w.imported++
-	if other.Type == counterTypeName || other.Type == gaugeTypeName {
+	key, tags, ok := w.limiter.admit(other.MetricKey, other.Tags)
+	if !ok {
+		return
+	}
+	if other.Type == counterTypeName || other.Type == gaugeTypeName || other.Type == distributionTypeName {
 		// this is an odd special case -- counters that are imported are global
-		w.wm.Upsert(other.MetricKey, samplers.GlobalOnly, other.Tags)
+		w.wm.Upsert(key, samplers.GlobalOnly, tags)
 	} else {
-		w.wm.Upsert(other.MetricKey, samplers.MixedScope, other.Tags)
+		w.wm.Upsert(key, samplers.MixedScope, tags)
 	}
 
 	switch other.Type {
 	case counterTypeName:
-		if err := w.wm.globalCounters[other.MetricKey].Combine(other.Value); err != nil {
+		if err := w.wm.globalCounters[key].Combine(other.Value); err != nil {
 			log.WithError(err).Error("Could not merge counters")
 		}
 	case gaugeTypeName:
-		if err := w.wm.globalGauges[other.MetricKey].Combine(other.Value); err != nil {
+		if err := w.wm.globalGauges[key].Combine(other.Value); err != nil {
 			log.WithError(err).Error("Could not merge gauges")
 		}
 	case setTypeName:
-		if err := w.wm.sets[other.MetricKey].Combine(other.Value); err != nil {
+		if err := w.wm.sets[key].Combine(other.Value); err != nil {
 			log.WithError(err).Error("Could not merge sets")
 		}
 	case histogramTypeName:
-		if err := w.wm.histograms[other.MetricKey].Combine(other.Value); err != nil {
+		if err := w.wm.histograms[key].CombineSketch(other.Sketch, other.Value); err != nil {
 			log.WithError(err).Error("Could not merge histograms")
 		}
 	case timerTypeName:
-		if err := w.wm.timers[other.MetricKey].Combine(other.Value); err != nil {
+		if err := w.wm.timers[key].CombineSketch(other.Sketch, other.Value); err != nil {
 			log.WithError(err).Error("Could not merge timers")
 		}
+	case distributionTypeName:
+		if err := w.wm.distributions[key].CombineSketch(other.Sketch, other.Value); err != nil {
+			log.WithError(err).Error("Could not merge distributions")
+		}
 	default:
 		log.WithField("type", other.Type).Error("Unknown metric type for importing")
 	}
@@ -442,7 +558,7 @@ func (w *Worker) ImportMetricGRPC(other *metricpb.Metric) (err error) {
 	key := samplers.NewMetricKeyFromMetric(other)
 
 	scope := samplers.ScopeFromPB(other.Scope)
-	if other.Type == metricpb.Type_Counter || other.Type == metricpb.Type_Gauge {
+	if other.Type == metricpb.Type_Counter || other.Type == metricpb.Type_Gauge || other.Type == metricpb.Type_Distribution {
 		scope = samplers.GlobalOnly
 	}
 
@@ -450,8 +566,12 @@ func (w *Worker) ImportMetricGRPC(other *metricpb.Metric) (err error) {
 		return fmt.Errorf("gRPC import does not accept local metrics")
 	}
 
-	w.wm.Upsert(key, scope, other.Tags)
 	w.imported++
+	key, tags, ok := w.limiter.admit(key, other.Tags)
+	if !ok {
+		return nil
+	}
+	w.wm.Upsert(key, scope, tags)
 
 	switch v := other.GetValue().(type) {
 	case *metricpb.Metric_Counter:
@@ -476,6 +596,8 @@ func (w *Worker) ImportMetricGRPC(other *metricpb.Metric) (err error) {
 			} else if other.Scope == metricpb.Scope_Global {
 				w.wm.globalTimers[key].Merge(v.Histogram)
 			}
+		case metricpb.Type_Distribution:
+			w.wm.distributions[key].Merge(v.Histogram)
 		}
 	case nil:
 		err = errors.New("Can't import a metric with a nil value")
@@ -500,11 +622,17 @@ func (w *Worker) Flush() WorkerMetrics {
 	// mutex is held! So we try and minimize it by copying the maps of values
 	// and assigning new ones.
 	wm := NewWorkerMetrics()
+	wm.sketches = w.sketches
 	w.mutex.Lock()
 	ret := w.wm
 	processed := w.processed
 	imported := w.imported
 
+	var limited map[string]int64
+	if w.limiter != nil {
+		limited = w.limiter.reset()
+	}
+
 	w.wm = wm
 	w.processed = 0
 	w.imported = 0
@@ -512,6 +640,9 @@ func (w *Worker) Flush() WorkerMetrics {
 
 	w.stats.Count("worker.metrics_processed_total", processed, []string{}, 1.0)
 	w.stats.Count("worker.metrics_imported_total", imported, []string{}, 1.0)
+	for name, count := range limited {
+		w.stats.Count("cardinality.limited", count, []string{"metric_name:" + name}, 1.0)
+	}
 
 	return ret
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stripe/veneur/ddsketch"
 	"github.com/stripe/veneur/sinks"
 	"github.com/stripe/veneur/ssf"
 	"github.com/stripe/veneur/trace"
@@ -16,6 +17,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stripe/veneur/samplers"
 	"github.com/stripe/veneur/samplers/metricpb"
+	"github.com/stripe/veneur/tdigest"
 )
 
 func TestWorker(t *testing.T) {
@@ -122,6 +124,70 @@ func TestWorkerImportHistogram(t *testing.T) {
 	assert.Len(t, wm.histograms, 1, "number of flushed histograms")
 }
 
+func TestWorkerDistribution(t *testing.T) {
+	w := NewWorker(1, true, false, nil, logrus.New(), nil)
+
+	m, err := samplers.ParseMetric([]byte("a.b.c:1|d|#foo:bar"))
+	require.NoError(t, err)
+	w.ProcessMetric(m)
+
+	jsonMetric, err := samplers.NewHist("a.b.c", []string{"foo:bar"}).Export()
+	require.NoError(t, err)
+	jsonMetric.Type = distributionTypeName
+	jsonMetric.MetricKey = m.MetricKey
+	w.ImportMetric(jsonMetric)
+
+	wm := w.Flush()
+	assert.Len(t, wm.distributions, 1, "imported and sampled distributions should aggregate together")
+	assert.Empty(t, wm.histograms)
+	assert.Empty(t, wm.globalHistograms)
+
+	forwarded := wm.ForwardableMetrics(nil)
+	require.Len(t, forwarded, 1)
+	assert.Equal(t, metricpb.Type_Distribution, forwarded[0].Type)
+	assert.Equal(t, metricpb.Scope_Global, forwarded[0].Scope)
+}
+
+func TestWorkerSketchSelector(t *testing.T) {
+	w := NewWorker(1, true, false, nil, logrus.New(), nil)
+	w.SetSketchSelector(samplers.SketchSelector{
+		Prefixes: map[string]samplers.SketchType{"api.": samplers.SketchDDSketch},
+	})
+	process := func() {
+		for _, line := range []string{"api.latency:1|h", "db.latency:1|ms"} {
+			m, err := samplers.ParseMetric([]byte(line))
+			require.NoError(t, err)
+			w.ProcessMetric(m)
+		}
+	}
+
+	process()
+	// a t-digest forwarded by a veneur that hasn't switched yet:
+	jsonMetric, err := samplers.NewHist("api.latency", nil).Export()
+	require.NoError(t, err)
+	jsonMetric.Type = "histogram"
+	jsonMetric.MetricKey = samplers.MetricKey{Name: "api.latency", Type: "histogram"}
+	w.ImportMetric(jsonMetric)
+
+	wm := w.Flush()
+	require.Len(t, wm.histograms, 1)
+	require.Len(t, wm.timers, 1)
+	for _, h := range wm.histograms {
+		assert.IsType(t, &ddsketch.DDSketch{}, h.Value)
+		assert.Equal(t, float64(1), h.Value.Count())
+	}
+	for _, h := range wm.timers {
+		assert.IsType(t, &tdigest.MergingDigest{}, h.Value)
+	}
+
+	// the selection should survive flushes:
+	process()
+	wm = w.Flush()
+	for _, h := range wm.histograms {
+		assert.IsType(t, &ddsketch.DDSketch{}, h.Value)
+	}
+}
+
 func TestWorkerStatusMetric(t *testing.T) {
 	w := NewWorker(1, true, false, nil, logrus.New(), nil)
 
@@ -314,6 +380,22 @@ func TestWorkerImportMetricGRPC(t *testing.T) {
 		assert.Len(t, w.Flush().timers, 1, "The number of flushed "+
 			"timers is not correct")
 	})
+	t.Run("distribution", func(t *testing.T) {
+		t.Parallel()
+		w := NewWorker(1, true, false, nil, logrus.New(), nil)
+		h := samplers.NewHist("test.distribution", nil)
+		h.Sample(1.0, 1.0)
+
+		m, err := h.Metric()
+		assert.NoErrorf(t, err, "exporting the histogram shouldn't have failed")
+		m.Type = metricpb.Type_Distribution
+		m.Scope = metricpb.Scope_Mixed
+
+		assert.NoError(t, w.ImportMetricGRPC(m), "importing a distribution "+
+			"shouldn't have failed")
+		assert.Len(t, w.Flush().distributions, 1, "The number of flushed "+
+			"distributions is not correct")
+	})
 	t.Run("set", func(t *testing.T) {
 		t.Parallel()
 		s := samplers.NewSet("test.set", nil)
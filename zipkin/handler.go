@@ -0,0 +1,138 @@
+package zipkin
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sirupsen/logrus"
+
+	"github.com/stripe/veneur/ssf"
+	"github.com/stripe/veneur/trace"
+	"github.com/stripe/veneur/zipkin/zipkinpb"
+)
+
+// SpansPath is the path that Zipkin reporters post spans to.
+const SpansPath = "/api/v2/spans"
+
+// DefaultMaxBodyBytes is the size of the largest list of spans that a
+// handler accepts if NewHandler's maxBodyBytes is zero.
+const DefaultMaxBodyBytes = 4 << 20
+
+var zipkinTags = map[string]string{"protocol": "zipkin"}
+
+var errBodyTooLarge = errors.New("zipkin: spans are larger than the limit")
+
+// SpanIngester receives spans that were translated from Zipkin.
+type SpanIngester interface {
+	IngestSpan(*ssf.SSFSpan)
+}
+
+type handler struct {
+	ingester     SpanIngester
+	maxBodyBytes int64
+	traceClient  *trace.Client
+	log          *logrus.Logger
+}
+
+// NewHandler returns an HTTP handler that implements Zipkin's v2 span
+// API: it accepts POSTs of span lists to SpansPath, as JSON or, with the
+// application/x-protobuf content type, as a zipkinpb.ListOfSpans, and
+// hands each span to the ingester. Bodies may be gzipped. Lists larger
+// than maxBodyBytes, before or after they're decompressed, are rejected.
+func NewHandler(ingester SpanIngester, maxBodyBytes int64, cl *trace.Client, log *logrus.Logger) http.Handler {
+	if maxBodyBytes <= 0 {
+		maxBodyBytes = DefaultMaxBodyBytes
+	}
+	mux := http.NewServeMux()
+	mux.Handle(SpansPath, &handler{
+		ingester:     ingester,
+		maxBodyBytes: maxBodyBytes,
+		traceClient:  cl,
+		log:          log,
+	})
+	return mux
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "spans must be POSTed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	span, _ := trace.StartSpanFromContext(r.Context(), "veneur.opentracing.zipkin.handle_spans")
+	span.SetTag("protocol", "zipkin")
+	defer span.ClientFinish(h.traceClient)
+
+	spans, err := decodeSpans(w, r, h.maxBodyBytes)
+	if err != nil {
+		span.Error(err)
+		span.Add(ssf.Count("import.request_error_total", 1, zipkinTags))
+		h.log.WithError(err).Warn("Could not decode Zipkin spans")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var rejected int
+	for _, zs := range spans {
+		converted, err := ToSSF(zs)
+		if err != nil {
+			rejected++
+			h.log.WithError(err).WithField("id", zs.ID).Debug("Rejecting Zipkin span")
+			continue
+		}
+		h.ingester.IngestSpan(converted)
+	}
+
+	span.Add(
+		ssf.Count("import.spans_total", float32(len(spans)), zipkinTags),
+		ssf.Count("import.rejected_total", float32(rejected), zipkinTags),
+	)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// decodeSpans reads the spans in the body of a request, in the encoding
+// of its content type. Bodies larger than maxBytes, after they're
+// decompressed, are rejected.
+func decodeSpans(w http.ResponseWriter, r *http.Request, maxBytes int64) ([]Span, error) {
+	var body io.Reader = http.MaxBytesReader(w, r.Body, maxBytes)
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		body = gz
+	}
+
+	buf, err := ioutil.ReadAll(io.LimitReader(body, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(buf)) > maxBytes {
+		return nil, errBodyTooLarge
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "application/x-protobuf" {
+		var spans []Span
+		err := json.Unmarshal(buf, &spans)
+		return spans, err
+	}
+
+	var list zipkinpb.ListOfSpans
+	if err := proto.Unmarshal(buf, &list); err != nil {
+		return nil, err
+	}
+	spans := make([]Span, 0, len(list.Spans))
+	for _, span := range list.Spans {
+		spans = append(spans, FromProto(span))
+	}
+	return spans, nil
+}
@@ -0,0 +1,274 @@
+// Package zipkin converts between spans in Zipkin's v2 model and SSF, and
+// receives them on an HTTP handler that implements Zipkin's /api/v2/spans
+// endpoint, in either its JSON or its protobuf encoding.
+package zipkin
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/stripe/veneur/ssf"
+	"github.com/stripe/veneur/zipkin/zipkinpb"
+)
+
+const (
+	errorTag        = "error"
+	errorMessageTag = "error.msg"
+	spanKindTag     = "span.kind"
+	peerServiceTag  = "peer.service"
+)
+
+// Span is a span in Zipkin's v2 JSON model.
+type Span struct {
+	// TraceID is 16 or 32 lower-case hex characters.
+	TraceID string `json:"traceId"`
+	// ParentID and ID are 16 lower-case hex characters. ParentID is
+	// empty on root spans.
+	ParentID string `json:"parentId,omitempty"`
+	ID       string `json:"id"`
+	// Kind is CLIENT, SERVER, PRODUCER or CONSUMER, if it's set.
+	Kind string `json:"kind,omitempty"`
+	Name string `json:"name,omitempty"`
+	// Timestamp is the start of the span, and Duration how long it
+	// took, both in microseconds.
+	Timestamp      int64             `json:"timestamp,omitempty"`
+	Duration       int64             `json:"duration,omitempty"`
+	LocalEndpoint  *Endpoint         `json:"localEndpoint,omitempty"`
+	RemoteEndpoint *Endpoint         `json:"remoteEndpoint,omitempty"`
+	Annotations    []Annotation      `json:"annotations,omitempty"`
+	Tags           map[string]string `json:"tags,omitempty"`
+	Debug          bool              `json:"debug,omitempty"`
+	Shared         bool              `json:"shared,omitempty"`
+}
+
+// Endpoint is a node in the service graph.
+type Endpoint struct {
+	ServiceName string `json:"serviceName,omitempty"`
+	IPv4        string `json:"ipv4,omitempty"`
+	IPv6        string `json:"ipv6,omitempty"`
+	Port        int32  `json:"port,omitempty"`
+}
+
+// Annotation is an event in a span, with its timestamp in microseconds.
+type Annotation struct {
+	Timestamp int64  `json:"timestamp"`
+	Value     string `json:"value"`
+}
+
+// ToSSF converts a span to SSF. SSF span IDs are 63 bits wide, so only
+// the low-order bits of the span's IDs are kept, as they are for the
+// OTLP spans that veneur receives. 128-bit trace IDs are kept in full.
+//
+// The tags of the span are kept. A span is an error if it has an "error"
+// tag, whose value, unless it's empty or "true", becomes the "error.msg"
+// tag. The kind of the span and the service name of its remote endpoint
+// become the "span.kind" and "peer.service" tags.
+func ToSSF(span Span) (*ssf.SSFSpan, error) {
+	traceIDHigh, traceIDLow, err := parseTraceID(span.TraceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid traceId: %v", err)
+	}
+	id, err := parseID(span.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id: %v", err)
+	}
+	var parentID int64
+	if span.ParentID != "" {
+		parentID, err = parseID(span.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parentId: %v", err)
+		}
+	}
+	if span.Timestamp <= 0 {
+		return nil, errors.New("the span has no timestamp")
+	}
+
+	tags := make(map[string]string, len(span.Tags)+2)
+	isError := false
+	for k, v := range span.Tags {
+		if k == errorTag {
+			isError = true
+			if v != "" && v != "true" {
+				tags[errorMessageTag] = v
+			}
+			continue
+		}
+		tags[k] = v
+	}
+	if span.Kind != "" {
+		tags[spanKindTag] = strings.ToLower(span.Kind)
+	}
+	if span.RemoteEndpoint != nil && span.RemoteEndpoint.ServiceName != "" {
+		tags[peerServiceTag] = span.RemoteEndpoint.ServiceName
+	}
+
+	var service string
+	if span.LocalEndpoint != nil {
+		service = span.LocalEndpoint.ServiceName
+	}
+
+	start := span.Timestamp * 1000
+	ret := &ssf.SSFSpan{
+		Id:             id,
+		ParentId:       parentID,
+		StartTimestamp: start,
+		EndTimestamp:   start + span.Duration*1000,
+		Error:          isError,
+		Service:        service,
+		Name:           span.Name,
+		Tags:           tags,
+	}
+	ret.SetFullTraceID(traceIDHigh, traceIDLow)
+	return ret, nil
+}
+
+// FromSSF converts an SSF span to Zipkin's model, reversing ToSSF: the
+// "error.msg", "span.kind" and "peer.service" tags become the span's error
+// tag, kind and remote endpoint. IDs are encoded as 16 hex characters,
+// and 128-bit trace IDs as 32.
+// Zipkin's timestamps are in microseconds, so a span that took less than
+// one lasts one.
+func FromSSF(span *ssf.SSFSpan) Span {
+	ret := Span{
+		TraceID:   formatTraceID(span),
+		ID:        formatID(span.Id),
+		Name:      span.Name,
+		Timestamp: span.StartTimestamp / 1000,
+		Tags:      make(map[string]string, len(span.Tags)+1),
+	}
+	if span.ParentId != 0 {
+		ret.ParentID = formatID(span.ParentId)
+	}
+	if span.EndTimestamp > span.StartTimestamp {
+		ret.Duration = (span.EndTimestamp - span.StartTimestamp) / 1000
+		if ret.Duration == 0 {
+			ret.Duration = 1
+		}
+	}
+	if span.Service != "" {
+		ret.LocalEndpoint = &Endpoint{ServiceName: span.Service}
+	}
+
+	for k, v := range span.Tags {
+		switch k {
+		case spanKindTag:
+			switch kind := strings.ToUpper(v); kind {
+			case "CLIENT", "SERVER", "PRODUCER", "CONSUMER":
+				ret.Kind = kind
+				continue
+			}
+		case peerServiceTag:
+			ret.RemoteEndpoint = &Endpoint{ServiceName: v}
+			continue
+		case errorMessageTag:
+			if span.Error {
+				continue
+			}
+		}
+		ret.Tags[k] = v
+	}
+	if span.Error {
+		ret.Tags[errorTag] = "true"
+		if msg := span.Tags[errorMessageTag]; msg != "" {
+			ret.Tags[errorTag] = msg
+		}
+	}
+	return ret
+}
+
+// FromProto converts a span in Zipkin's protobuf encoding to the JSON
+// model.
+func FromProto(span *zipkinpb.Span) Span {
+	ret := Span{
+		TraceID:        fmt.Sprintf("%x", span.TraceId),
+		ID:             fmt.Sprintf("%x", span.Id),
+		Name:           span.Name,
+		Timestamp:      int64(span.Timestamp),
+		Duration:       int64(span.Duration),
+		LocalEndpoint:  endpointFromProto(span.LocalEndpoint),
+		RemoteEndpoint: endpointFromProto(span.RemoteEndpoint),
+		Tags:           span.Tags,
+		Debug:          span.Debug,
+		Shared:         span.Shared,
+	}
+	if len(span.ParentId) > 0 {
+		ret.ParentID = fmt.Sprintf("%x", span.ParentId)
+	}
+	if span.Kind != zipkinpb.Span_SPAN_KIND_UNSPECIFIED {
+		ret.Kind = span.Kind.String()
+	}
+	for _, a := range span.Annotations {
+		ret.Annotations = append(ret.Annotations, Annotation{
+			Timestamp: int64(a.Timestamp),
+			Value:     a.Value,
+		})
+	}
+	return ret
+}
+
+func endpointFromProto(e *zipkinpb.Endpoint) *Endpoint {
+	if e == nil {
+		return nil
+	}
+	ret := &Endpoint{ServiceName: e.ServiceName, Port: e.Port}
+	if len(e.Ipv4) == net.IPv4len {
+		ret.IPv4 = net.IP(e.Ipv4).String()
+	}
+	if len(e.Ipv6) == net.IPv6len {
+		ret.IPv6 = net.IP(e.Ipv6).String()
+	}
+	return ret
+}
+
+// formatID encodes a 64-bit ID as 16 lower-case hex characters.
+func formatID(id int64) string {
+	return fmt.Sprintf("%016x", uint64(id))
+}
+
+// formatTraceID encodes the trace ID of a span as 16 lower-case hex
+// characters, or 32 if it's a 128-bit ID.
+func formatTraceID(span *ssf.SSFSpan) string {
+	high, low := span.FullTraceID()
+	if high == 0 {
+		return fmt.Sprintf("%016x", low)
+	}
+	return fmt.Sprintf("%016x%016x", high, low)
+}
+
+// parseTraceID parses a hex-encoded Zipkin ID of up to 128 bits, and
+// returns its high and low 64 bits. It's an error if the low-order 63
+// bits are all zero, since SSF can't represent that.
+func parseTraceID(id string) (high, low uint64, err error) {
+	if len(id) == 0 || len(id) > 32 {
+		return 0, 0, fmt.Errorf("%q must be 1 to 32 hex characters", id)
+	}
+	if len(id) > 16 {
+		high, err = strconv.ParseUint(id[:len(id)-16], 16, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+		id = id[len(id)-16:]
+	}
+	low, err = strconv.ParseUint(id, 16, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	if low&math.MaxInt64 == 0 {
+		return 0, 0, fmt.Errorf("%q is zero", id)
+	}
+	return high, low, nil
+}
+
+// parseID parses a hex-encoded Zipkin ID of up to 128 bits, and returns
+// its low-order 63 bits. It's an error if those are all zero.
+func parseID(id string) (int64, error) {
+	_, low, err := parseTraceID(id)
+	if err != nil {
+		return 0, err
+	}
+	return int64(low & math.MaxInt64), nil
+}
@@ -0,0 +1,201 @@
+package zipkin
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/veneur/ssf"
+	"github.com/stripe/veneur/trace"
+	"github.com/stripe/veneur/zipkin/zipkinpb"
+)
+
+type recordingIngester struct {
+	mtx   sync.Mutex
+	spans []*ssf.SSFSpan
+}
+
+func (ri *recordingIngester) IngestSpan(span *ssf.SSFSpan) {
+	ri.mtx.Lock()
+	defer ri.mtx.Unlock()
+	ri.spans = append(ri.spans, span)
+}
+
+func TestToSSF(t *testing.T) {
+	span, err := ToSSF(Span{
+		TraceID:        "463ac35c9f6413ad48485a3953bb6124",
+		ParentID:       "0000000000000001",
+		ID:             "a2fb4a1d1a96d312",
+		Kind:           "CLIENT",
+		Name:           "get /api",
+		Timestamp:      1472470996199000,
+		Duration:       207000,
+		LocalEndpoint:  &Endpoint{ServiceName: "frontend"},
+		RemoteEndpoint: &Endpoint{ServiceName: "backend"},
+		Tags:           map[string]string{"http.path": "/api", "error": "timed out"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(0x48485a3953bb6124), span.TraceId, "trace_id holds the low bits of the trace ID")
+	assert.Equal(t, uint64(0x463ac35c9f6413ad), span.TraceIdHigh)
+	assert.Equal(t, uint64(0x48485a3953bb6124), span.TraceIdLow)
+	assert.Equal(t, int64(1), span.ParentId)
+	assert.Equal(t, int64(0x22fb4a1d1a96d312), span.Id, "the top bit of the ID is dropped")
+	assert.Equal(t, int64(1472470996199000000), span.StartTimestamp)
+	assert.Equal(t, int64(1472470996406000000), span.EndTimestamp)
+	assert.Equal(t, "frontend", span.Service)
+	assert.Equal(t, "get /api", span.Name)
+	assert.True(t, span.Error)
+	assert.Equal(t, map[string]string{
+		"http.path":    "/api",
+		"error.msg":    "timed out",
+		"span.kind":    "client",
+		"peer.service": "backend",
+	}, span.Tags)
+
+	for _, invalid := range []Span{
+		{TraceID: "", ID: "1", Timestamp: 1},
+		{TraceID: "1", ID: "xyz", Timestamp: 1},
+		{TraceID: "1", ID: "0000000000000000", Timestamp: 1},
+		{TraceID: "1", ID: "1", ParentID: "not hex", Timestamp: 1},
+		{TraceID: "1", ID: "1"},
+	} {
+		_, err := ToSSF(invalid)
+		assert.Error(t, err, "%#v should be rejected", invalid)
+	}
+}
+
+func TestFromProto(t *testing.T) {
+	span := FromProto(&zipkinpb.Span{
+		TraceId:        []byte{0x46, 0x3a, 0xc3, 0x5c, 0x9f, 0x64, 0x13, 0xad},
+		Id:             []byte{0, 0, 0, 0, 0, 0, 0, 2},
+		ParentId:       []byte{0, 0, 0, 0, 0, 0, 0, 1},
+		Kind:           zipkinpb.Span_SERVER,
+		Timestamp:      10,
+		Duration:       5,
+		LocalEndpoint:  &zipkinpb.Endpoint{ServiceName: "backend", Ipv4: []byte{10, 0, 0, 1}},
+		RemoteEndpoint: &zipkinpb.Endpoint{Ipv6: make([]byte, 16)},
+	})
+	assert.Equal(t, Span{
+		TraceID:        "463ac35c9f6413ad",
+		ID:             "0000000000000002",
+		ParentID:       "0000000000000001",
+		Kind:           "SERVER",
+		Timestamp:      10,
+		Duration:       5,
+		LocalEndpoint:  &Endpoint{ServiceName: "backend", IPv4: "10.0.0.1"},
+		RemoteEndpoint: &Endpoint{IPv6: "::"},
+	}, span)
+}
+
+func TestHandler(t *testing.T) {
+	ingester := &recordingIngester{}
+	srv := httptest.NewServer(NewHandler(ingester, 1024, trace.DefaultClient, logrus.New()))
+	defer srv.Close()
+
+	const body = `[
+		{"traceId": "1", "id": "1", "name": "root", "timestamp": 1, "duration": 2},
+		{"traceId": "1", "id": "2", "parentId": "1", "name": "child", "timestamp": 1},
+		{"traceId": "1", "name": "no id", "timestamp": 1}
+	]`
+	res, err := http.Post(srv.URL+SpansPath, "application/json", bytes.NewBufferString(body))
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusAccepted, res.StatusCode)
+	require.Len(t, ingester.spans, 2, "the span without an ID should be rejected")
+	assert.Equal(t, int64(1), ingester.spans[1].ParentId)
+
+	// protobuf, gzipped
+	buf, err := proto.Marshal(&zipkinpb.ListOfSpans{Spans: []*zipkinpb.Span{{
+		TraceId:   []byte{0, 0, 0, 0, 0, 0, 0, 3},
+		Id:        []byte{0, 0, 0, 0, 0, 0, 0, 3},
+		Name:      "proto",
+		Timestamp: 1,
+	}}})
+	require.NoError(t, err)
+	var gzipped bytes.Buffer
+	gz := gzip.NewWriter(&gzipped)
+	_, err = gz.Write(buf)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	req, err := http.NewRequest(http.MethodPost, srv.URL+SpansPath, &gzipped)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", "gzip")
+	res, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusAccepted, res.StatusCode)
+	require.Len(t, ingester.spans, 3)
+	assert.Equal(t, "proto", ingester.spans[2].Name)
+	assert.Equal(t, int64(3), ingester.spans[2].TraceId)
+
+	res, err = http.Post(srv.URL+SpansPath, "application/json", bytes.NewBufferString("{"))
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	// a small gzipped body that decompresses to more than the limit
+	gzipped.Reset()
+	gz = gzip.NewWriter(&gzipped)
+	_, err = gz.Write([]byte("["))
+	require.NoError(t, err)
+	_, err = gz.Write(bytes.Repeat([]byte(" "), 64<<10))
+	require.NoError(t, err)
+	_, err = gz.Write([]byte("]"))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	require.True(t, gzipped.Len() < 1024)
+	req, err = http.NewRequest(http.MethodPost, srv.URL+SpansPath, &gzipped)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "gzip")
+	res, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode, "decompressed bodies larger than the limit should be rejected")
+
+	res, err = http.Post(srv.URL+SpansPath, "application/json", bytes.NewReader(make([]byte, 1025)))
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode, "bodies larger than the limit should be rejected")
+	assert.Len(t, ingester.spans, 3)
+
+	res, err = http.Get(srv.URL + SpansPath)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+}
+
+func TestFromSSF(t *testing.T) {
+	span := FromSSF(&ssf.SSFSpan{
+		TraceId:        1,
+		Id:             0x7fffffffffffffff,
+		StartTimestamp: 1500,
+		EndTimestamp:   1800,
+		Name:           "quick",
+		Error:          true,
+		Tags:           map[string]string{"span.kind": "internal"},
+	})
+	assert.Equal(t, Span{
+		TraceID:   "0000000000000001",
+		ID:        "7fffffffffffffff",
+		Name:      "quick",
+		Timestamp: 1,
+		Duration:  1,
+		Tags:      map[string]string{"span.kind": "internal", "error": "true"},
+	}, span, "Zipkin has no internal kind, and spans take at least a microsecond")
+
+	wide := &ssf.SSFSpan{Id: 1}
+	wide.SetFullTraceID(0x463ac35c9f6413ad, 0xc8485a3953bb6124)
+	assert.Equal(t, "463ac35c9f6413adc8485a3953bb6124", FromSSF(wide).TraceID)
+}